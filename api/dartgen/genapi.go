@@ -11,21 +11,21 @@ const apiTemplate = `import 'api.dart';
 import '../data/{{with .Info}}{{.Title}}{{end}}.dart';
 {{with .Service}}
 /// {{.Name}}
-{{range .Routes}}
-/// --{{.Path}}--
+{{range $group := .Groups}}{{range .Routes}}
+/// --{{$group.JoinPrefix .Path}}--
 ///
 /// 请求: {{with .RequestType}}{{.Name}}{{end}}
 /// 返回: {{with .ResponseType}}{{.Name}}{{end}}
-Future {{pathToFuncName .Path}}( {{if ne .Method "get"}}{{with .RequestType}}{{.Name}} request,{{end}}{{end}}
+Future {{pathToFuncName ($group.JoinPrefix .Path)}}( {{if ne .Method "get"}}{{with .RequestType}}{{.Name}} request,{{end}}{{end}}
     {Function({{with .ResponseType}}{{.Name}}{{end}}) ok,
     Function(String) fail,
     Function eventually}) async {
-  await api{{if eq .Method "get"}}Get{{else}}Post{{end}}('{{.Path}}',{{if ne .Method "get"}}request,{{end}}
+  await api{{if eq .Method "get"}}Get{{else}}Post{{end}}('{{$group.JoinPrefix .Path}}',{{if ne .Method "get"}}request,{{end}}
   	 ok: (data) {
     if (ok != null) ok({{with .ResponseType}}{{.Name}}{{end}}.fromJson(data));
   }, fail: fail, eventually: eventually);
 }
-{{end}}
+{{end}}{{end}}
 {{end}}`
 
 func genApi(dir string, api *spec.ApiSpec) error {
@@ -8,27 +8,39 @@ import (
 )
 
 const dataTemplate = `// --{{with .Info}}{{.Title}}{{end}}--
-{{ range .Types}}
+{{ range .Types}}{{if isAliasType .}}{{else if isEnumType .}}{{$enum := .Name}}
+enum {{.Name}} { {{range .Members}}
+	{{lowCamelCase .Name}},{{end}}
+}
+
+extension {{.Name}}Value on {{.Name}} {
+	{{dartType .Value}} get value => const { {{range .Members}}
+		{{$enum}}.{{lowCamelCase .Name}}: {{.Value}},{{end}}
+	}[this];
+}
+
+{{.Name}} {{enumFromJson .Name}}(dynamic v) => {{.Name}}.values.firstWhere((e) => e.value == v);
+{{else}}
 class {{.Name}}{
 	{{range .Members}}
 	/// {{.Comment}}
-	final {{.Type}} {{lowCamelCase .Name}};
+	final {{dartType .Type}} {{lowCamelCase .Name}};
 	{{end}}
 	{{.Name}}({ {{range .Members}}
 		this.{{lowCamelCase .Name}},{{end}}
 	});
 	factory {{.Name}}.fromJson(Map<String,dynamic> m) {
-		return {{.Name}}({{range .Members}}
-			{{lowCamelCase .Name}}: {{if isDirectType .Type}}m['{{tagGet .Tag "json"}}']{{else if isClassListType .Type}}(m['{{tagGet .Tag "json"}}'] as List<dynamic>).map((i) => {{getCoreType .Type}}.fromJson(i)){{else}}{{.Type}}.fromJson(m['{{tagGet .Tag "json"}}']){{end}},{{end}}
+		return {{.Name}}({{range .Members}}{{$type := dartType .Type}}
+			{{lowCamelCase .Name}}: {{if isEnumType .Type}}{{enumFromJson $type}}(m['{{tagGet .Tag "json"}}']){{else if isEnumListType .Type}}(m['{{tagGet .Tag "json"}}'] as List<dynamic>).map((i) => {{enumFromJson (getCoreType $type)}}(i)).toList(){{else if isDirectType $type}}m['{{tagGet .Tag "json"}}']{{else if isClassListType $type}}(m['{{tagGet .Tag "json"}}'] as List<dynamic>).map((i) => {{getCoreType $type}}.fromJson(i)){{else}}{{$type}}.fromJson(m['{{tagGet .Tag "json"}}']){{end}},{{end}}
 		);
 	}
 	Map<String,dynamic> toJson() {
-		return { {{range .Members}}
-			'{{tagGet .Tag "json"}}': {{if isDirectType .Type}}{{lowCamelCase .Name}}{{else if isClassListType .Type}}{{lowCamelCase .Name}}.map((i) => i.toJson()){{else}}{{lowCamelCase .Name}}.toJson(){{end}},{{end}}
+		return { {{range .Members}}{{$type := dartType .Type}}
+			'{{tagGet .Tag "json"}}': {{if isEnumType .Type}}{{lowCamelCase .Name}}.value{{else if isEnumListType .Type}}{{lowCamelCase .Name}}.map((i) => i.value).toList(){{else if isDirectType $type}}{{lowCamelCase .Name}}{{else if isClassListType $type}}{{lowCamelCase .Name}}.map((i) => i.toJson()){{else}}{{lowCamelCase .Name}}.toJson(){{end}},{{end}}
 		};
 	}
 }
-{{end}}
+{{end}}{{end}}
 `
 
 func genData(dir string, api *spec.ApiSpec) error {
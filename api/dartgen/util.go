@@ -78,6 +78,69 @@ func getCoreType(s string) string {
 	return s
 }
 
+func dartType(tp spec.Type) string {
+	switch v := tp.(type) {
+	case spec.PrimitiveType:
+		switch v.RawName {
+		case "string":
+			return "String"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+			return "int"
+		case "float32", "float64":
+			return "double"
+		case "bool":
+			return "bool"
+		}
+	case spec.ArrayType:
+		return "List<" + dartType(v.Value) + ">"
+	case spec.MapType:
+		return "Map<String," + dartType(v.Value) + ">"
+	case spec.PointerType:
+		return dartType(v.Type)
+	case spec.AliasType:
+		// dart classes refer to the underlying type of the alias
+		return dartType(v.Value)
+	case spec.InterfaceType:
+		return "dynamic"
+	}
+
+	return tp.Name()
+}
+
+func isAliasType(tp spec.Type) bool {
+	_, ok := tp.(spec.AliasType)
+	return ok
+}
+
+func isEnumType(tp spec.Type) bool {
+	switch v := tp.(type) {
+	case spec.EnumType:
+		return true
+	case spec.PointerType:
+		return isEnumType(v.Type)
+	case spec.AliasType:
+		return isEnumType(v.Value)
+	}
+
+	return false
+}
+
+func isEnumListType(tp spec.Type) bool {
+	switch v := tp.(type) {
+	case spec.ArrayType:
+		return isEnumType(v.Value)
+	case spec.AliasType:
+		return isEnumListType(v.Value)
+	}
+
+	return false
+}
+
+// enumFromJson returns the name of the function which converts a json value to the enum
+func enumFromJson(name string) string {
+	return lowCamelCase(name) + "FromJson"
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
@@ -9,6 +9,11 @@ var funcMap = template.FuncMap{
 	"getCoreType":     getCoreType,
 	"pathToFuncName":  pathToFuncName,
 	"lowCamelCase":    lowCamelCase,
+	"dartType":        dartType,
+	"isAliasType":     isAliasType,
+	"isEnumType":      isEnumType,
+	"isEnumListType":  isEnumListType,
+	"enumFromJson":    enumFromJson,
 }
 
 const (
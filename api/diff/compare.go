@@ -0,0 +1,491 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weitrue/goctl/api/spec"
+)
+
+const (
+	sideRequest  = "request"
+	sideResponse = "response"
+)
+
+// the kinds of change
+const (
+	RouteAdded       = "route-added"
+	RouteRemoved     = "route-removed"
+	RouteRenamed     = "route-renamed"
+	MethodChanged    = "method-changed"
+	RequestRemoved   = "request-removed"
+	ResponseRemoved  = "response-removed"
+	FieldAdded       = "field-added"
+	FieldRemoved     = "field-removed"
+	FieldRenamed     = "field-renamed"
+	FieldRequired    = "field-required"
+	TypeChanged      = "type-changed"
+	EnumValueAdded   = "enum-value-added"
+	EnumValueRemoved = "enum-value-removed"
+)
+
+var propertyTagKeys = []string{"json", "form", "path", "header"}
+
+type (
+	// Change describes a difference between two api versions
+	Change struct {
+		Kind     string `json:"kind"`
+		Breaking bool   `json:"breaking"`
+		// Route is the route which the change affects, such as GET /users/:id
+		Route string `json:"route"`
+		// Field is the path of the field, such as response.profile.name
+		Field   string `json:"field,omitempty"`
+		Message string `json:"message"`
+	}
+
+	routeEntry struct {
+		service string
+		method  string
+		path    string
+		route   spec.Route
+		matched bool
+	}
+
+	property struct {
+		member   spec.Member
+		key      string
+		name     string
+		optional bool
+	}
+
+	comparator struct {
+		oldTypes map[string]spec.Type
+		newTypes map[string]spec.Type
+		changes  []Change
+		route    string
+		visited  map[string]bool
+	}
+)
+
+// Compare returns the changes from the old api to the new api, the routes are identified by
+// service, method and path, a route whose handler is kept is treated as renamed or method changed
+func Compare(oldApi, newApi *spec.ApiSpec) []Change {
+	c := &comparator{
+		oldTypes: typeMap(oldApi),
+		newTypes: typeMap(newApi),
+	}
+
+	oldRoutes, newRoutes := routeEntries(oldApi), routeEntries(newApi)
+	newIndex := make(map[string]*routeEntry)
+	for _, each := range newRoutes {
+		newIndex[each.key()] = each
+	}
+
+	for _, old := range oldRoutes {
+		if target, ok := newIndex[old.key()]; ok && !target.matched {
+			old.matched, target.matched = true, true
+			c.compareRoute(old, target)
+		}
+	}
+
+	for _, old := range oldRoutes {
+		if old.matched {
+			continue
+		}
+
+		target := findByHandler(newRoutes, old)
+		if target == nil {
+			c.add(Change{
+				Kind:     RouteRemoved,
+				Breaking: true,
+				Route:    old.String(),
+				Message:  fmt.Sprintf("route %s is removed", old),
+			})
+			continue
+		}
+
+		old.matched, target.matched = true, true
+		c.route = old.String()
+		if old.path == target.path {
+			c.add(Change{
+				Kind:     MethodChanged,
+				Breaking: true,
+				Message:  fmt.Sprintf("method is changed from %s to %s", old.method, target.method),
+			})
+		} else {
+			c.add(Change{
+				Kind:     RouteRenamed,
+				Breaking: true,
+				Message:  fmt.Sprintf("route is renamed to %s", target),
+			})
+		}
+		c.compareRoute(old, target)
+	}
+
+	for _, each := range newRoutes {
+		if !each.matched {
+			c.add(Change{
+				Kind:    RouteAdded,
+				Route:   each.String(),
+				Message: fmt.Sprintf("route %s is added", each),
+			})
+		}
+	}
+
+	return c.changes
+}
+
+// HasBreaking returns true if any of the changes is breaking
+func HasBreaking(changes []Change) bool {
+	return countBreaking(changes) > 0
+}
+
+func typeMap(api *spec.ApiSpec) map[string]spec.Type {
+	types := make(map[string]spec.Type)
+	for _, tp := range api.Types {
+		types[tp.Name()] = tp
+	}
+
+	return types
+}
+
+func routeEntries(api *spec.ApiSpec) []*routeEntry {
+	services := api.Services
+	if len(services) == 0 {
+		services = []spec.Service{api.Service}
+	}
+
+	var entries []*routeEntry
+	for _, service := range services {
+		for _, group := range service.Groups {
+			for _, route := range group.Routes {
+				entries = append(entries, &routeEntry{
+					service: service.Name,
+					method:  strings.ToUpper(route.Method),
+					path:    group.JoinPrefix(route.Path),
+					route:   route,
+				})
+			}
+		}
+	}
+
+	return entries
+}
+
+func findByHandler(entries []*routeEntry, target *routeEntry) *routeEntry {
+	for _, each := range entries {
+		if !each.matched && each.service == target.service && each.route.Handler == target.route.Handler {
+			return each
+		}
+	}
+
+	return nil
+}
+
+func (e *routeEntry) key() string {
+	return fmt.Sprintf("%s %s %s", e.service, e.method, e.path)
+}
+
+func (e *routeEntry) String() string {
+	return fmt.Sprintf("%s %s", e.method, e.path)
+}
+
+func (c *comparator) add(change Change) {
+	if len(change.Route) == 0 {
+		change.Route = c.route
+	}
+	c.changes = append(c.changes, change)
+}
+
+func (c *comparator) compareRoute(old, target *routeEntry) {
+	c.route = old.String()
+	c.visited = make(map[string]bool)
+
+	switch {
+	case old.route.RequestType != nil && target.route.RequestType != nil:
+		c.compareType(sideRequest, sideRequest, old.route.RequestType, target.route.RequestType)
+	case old.route.RequestType != nil:
+		c.add(Change{
+			Kind:    RequestRemoved,
+			Field:   sideRequest,
+			Message: "request body is removed, the fields sent by client are ignored",
+		})
+	case target.route.RequestType != nil:
+		c.compareType(sideRequest, sideRequest, spec.DefineStruct{}, target.route.RequestType)
+	}
+
+	switch {
+	case old.route.ResponseType != nil && target.route.ResponseType != nil:
+		c.compareType(sideResponse, sideResponse, old.route.ResponseType, target.route.ResponseType)
+	case old.route.ResponseType != nil:
+		c.add(Change{
+			Kind:     ResponseRemoved,
+			Breaking: true,
+			Field:    sideResponse,
+			Message:  "response body is removed",
+		})
+	}
+}
+
+func (c *comparator) compareType(side, field string, old, target spec.Type) {
+	old = c.underlying(old, c.oldTypes)
+	target = c.underlying(target, c.newTypes)
+
+	switch o := old.(type) {
+	case spec.DefineStruct:
+		if t, ok := target.(spec.DefineStruct); ok {
+			c.compareStruct(side, field, o, t)
+			return
+		}
+	case spec.ArrayType:
+		if t, ok := target.(spec.ArrayType); ok {
+			c.compareType(side, field+"[]", o.Value, t.Value)
+			return
+		}
+	case spec.MapType:
+		if t, ok := target.(spec.MapType); ok && o.Key == t.Key {
+			c.compareType(side, field+"{}", o.Value, t.Value)
+			return
+		}
+	case spec.EnumType:
+		switch t := target.(type) {
+		case spec.EnumType:
+			if o.Value.RawName == t.Value.RawName {
+				c.compareEnum(field, o, t)
+				return
+			}
+		case spec.PrimitiveType:
+			// the enum is relaxed to any value of the underlying type
+			if o.Value.RawName == t.RawName {
+				return
+			}
+		}
+	case spec.PrimitiveType:
+		switch t := target.(type) {
+		case spec.PrimitiveType:
+			if o.RawName == t.RawName {
+				return
+			}
+		case spec.EnumType:
+			if o.RawName == t.Value.RawName {
+				c.add(Change{
+					Kind:     TypeChanged,
+					Breaking: side == sideRequest,
+					Field:    field,
+					Message:  fmt.Sprintf("values are restricted to enum %s", t.Name()),
+				})
+				return
+			}
+		}
+	case spec.InterfaceType:
+		if _, ok := target.(spec.InterfaceType); ok {
+			return
+		}
+	}
+
+	c.add(Change{
+		Kind:     TypeChanged,
+		Breaking: true,
+		Field:    field,
+		Message:  fmt.Sprintf("type is changed from %s to %s", typeName(old), typeName(target)),
+	})
+}
+
+func (c *comparator) compareStruct(side, field string, old, target spec.DefineStruct) {
+	key := fmt.Sprintf("%s %s %s", field, old.Name(), target.Name())
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+
+	// the fields are matched by the names on the wire, renaming the golang field is compatible,
+	// the field whose wire name is changed but golang name is kept is reported as renamed.
+	oldProps, newProps := c.properties(old, c.oldTypes), c.properties(target, c.newTypes)
+	oldWires := make(map[string]bool)
+	for _, each := range oldProps {
+		oldWires[each.String()] = true
+	}
+
+	newIndex := make(map[string]property)
+	renamed := make(map[string]property)
+	for _, each := range newProps {
+		newIndex[each.String()] = each
+		if !oldWires[each.String()] {
+			renamed[each.member.Name] = each
+		}
+	}
+
+	matched := make(map[string]bool)
+	for _, o := range oldProps {
+		path := field + "." + o.name
+		t, ok := newIndex[o.String()]
+		if ok {
+			if o.member.Name != t.member.Name {
+				c.add(Change{
+					Kind:    FieldRenamed,
+					Field:   path,
+					Message: fmt.Sprintf("golang field %s is renamed to %s", o.member.Name, t.member.Name),
+				})
+			}
+		} else if t, ok = renamed[o.member.Name]; ok && !matched[t.String()] {
+			c.add(Change{
+				Kind:     FieldRenamed,
+				Breaking: true,
+				Field:    path,
+				Message:  fmt.Sprintf("field %s is renamed to %s", o, t),
+			})
+			path = field + "." + t.name
+		} else {
+			c.add(Change{
+				Kind:     FieldRemoved,
+				Breaking: side == sideResponse,
+				Field:    path,
+				Message:  fmt.Sprintf("field %s is removed", o),
+			})
+			continue
+		}
+		matched[t.String()] = true
+
+		if side == sideRequest && o.optional && !t.optional {
+			c.add(Change{
+				Kind:     FieldRequired,
+				Breaking: true,
+				Field:    path,
+				Message:  fmt.Sprintf("optional field %s becomes required", t),
+			})
+		}
+
+		c.compareType(side, path, o.member.Type, t.member.Type)
+	}
+
+	for _, t := range newProps {
+		if matched[t.String()] {
+			continue
+		}
+
+		breaking := side == sideRequest && !t.optional
+		message := fmt.Sprintf("field %s is added", t)
+		if breaking {
+			message = fmt.Sprintf("required field %s is added", t)
+		}
+		c.add(Change{
+			Kind:     FieldAdded,
+			Breaking: breaking,
+			Field:    field + "." + t.name,
+			Message:  message,
+		})
+	}
+}
+
+func (c *comparator) compareEnum(field string, old, target spec.EnumType) {
+	values := make(map[string]bool)
+	for _, each := range target.Members {
+		values[each.Value] = true
+	}
+
+	oldValues := make(map[string]bool)
+	for _, each := range old.Members {
+		oldValues[each.Value] = true
+		if !values[each.Value] {
+			c.add(Change{
+				Kind:     EnumValueRemoved,
+				Breaking: true,
+				Field:    field,
+				Message:  fmt.Sprintf("value %s of enum %s is removed", each.Value, old.Name()),
+			})
+		}
+	}
+
+	for _, each := range target.Members {
+		if !oldValues[each.Value] {
+			c.add(Change{
+				Kind:    EnumValueAdded,
+				Field:   field,
+				Message: fmt.Sprintf("value %s is added to enum %s", each.Value, target.Name()),
+			})
+		}
+	}
+}
+
+// properties returns the members which are encoded with a tag, the inline members are flattened
+func (c *comparator) properties(tp spec.DefineStruct, types map[string]spec.Type) []property {
+	var result []property
+	for _, member := range tp.Members {
+		if member.IsInline {
+			if inline, ok := c.underlying(member.Type, types).(spec.DefineStruct); ok {
+				result = append(result, c.properties(inline, types)...)
+			}
+			continue
+		}
+
+		tags, err := spec.Parse(member.Tag)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range propertyTagKeys {
+			tag, err := tags.Get(key)
+			if err != nil || tag.Name == "-" {
+				continue
+			}
+
+			p := property{
+				member: member,
+				key:    tag.Key,
+				name:   tag.Name,
+			}
+			if rule, err := member.GetValidationRule(); err == nil {
+				p.optional = key != "path" && (rule.Optional || rule.Default != nil)
+			}
+			result = append(result, p)
+			break
+		}
+	}
+
+	return result
+}
+
+// underlying resolves the declared types by name and unwraps the aliases and pointers,
+// since they are encoded the same as the underlying type
+func (c *comparator) underlying(tp spec.Type, types map[string]spec.Type) spec.Type {
+	for depth := 0; depth < 10; depth++ {
+		switch v := tp.(type) {
+		case spec.DefineStruct:
+			declared, ok := types[v.Name()]
+			if !ok {
+				return tp
+			}
+
+			tp = declared
+			if _, ok := tp.(spec.DefineStruct); ok {
+				return tp
+			}
+		case spec.EnumType:
+			if declared, ok := types[v.Name()]; ok {
+				return declared
+			}
+
+			return tp
+		case spec.AliasType:
+			tp = v.Value
+		case spec.PointerType:
+			tp = v.Type
+		default:
+			return tp
+		}
+	}
+
+	return tp
+}
+
+func (p property) String() string {
+	return fmt.Sprintf(`%s:"%s"`, p.key, p.name)
+}
+
+func typeName(tp spec.Type) string {
+	if tp == nil {
+		return "nil"
+	}
+
+	return tp.Name()
+}
@@ -0,0 +1,252 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/api/parser"
+)
+
+const oldApi = `
+type Status enum {
+	Active = 1
+	Banned = 2
+}
+
+type Profile {
+	Avatar string ` + "`json:\"avatar\"`" + `
+}
+
+type User {
+	Name    string  ` + "`json:\"name\"`" + `
+	Age     int32   ` + "`json:\"age\"`" + `
+	Email   string  ` + "`json:\"email\"`" + `
+	Status  Status  ` + "`json:\"status\"`" + `
+	Profile Profile ` + "`json:\"profile\"`" + `
+}
+
+type UpdateReq {
+	Id       int64  ` + "`path:\"id\"`" + `
+	Nickname string ` + "`json:\"nickname,optional\"`" + `
+	Remark   string ` + "`json:\"remark,optional\"`" + `
+}
+
+service user-api {
+	@handler getUser
+	get /users/:id returns (User)
+
+	@handler updateUser
+	put /users/:id (UpdateReq) returns (User)
+
+	@handler deleteUser
+	delete /users/:id
+
+	@handler listUsers
+	get /users returns ([]User)
+
+	@handler ping
+	get /ping
+}
+`
+
+const newApi = `
+type Status enum {
+	Active = 1
+	Deleted = 3
+}
+
+type Profile {
+	Avatar int64 ` + "`json:\"avatar\"`" + `
+}
+
+type User {
+	Name    string  ` + "`json:\"fullName\"`" + `
+	Age     int64   ` + "`json:\"age\"`" + `
+	Status  Status  ` + "`json:\"status\"`" + `
+	Profile Profile ` + "`json:\"profile\"`" + `
+	Phone   string  ` + "`json:\"phone\"`" + `
+}
+
+type UpdateReq {
+	Id       int64  ` + "`path:\"id\"`" + `
+	Nickname string ` + "`json:\"nickname\"`" + `
+	Tag      string ` + "`json:\"tag,optional\"`" + `
+}
+
+service user-api {
+	@handler getUser
+	get /users/:id returns (User)
+
+	@handler updateUser
+	post /users/:id (UpdateReq) returns (User)
+
+	@handler listUsers
+	get /users/list returns ([]User)
+
+	@handler ping
+	get /ping
+
+	@handler createUser
+	post /users (UpdateReq)
+}
+`
+
+func TestCompare(t *testing.T) {
+	oldSpec, err := parser.ParseContent(oldApi)
+	assert.Nil(t, err)
+	newSpec, err := parser.ParseContent(newApi)
+	assert.Nil(t, err)
+
+	changes := Compare(oldSpec, newSpec)
+	assert.True(t, HasBreaking(changes))
+
+	contains := func(expected Change) {
+		assert.Contains(t, changes, expected)
+	}
+	contains(Change{
+		Kind:     FieldRenamed,
+		Breaking: true,
+		Route:    "GET /users/:id",
+		Field:    "response.name",
+		Message:  `field json:"name" is renamed to json:"fullName"`,
+	})
+	contains(Change{
+		Kind:     TypeChanged,
+		Breaking: true,
+		Route:    "GET /users/:id",
+		Field:    "response.age",
+		Message:  "type is changed from int32 to int64",
+	})
+	contains(Change{
+		Kind:     FieldRemoved,
+		Breaking: true,
+		Route:    "GET /users/:id",
+		Field:    "response.email",
+		Message:  `field json:"email" is removed`,
+	})
+	contains(Change{
+		Kind:     EnumValueRemoved,
+		Breaking: true,
+		Route:    "GET /users/:id",
+		Field:    "response.status",
+		Message:  "value 2 of enum Status is removed",
+	})
+	contains(Change{
+		Kind:     TypeChanged,
+		Breaking: true,
+		Route:    "GET /users/:id",
+		Field:    "response.profile.avatar",
+		Message:  "type is changed from string to int64",
+	})
+	contains(Change{
+		Kind:    FieldAdded,
+		Route:   "GET /users/:id",
+		Field:   "response.phone",
+		Message: `field json:"phone" is added`,
+	})
+	contains(Change{
+		Kind:     MethodChanged,
+		Breaking: true,
+		Route:    "PUT /users/:id",
+		Message:  "method is changed from PUT to POST",
+	})
+	contains(Change{
+		Kind:     FieldRequired,
+		Breaking: true,
+		Route:    "PUT /users/:id",
+		Field:    "request.nickname",
+		Message:  `optional field json:"nickname" becomes required`,
+	})
+	contains(Change{
+		Kind:    FieldRemoved,
+		Route:   "PUT /users/:id",
+		Field:   "request.remark",
+		Message: `field json:"remark" is removed`,
+	})
+	contains(Change{
+		Kind:    FieldAdded,
+		Route:   "PUT /users/:id",
+		Field:   "request.tag",
+		Message: `field json:"tag" is added`,
+	})
+	contains(Change{
+		Kind:     RouteRemoved,
+		Breaking: true,
+		Route:    "DELETE /users/:id",
+		Message:  "route DELETE /users/:id is removed",
+	})
+	contains(Change{
+		Kind:     RouteRenamed,
+		Breaking: true,
+		Route:    "GET /users",
+		Message:  "route is renamed to GET /users/list",
+	})
+	contains(Change{
+		Kind:     TypeChanged,
+		Breaking: true,
+		Route:    "GET /users",
+		Field:    "response[].age",
+		Message:  "type is changed from int32 to int64",
+	})
+	contains(Change{
+		Kind:    RouteAdded,
+		Route:   "POST /users",
+		Message: "route POST /users is added",
+	})
+
+	for _, each := range changes {
+		assert.NotEqual(t, "GET /ping", each.Route)
+	}
+}
+
+func TestCompareSame(t *testing.T) {
+	oldSpec, err := parser.ParseContent(oldApi)
+	assert.Nil(t, err)
+	newSpec, err := parser.ParseContent(oldApi)
+	assert.Nil(t, err)
+
+	assert.Empty(t, Compare(oldSpec, newSpec))
+}
+
+func TestCompareGoFieldRenamed(t *testing.T) {
+	oldSpec, err := parser.ParseContent(`
+type User {
+	Name string ` + "`json:\"name\"`" + `
+	Mail string ` + "`json:\"mail\"`" + `
+}
+
+service user-api {
+	@handler getUser
+	get /users/:id returns (User)
+}
+`)
+	assert.Nil(t, err)
+	newSpec, err := parser.ParseContent(`
+type User {
+	FullName string ` + "`json:\"name\"`" + `
+	Mail     string ` + "`json:\"email\"`" + `
+}
+
+service user-api {
+	@handler getUser
+	get /users/:id returns (User)
+}
+`)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Change{
+		{
+			Kind:    FieldRenamed,
+			Route:   "GET /users/:id",
+			Field:   "response.name",
+			Message: "golang field Name is renamed to FullName",
+		},
+		{
+			Kind:     FieldRenamed,
+			Breaking: true,
+			Route:    "GET /users/:id",
+			Field:    "response.mail",
+			Message:  `field json:"mail" is renamed to json:"email"`,
+		},
+	}, Compare(oldSpec, newSpec))
+}
@@ -0,0 +1,102 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/api/parser"
+)
+
+// DiffCommand compares two versions of api file and reports the changes, it returns an error
+// if there are breaking changes, so that it can be used as a gate in code review
+func DiffCommand(c *cli.Context) error {
+	oldFile := c.String("old")
+	newFile := c.String("new")
+	if len(oldFile) == 0 {
+		return errors.New("missing -old")
+	}
+	if len(newFile) == 0 {
+		return errors.New("missing -new")
+	}
+
+	oldApi, err := parser.Parse(oldFile)
+	if err != nil {
+		return fmt.Errorf("%s: %w", oldFile, err)
+	}
+
+	newApi, err := parser.Parse(newFile)
+	if err != nil {
+		return fmt.Errorf("%s: %w", newFile, err)
+	}
+
+	changes := Compare(oldApi, newApi)
+	if c.Bool("json") {
+		err = writeJson(os.Stdout, changes)
+	} else {
+		err = writeText(os.Stdout, changes)
+	}
+	if err != nil {
+		return err
+	}
+
+	if breaking := countBreaking(changes); breaking > 0 {
+		return fmt.Errorf("found %d breaking changes", breaking)
+	}
+
+	return nil
+}
+
+func writeJson(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeText(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, aurora.Green("no changes"))
+		return err
+	}
+
+	for _, each := range changes {
+		level := aurora.Yellow("compatible")
+		if each.Breaking {
+			level = aurora.Red("breaking  ")
+		}
+
+		target := each.Route
+		if len(each.Field) > 0 {
+			target = fmt.Sprintf("%s %s", each.Route, each.Field)
+		}
+		if _, err := fmt.Fprintf(w, "%s %s: %s\n", level, target, each.Message); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d changes, %d breaking\n", len(changes), countBreaking(changes))
+	return err
+}
+
+func countBreaking(changes []Change) int {
+	var count int
+	for _, each := range changes {
+		if each.Breaking {
+			count++
+		}
+	}
+
+	return count
+}
@@ -41,45 +41,56 @@ func genDoc(api *spec.ApiSpec, dir, filename string) error {
 	defer fp.Close()
 
 	var builder strings.Builder
-	for index, route := range api.Service.Routes() {
-		routeComment := route.JoinedDoc()
-		if len(routeComment) == 0 {
-			routeComment = "N/A"
+	var index int
+	for _, group := range api.Service.Groups {
+		for _, route := range group.Routes {
+			index++
+			if err := writeRouteDoc(&builder, api, index, group.JoinPrefix(route.Path), route); err != nil {
+				return err
+			}
 		}
+	}
+	_, err = fp.WriteString(strings.Replace(builder.String(), "&#34;", `"`, -1))
+	return err
+}
 
-		requestContent, err := buildDoc(route.RequestType)
-		if err != nil {
-			return err
-		}
+func writeRouteDoc(builder *strings.Builder, api *spec.ApiSpec, index int, path string, route spec.Route) error {
+	routeComment := route.JoinedDoc()
+	if len(routeComment) == 0 {
+		routeComment = "N/A"
+	}
 
-		responseContent, err := buildDoc(route.ResponseType)
-		if err != nil {
-			return err
-		}
+	requestContent, err := buildDoc(route.RequestType, api.Types)
+	if err != nil {
+		return err
+	}
 
-		t := template.Must(template.New("markdownTemplate").Parse(markdownTemplate))
-		var tmplBytes bytes.Buffer
-		err = t.Execute(&tmplBytes, map[string]string{
-			"index":           strconv.Itoa(index + 1),
-			"routeComment":    routeComment,
-			"method":          strings.ToUpper(route.Method),
-			"uri":             route.Path,
-			"requestType":     "`" + stringx.TakeOne(route.RequestTypeName(), "-") + "`",
-			"responseType":    "`" + stringx.TakeOne(route.ResponseTypeName(), "-") + "`",
-			"requestContent":  requestContent,
-			"responseContent": responseContent,
-		})
-		if err != nil {
-			return err
-		}
+	responseContent, err := buildDoc(route.ResponseType, api.Types)
+	if err != nil {
+		return err
+	}
 
-		builder.Write(tmplBytes.Bytes())
+	t := template.Must(template.New("markdownTemplate").Parse(markdownTemplate))
+	var tmplBytes bytes.Buffer
+	err = t.Execute(&tmplBytes, map[string]string{
+		"index":           strconv.Itoa(index),
+		"routeComment":    routeComment,
+		"method":          strings.ToUpper(route.Method),
+		"uri":             path,
+		"requestType":     "`" + stringx.TakeOne(route.RequestTypeName(), "-") + "`",
+		"responseType":    "`" + stringx.TakeOne(route.ResponseTypeName(), "-") + "`",
+		"requestContent":  requestContent,
+		"responseContent": responseContent,
+	})
+	if err != nil {
+		return err
 	}
-	_, err = fp.WriteString(strings.Replace(builder.String(), "&#34;", `"`, -1))
-	return err
+
+	builder.Write(tmplBytes.Bytes())
+	return nil
 }
 
-func buildDoc(route spec.Type) (string, error) {
+func buildDoc(route spec.Type, types []spec.Type) (string, error) {
 	if route == nil || len(route.Name()) == 0 {
 		return "", nil
 	}
@@ -87,31 +98,72 @@ func buildDoc(route spec.Type) (string, error) {
 	tps := make([]spec.Type, 0)
 	tps = append(tps, route)
 	if definedType, ok := route.(spec.DefineStruct); ok {
-		associatedTypes(definedType, &tps)
+		for _, item := range definedType.Members {
+			associatedTypes(item.Type, types, &tps)
+		}
 	}
 	value, err := gogen.BuildTypes(tps)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("\n\n```golang\n%s\n```\n", value), nil
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\n\n```golang\n%s\n```\n", value))
+	for _, tp := range tps {
+		if enumType, ok := tp.(spec.EnumType); ok {
+			builder.WriteString(buildEnumValues(enumType))
+		}
+	}
+
+	return builder.String(), nil
 }
 
-func associatedTypes(tp spec.DefineStruct, tps *[]spec.Type) {
-	hasAdded := false
-	for _, item := range *tps {
-		if item.Name() == tp.Name() {
-			hasAdded = true
-			break
-		}
+func buildEnumValues(tp spec.EnumType) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\n`%s` 可选值:\n\n", tp.RawName))
+	builder.WriteString("| 名称 | 值 | 说明 |\n")
+	builder.WriteString("| --- | --- | --- |\n")
+	for _, member := range tp.Members {
+		comment := strings.TrimSpace(strings.TrimPrefix(member.Comment, "//"))
+		comment = strings.ReplaceAll(comment, "|", "\\|")
+		builder.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", member.Name, member.Value,
+			stringx.TakeOne(comment, "-")))
 	}
-	if !hasAdded {
+
+	return builder.String()
+}
+
+func associatedTypes(tp spec.Type, types []spec.Type, tps *[]spec.Type) {
+	switch v := tp.(type) {
+	case spec.DefineStruct, spec.AliasType, spec.EnumType:
+		for _, item := range *tps {
+			if item.Name() == tp.Name() {
+				return
+			}
+		}
+
+		// nested members only keep the type name, look up the declaration
+		for _, item := range types {
+			if item.Name() == tp.Name() {
+				tp = item
+				break
+			}
+		}
 		*tps = append(*tps, tp)
-	}
 
-	for _, item := range tp.Members {
-		if definedType, ok := item.Type.(spec.DefineStruct); ok {
-			associatedTypes(definedType, tps)
+		switch v := tp.(type) {
+		case spec.DefineStruct:
+			for _, item := range v.Members {
+				associatedTypes(item.Type, types, tps)
+			}
+		case spec.AliasType:
+			associatedTypes(v.Value, types, tps)
 		}
+	case spec.ArrayType:
+		associatedTypes(v.Value, types, tps)
+	case spec.MapType:
+		associatedTypes(v.Value, types, tps)
+	case spec.PointerType:
+		associatedTypes(v.Type, types, tps)
 	}
 }
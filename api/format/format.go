@@ -12,8 +12,10 @@ import (
 	"strings"
 
 	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/api/parser/g4/gen/api"
 	"github.com/weitrue/goctl/api/util"
 	ctlutil "github.com/weitrue/goctl/util"
+	"github.com/zeromicro/antlr"
 	"github.com/zeromicro/go-zero/core/errorx"
 )
 
@@ -100,7 +102,13 @@ func ApiFormatByPath(apiFilePath string) error {
 	// 	return err
 	// }
 
-	return ioutil.WriteFile(apiFilePath, []byte(result), os.ModePerm)
+	return ctlutil.WriteFile(apiFilePath, []byte(result), os.ModePerm)
+}
+
+// ApiFormatByContent formats the api content, it is used by the editor integrations
+// which hold the unsaved content
+func ApiFormatByContent(data string) (string, error) {
+	return apiFormat(data)
 }
 
 func apiFormat(data string, filename ...string) (string, error) {
@@ -164,17 +172,34 @@ func apiFormat(data string, filename ...string) (string, error) {
 func formatGoTypeDef(line string, scanner *bufio.Scanner, builder *strings.Builder) (bool, error) {
 	noCommentLine := util.RemoveComment(line)
 	tokenCount := 0
+	// the enum declaration is not golang syntax, leave it to the common indent way
+	if isEnumDecl(noCommentLine) {
+		return false, nil
+	}
+
 	if strings.HasPrefix(noCommentLine, "type") && (strings.HasSuffix(noCommentLine, leftParenthesis) ||
 		strings.HasSuffix(noCommentLine, leftBrace)) {
 		var typeBuilder strings.Builder
 		typeBuilder.WriteString(mayInsertStructKeyword(line, &tokenCount) + ctlutil.NL)
+		lines := []string{line}
+		containsEnum := false
 		for scanner.Scan() {
 			noCommentLine := util.RemoveComment(scanner.Text())
+			if isEnumDecl(noCommentLine) {
+				containsEnum = true
+			}
+
+			lines = append(lines, scanner.Text())
 			typeBuilder.WriteString(mayInsertStructKeyword(scanner.Text(), &tokenCount) + ctlutil.NL)
 			if noCommentLine == rightBrace || noCommentLine == rightParenthesis {
 				tokenCount--
 			}
 			if tokenCount == 0 {
+				if containsEnum {
+					formatEnumTypeBlock(lines, builder)
+					break
+				}
+
 				ts, err := format.Source([]byte(typeBuilder.String()))
 				if err != nil {
 					return false, errors.New("error format \n" + typeBuilder.String())
@@ -192,8 +217,52 @@ func formatGoTypeDef(line string, scanner *bufio.Scanner, builder *strings.Build
 	return false, nil
 }
 
+// formatEnumTypeBlock indents a type group which contains enum declarations,
+// gofmt can not be used here since the enum is not golang syntax.
+func formatEnumTypeBlock(lines []string, builder *strings.Builder) {
+	tapCount := 0
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		noCommentLine := util.RemoveComment(line)
+		if strings.HasPrefix(noCommentLine, rightBrace) || strings.HasPrefix(noCommentLine, rightParenthesis) {
+			tapCount--
+		}
+
+		if len(line) > 0 {
+			util.WriteIndent(builder, tapCount)
+		}
+		builder.WriteString(line + ctlutil.NL)
+		if strings.HasSuffix(noCommentLine, leftParenthesis) || strings.HasSuffix(noCommentLine, leftBrace) {
+			tapCount++
+		}
+	}
+}
+
+// isEnumDecl returns true if the line declares an enum like Foo enum {, the line is split by the api lexer
+// the same way as the parser
+func isEnumDecl(line string) bool {
+	lexer := api.NewApiParserLexer(antlr.NewInputStream(line))
+	lexer.RemoveErrorListeners()
+	var list []string
+	for _, token := range lexer.GetAllTokens() {
+		if token.GetChannel() == antlr.TokenDefaultChannel {
+			list = append(list, token.GetText())
+		}
+	}
+
+	if len(list) > 0 && list[0] == "type" {
+		list = list[1:]
+	}
+
+	return len(list) > 2 && list[1] == "enum" && list[2] == leftBrace
+}
+
 func mayInsertStructKeyword(line string, token *int) string {
 	insertStruct := func() string {
+		if isEnumDecl(line) {
+			return line
+		}
+
 		if strings.Contains(line, " struct") {
 			return line
 		}
@@ -41,3 +41,34 @@ func TestFormat(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, r, formattedStr)
 }
+
+const (
+	notFormattedEnumStr = `type Status enum {
+Pending = 1
+   Paid = 2
+}
+type (
+Color enum { Red = "red"; Green = "green" }
+  Request {
+Status Status ` + "`" + `json:"status"` + "`" + `
+}
+)`
+
+	formattedEnumStr = `type Status enum {
+	Pending = 1
+	Paid = 2
+}
+
+type (
+	Color enum { Red = "red"; Green = "green" }
+	Request {
+		Status Status ` + "`" + `json:"status"` + "`" + `
+	}
+)`
+)
+
+func TestFormatEnum(t *testing.T) {
+	r, err := apiFormat(notFormattedEnumStr)
+	assert.Nil(t, err)
+	assert.Equal(t, formattedEnumStr, r)
+}
@@ -0,0 +1,340 @@
+package goclientgen
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/urfave/cli"
+
+	"github.com/weitrue/goctl/api/gogen"
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/spec"
+	"github.com/weitrue/goctl/util"
+)
+
+const (
+	pathTagKey     = "path"
+	headerTagKey   = "header"
+	optionalOption = "optional"
+)
+
+type (
+	// route is the data of a client method
+	route struct {
+		Name         string
+		Doc          string
+		Method       string
+		Pattern      string
+		Path         string
+		Request      string
+		HasRequest   bool
+		Validate     bool
+		Response     string
+		HasResponse  bool
+		ResponseRef  bool
+		Query        []param
+		Headers      []param
+		Body         []bodyField
+		BodyWhole    bool
+		PathHasParam bool
+	}
+
+	// param is a member which is encoded into the query or header, the optional members
+	// are omitted if Check is false
+	param struct {
+		Key   string
+		Field string
+		Check string
+	}
+
+	bodyField struct {
+		Field string
+		Type  string
+		Tag   string
+	}
+)
+
+// GoClientCommand generates a typed go client package for the services of api file
+func GoClientCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	dir := c.String("dir")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+	if len(dir) == 0 {
+		return errors.New("missing -dir")
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	if err = DoGenClient(api, dir); err != nil {
+		return err
+	}
+
+	fmt.Println(aurora.Green("Done."))
+	return nil
+}
+
+// DoGenClient generates the client package into dir, the package name is the base of dir
+func DoGenClient(api *spec.ApiSpec, dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	if err = util.MkdirIfNotExist(abs); err != nil {
+		return err
+	}
+
+	pkg := packageName(abs)
+	if err = genTypes(abs, pkg, api); err != nil {
+		return err
+	}
+
+	if err = util.With("client").GoFmt(true).Parse(clientTemplate).SaveTo(map[string]string{
+		"pkg": pkg,
+	}, filepath.Join(abs, "client.go"), true); err != nil {
+		return err
+	}
+
+	routes, err := buildRoutes(api)
+	if err != nil {
+		return err
+	}
+
+	return util.With("routes").GoFmt(true).Parse(routesTemplate).SaveTo(map[string]interface{}{
+		"pkg":     pkg,
+		"imports": genImports(routes),
+		"routes":  routes,
+	}, filepath.Join(abs, "routes.go"), true)
+}
+
+func genTypes(dir, pkg string, api *spec.ApiSpec) error {
+	types, err := gogen.BuildTypes(api.Types)
+	if err != nil {
+		return err
+	}
+
+	validations, err := gogen.BuildValidations(api)
+	if err != nil {
+		return err
+	}
+
+	return util.With("types").GoFmt(true).Parse(typesTemplate).SaveTo(map[string]interface{}{
+		"pkg":                pkg,
+		"types":              types,
+		"validations":        validations,
+		"containsValidation": strings.Contains(validations, "fmt."),
+	}, filepath.Join(dir, "types.go"), true)
+}
+
+func buildRoutes(api *spec.ApiSpec) ([]route, error) {
+	structs := make(map[string]spec.DefineStruct)
+	for _, tp := range api.Types {
+		if v, ok := tp.(spec.DefineStruct); ok {
+			structs[v.Name()] = v
+		}
+	}
+
+	var routes []route
+	for _, group := range api.Service.Groups {
+		for _, r := range group.Routes {
+			item, err := buildRoute(structs, group, r)
+			if err != nil {
+				return nil, err
+			}
+
+			routes = append(routes, item)
+		}
+	}
+
+	return routes, nil
+}
+
+func buildRoute(structs map[string]spec.DefineStruct, group spec.Group, r spec.Route) (route, error) {
+	name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(r.Handler), "Handler"), "handler")
+	result := route{
+		Name:    util.Title(name),
+		Doc:     strings.Trim(strings.TrimSpace(r.JoinedDoc()), `"`),
+		Method:  strings.ToUpper(r.Method),
+		Pattern: group.JoinPrefix(r.Path),
+	}
+
+	if r.ResponseType != nil {
+		result.HasResponse = true
+		result.Response = util.Title(r.ResponseType.Name())
+		if _, ok := r.ResponseType.(spec.DefineStruct); ok {
+			result.ResponseRef = true
+		}
+	}
+
+	paths := make(map[string]string)
+	if r.RequestType != nil {
+		result.HasRequest = true
+		result.Request = util.Title(r.RequestType.Name())
+		request, ok := r.RequestType.(spec.DefineStruct)
+		if ok {
+			result.Request = "*" + result.Request
+			result.Validate = true
+			if err := collectMembers(structs, request, paths, &result); err != nil {
+				return result, err
+			}
+		} else {
+			result.BodyWhole = true
+		}
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(result.Pattern, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			segments = append(segments, segment)
+			continue
+		}
+
+		field, ok := paths[segment[1:]]
+		if !ok {
+			return result, fmt.Errorf("route %s %s: missing the path member %s", r.Method, r.Path, segment)
+		}
+
+		result.PathHasParam = true
+		segments = append(segments, fmt.Sprintf(`" + url.PathEscape(formatValue(req.%s)) + "`, field))
+	}
+	result.Path = `"` + strings.Join(segments, "/") + `"`
+	result.Path = strings.TrimSuffix(result.Path, ` + ""`)
+
+	return result, nil
+}
+
+// collectMembers dispatches the members by their tags, the members of inline structures are
+// promoted, so they are accessed by the name directly
+func collectMembers(structs map[string]spec.DefineStruct, tp spec.DefineStruct, paths map[string]string,
+	result *route) error {
+	for _, member := range tp.Members {
+		if member.IsInline {
+			inline, ok := structs[member.Type.Name()]
+			if !ok {
+				return fmt.Errorf("inline type %s is not a structure", member.Type.Name())
+			}
+
+			if err := collectMembers(structs, inline, paths, result); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tags, err := spec.Parse(member.Tag)
+		if err != nil {
+			return err
+		}
+
+		if tag, err := tags.Get(pathTagKey); err == nil {
+			paths[tag.Name] = member.Name
+		}
+		if tag, err := tags.Get(headerTagKey); err == nil {
+			result.Headers = append(result.Headers, param{
+				Key:   tag.Name,
+				Field: member.Name,
+				Check: nonZeroCheck(member, tag),
+			})
+		}
+		if member.IsFormMember() {
+			tag, _ := tags.Get("form")
+			result.Query = append(result.Query, param{
+				Key:   tag.Name,
+				Field: member.Name,
+				Check: nonZeroCheck(member, tag),
+			})
+		}
+		if member.IsBodyMember() {
+			// the options of go-zero are dropped, and the unset optional members are omitted, since
+			// the server checks the ranges and the options of all the present keys
+			tag, _ := tags.Get("json")
+			name := tag.Name
+			if isOptional(tag) {
+				name += ",omitempty"
+			}
+			result.Body = append(result.Body, bodyField{
+				Field: member.Name,
+				Type:  member.Type.Name(),
+				Tag:   fmt.Sprintf("`json:%q`", name),
+			})
+		}
+	}
+
+	return nil
+}
+
+// nonZeroCheck returns the condition to send the optional member, the empty slices are never sent
+func nonZeroCheck(member spec.Member, tag *spec.Tag) string {
+	field := "req." + member.Name
+	switch v := member.Type.(type) {
+	case spec.ArrayType:
+		return fmt.Sprintf("len(%s) > 0", field)
+	case spec.PrimitiveType:
+		if !isOptional(tag) {
+			return ""
+		}
+
+		switch {
+		case v.RawName == "string":
+			return fmt.Sprintf("len(%s) > 0", field)
+		case v.RawName == "bool":
+			return field
+		case strings.HasPrefix(v.RawName, "int"), strings.HasPrefix(v.RawName, "uint"),
+			strings.HasPrefix(v.RawName, "float"), v.RawName == "byte", v.RawName == "rune":
+			return field + " != 0"
+		}
+	}
+
+	return ""
+}
+
+func isOptional(tag *spec.Tag) bool {
+	for _, option := range tag.Options {
+		if option == optionalOption {
+			return true
+		}
+	}
+
+	return false
+}
+
+func genImports(routes []route) string {
+	imports := []string{`"context"`}
+	var usesHTTP, usesURL bool
+	for _, each := range routes {
+		if each.PathHasParam || len(each.Query) > 0 {
+			usesURL = true
+		}
+		if len(each.Headers) > 0 {
+			usesHTTP = true
+		}
+	}
+
+	if usesHTTP {
+		imports = append(imports, `"net/http"`)
+	}
+	if usesURL {
+		imports = append(imports, `"net/url"`)
+	}
+
+	return strings.Join(imports, "\n\t")
+}
+
+// packageName converts the base of dir into a valid package name
+func packageName(dir string) string {
+	name := strings.ToLower(filepath.Base(dir))
+	name = strings.NewReplacer("-", "", ".", "", " ", "").Replace(name)
+	if len(name) == 0 || !unicode.IsLetter(rune(name[0])) {
+		name = "client" + name
+	}
+
+	return name
+}
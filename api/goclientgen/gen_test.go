@@ -0,0 +1,175 @@
+package goclientgen
+
+import (
+	goformat "go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/rpc/execx"
+)
+
+const testApi = `
+type (
+	Base {
+		Token string ` + "`header:\"X-Token\"`" + `
+	}
+
+	UserReq {
+		Base
+		Id     int64    ` + "`path:\"id\"`" + `
+		Fields []string ` + "`form:\"fields,optional\"`" + `
+		Lang   string   ` + "`form:\"lang,options=en|zh,optional\"`" + `
+	}
+
+	User {
+		Id   int64  ` + "`json:\"id\"`" + `
+		Name string ` + "`json:\"name\"`" + `
+	}
+
+	UpdateReq {
+		Id   int64  ` + "`path:\"id\"`" + `
+		Name string ` + "`json:\"name\"`" + `
+		Age  int    ` + "`json:\"age,optional,range=[1:150]\"`" + `
+	}
+)
+
+@server(
+	prefix: /v1
+	group: user
+)
+service user-api {
+	@doc "get the user"
+	@handler getUser
+	get /users/:id (UserReq) returns (User)
+
+	@handler updateUser
+	put /users/:id (UpdateReq)
+
+	@handler ping
+	get /ping returns (User)
+}
+`
+
+const testClient = `package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"github.com/zeromicro/go-zero/rest/router"
+)
+
+func TestClient(t *testing.T) {
+	r := router.NewRouter()
+	r.Handle(http.MethodGet, "/v1/users/:id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req UserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.Error(w, err)
+			return
+		}
+		if req.Id != 7 || req.Token != "token" || len(req.Fields) != 2 || req.Lang != "zh" ||
+			r.Header.Get("Authorization") != "auth" {
+			httpx.Error(w, errors.New("unexpected request"))
+			return
+		}
+		httpx.OkJson(w, User{Id: req.Id, Name: "kevin"})
+	}))
+	r.Handle(http.MethodPut, "/v1/users/:id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req UpdateReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.Error(w, err)
+			return
+		}
+		if req.Id != 7 || req.Name != "kevin" {
+			httpx.Error(w, errors.New("unexpected request"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	svr := httptest.NewServer(r)
+	defer svr.Close()
+
+	c := NewClient(svr.URL, WithHeader("Authorization", "auth"))
+	ctx := context.Background()
+	user, err := c.GetUser(ctx, &UserReq{
+		Base:   Base{Token: "token"},
+		Id:     7,
+		Fields: []string{"id", "name"},
+		Lang:   "zh",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Id != 7 || user.Name != "kevin" {
+		t.Fatalf("unexpected response: %+v", user)
+	}
+
+	if err = c.UpdateUser(ctx, &UpdateReq{Id: 7, Name: "kevin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetUser(ctx, &UserReq{Id: 7, Lang: "fr"})
+	if err == nil {
+		t.Fatal("expect the validation error")
+	}
+
+	_, err = c.GetUser(ctx, &UserReq{Id: 8})
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusBadRequest || e.Message != "unexpected request" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = c.Ping(ctx)
+	if !errors.As(err, &e) || e.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+`
+
+func TestGoClient(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(testApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+
+	dir := filepath.Join("_client", "client")
+	os.RemoveAll("_client")
+	defer os.RemoveAll("_client")
+	assert.Nil(t, DoGenClient(api, dir))
+
+	routes, err := ioutil.ReadFile(filepath.Join(dir, "routes.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(routes), "// GetUser get the user")
+	assert.Contains(t, string(routes),
+		"func (c *Client) GetUser(ctx context.Context, req *UserReq) (*User, error)")
+	assert.Contains(t, string(routes), "func (c *Client) UpdateUser(ctx context.Context, req *UpdateReq) error")
+	assert.Contains(t, string(routes), "func (c *Client) Ping(ctx context.Context) (*User, error)")
+	assert.Contains(t, string(routes), "`json:\"age,omitempty\"`")
+	assert.Contains(t, string(routes), "Name string `json:\"name\"`")
+
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(path, ".go") {
+			code, err := ioutil.ReadFile(path)
+			assert.Nil(t, err)
+			_, err = goformat.Source(code)
+			assert.Nil(t, err)
+		}
+		return nil
+	})
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "client_test.go"), []byte(testClient), os.ModePerm))
+	_, err = execx.Run("go test ./...", dir)
+	assert.Nil(t, err)
+}
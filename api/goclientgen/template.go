@@ -0,0 +1,214 @@
+package goclientgen
+
+const (
+	typesTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.pkg}}{{if .containsValidation}}
+
+import "fmt"{{end}}
+
+{{.types}}
+
+{{.validations}}
+`
+
+	clientTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.pkg}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+type (
+	// Client calls the services over http, the methods are generated from the routes
+	Client struct {
+		host   string
+		client *http.Client
+		header http.Header
+	}
+
+	// Option customizes the Client
+	Option func(c *Client)
+
+	// Error is returned if the service responds a non-2xx status, Message is the error
+	// written by httpx.Error, Code is set if the error is encoded in json with code and msg
+	Error struct {
+		StatusCode int
+		Code       int
+		Message    string
+	}
+)
+
+// NewClient returns a Client which sends the requests to host, such as http://localhost:8888
+func NewClient(host string, opts ...Option) *Client {
+	c := &Client{
+		host:   strings.TrimSuffix(host, "/"),
+		client: http.DefaultClient,
+		header: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithHTTPClient customizes the http client, such as the timeout and transport
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithHeader sets a header for all requests, such as Authorization
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Set(key, value)
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("http status %d, code %d: %s", e.StatusCode, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Message)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header,
+	body, resp interface{}) error {
+	target := c.host + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	for key, values := range c.header {
+		r.Header[key] = values
+	}
+	for key, values := range header {
+		r.Header[key] = values
+	}
+
+	res, err := c.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return newError(res.StatusCode, data)
+	}
+
+	if resp == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, resp)
+}
+
+func newError(status int, data []byte) error {
+	e := &Error{
+		StatusCode: status,
+		Message:    strings.TrimSpace(string(data)),
+	}
+
+	var body struct {
+		Code int    ` + "`json:\"code\"`" + `
+		Msg  string ` + "`json:\"msg\"`" + `
+	}
+	if json.Unmarshal(data, &body) == nil && len(body.Msg) > 0 {
+		e.Code = body.Code
+		e.Message = body.Msg
+	}
+
+	return e
+}
+
+// formatValue formats the value of path, form or header, the slices are encoded in json,
+// which are decoded by httpx.Parse
+func formatValue(v interface{}) string {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+`
+
+	routesTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.pkg}}
+
+import (
+	{{.imports}}
+)
+{{range .routes}}
+{{if .Doc}}// {{.Name}} {{.Doc}}{{else}}// {{.Name}} calls {{.Method}} {{.Pattern}}{{end}}
+func (c *Client) {{.Name}}(ctx context.Context{{if .HasRequest}}, req {{.Request}}{{end}}) {{if .HasResponse}}({{if .ResponseRef}}*{{end}}{{.Response}}, error){{else}}error{{end}} {
+	{{if .Validate}}if err := req.Validate(); err != nil {
+		return {{if .HasResponse}}nil, {{end}}err
+	}
+
+	{{end}}{{if .Query}}query := url.Values{}
+	{{range .Query}}{{if .Check}}if {{.Check}} {
+		query.Set("{{.Key}}", formatValue(req.{{.Field}}))
+	}
+	{{else}}query.Set("{{.Key}}", formatValue(req.{{.Field}}))
+	{{end}}{{end}}
+	{{end}}{{if .Headers}}header := make(http.Header)
+	{{range .Headers}}{{if .Check}}if {{.Check}} {
+		header.Set("{{.Key}}", formatValue(req.{{.Field}}))
+	}
+	{{else}}header.Set("{{.Key}}", formatValue(req.{{.Field}}))
+	{{end}}{{end}}
+	{{end}}{{if .Body}}body := struct {
+		{{range .Body}}{{.Field}} {{.Type}} {{.Tag}}
+		{{end}}
+	}{
+		{{range .Body}}{{.Field}}: req.{{.Field}},
+		{{end}}
+	}
+	{{end}}{{if .HasResponse}}var resp {{.Response}}
+	{{end}}{{if .HasResponse}}if{{else}}return{{end}} {{if .HasResponse}}err := {{end}}c.do(ctx, "{{.Method}}", {{.Path}}, {{if .Query}}query{{else}}nil{{end}}, {{if .Headers}}header{{else}}nil{{end}}, {{if .Body}}body{{else if .BodyWhole}}req{{else}}nil{{end}}, {{if .HasResponse}}&resp{{else}}nil{{end}}){{if .HasResponse}}; err != nil {
+		return nil, err
+	}
+
+	return {{if .ResponseRef}}&resp{{else}}resp{{end}}, nil{{end}}
+}
+{{end}}`
+)
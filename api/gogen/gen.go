@@ -26,12 +26,30 @@ const tmpFile = "%s-%d"
 
 var tmpDir = path.Join(os.TempDir(), "goctl")
 
+type (
+	// Option customizes the generation of DoGenProject
+	Option func(o *genOption)
+
+	genOption struct {
+		merge bool
+	}
+)
+
+// WithMerge regenerates the existing handler files and updates the signatures of existing
+// logic methods, the bodies which are written by user are kept
+func WithMerge(merge bool) Option {
+	return func(o *genOption) {
+		o.merge = merge
+	}
+}
+
 // GoCommand gen go project files from command line
 func GoCommand(c *cli.Context) error {
 	apiFile := c.String("api")
 	dir := c.String("dir")
 	namingStyle := c.String("style")
 	home := c.String("home")
+	merge := c.Bool("merge")
 
 	if len(home) > 0 {
 		util.RegisterGoctlHome(home)
@@ -43,11 +61,16 @@ func GoCommand(c *cli.Context) error {
 		return errors.New("missing -dir")
 	}
 
-	return DoGenProject(apiFile, dir, namingStyle)
+	return DoGenProject(apiFile, dir, namingStyle, WithMerge(merge))
 }
 
 // DoGenProject gen go project files with api file
-func DoGenProject(apiFile, dir, style string) error {
+func DoGenProject(apiFile, dir, style string, opts ...Option) error {
+	var opt genOption
+	for _, fn := range opts {
+		fn(&opt)
+	}
+
 	api, err := parser.Parse(apiFile)
 	if err != nil {
 		return err
@@ -70,8 +93,8 @@ func DoGenProject(apiFile, dir, style string) error {
 	logx.Must(genServiceContext(dir, rootPkg, cfg, api))
 	logx.Must(genTypes(dir, cfg, api))
 	logx.Must(genRoutes(dir, rootPkg, cfg, api))
-	logx.Must(genHandlers(dir, rootPkg, cfg, api))
-	logx.Must(genLogic(dir, rootPkg, cfg, api))
+	logx.Must(genHandlers(dir, rootPkg, cfg, api, opt.merge))
+	logx.Must(genLogic(dir, rootPkg, cfg, api, opt.merge))
 	logx.Must(genMiddleware(dir, cfg, api))
 
 	if err := backupAndSweep(apiFile); err != nil {
@@ -318,6 +318,174 @@ service A-api {
 }
 `
 
+const aliasTypeApi = `
+type UserID int64
+
+type (
+	Tags []string
+	UserIDs []UserID
+	Nickname = string
+)
+
+type Request {
+  Id UserID ` + "`" + `path:"id"` + "`" + `
+  Tags Tags ` + "`" + `json:"tags"` + "`" + `
+  Nickname Nickname ` + "`" + `json:"nickname"` + "`" + `
+}
+
+type Response {
+  Friends UserIDs ` + "`" + `json:"friends"` + "`" + `
+}
+
+type (
+	UpdateRequest Request
+	DeleteRequest = Request
+)
+
+service A-api {
+  @handler GreetHandler
+  post /greet/from/:id(Request) returns (Response)
+
+  @handler UpdateHandler
+  put /greet/from/:id(UpdateRequest) returns (Response)
+
+  @handler DeleteHandler
+  delete /greet/from/:id(DeleteRequest)
+}
+`
+
+const enumTypeApi = `
+// order status
+type OrderStatus enum { Pending = 1; Paid = 2 }
+
+type (
+	Color enum {
+		Red = "red" // red comment
+		Green = "green"
+	}
+
+	Request {
+		Status OrderStatus ` + "`" + `json:"status"` + "`" + `
+		Colors []Color ` + "`" + `json:"colors"` + "`" + `
+	}
+)
+
+service A-api {
+  @handler GreetHandler
+  post /greet (Request)
+}
+`
+
+const prefixApi = `
+type Request {
+	Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+@server(
+	prefix: /api/v1
+	group: v1
+)
+service A-api {
+  @handler GreetV1Handler
+  get /greet/from/:name(Request)
+}
+
+@server(
+	prefix: /api/v2
+	group: v2
+)
+service A-api {
+  @handler GreetV2Handler
+  get /greet/from/:name(Request)
+}
+`
+
+const multipleServiceApi = `
+type Request {
+	Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+service user-api {
+  @handler GreetHandler
+  get /greet/from/:name(Request)
+}
+
+@server(
+	jwt: Auth
+	group: admin
+	middleware: CheckMiddleware
+)
+service user-admin {
+  @handler AdminGreetHandler
+  get /greet/from/:name(Request)
+}
+`
+
+const mergeApi = `
+type Request {
+	Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+type Response {
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+@server(
+	group: user
+)
+service user-api {
+  @handler GreetHandler
+  get /greet/from/:name(Request) returns (Response)
+  @handler PingHandler
+  get /ping(Request) returns (Response)
+  @handler RemovedHandler
+  get /removed
+}
+`
+
+const mergedApi = `
+type Request {
+	Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+type Response {
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+@server(
+	group: user
+)
+service user-api {
+  @handler GreetHandler
+  get /greet/from/:name
+  @handler PingHandler
+  get /ping returns (Response)
+  @handler AddedHandler
+  post /added(Request)
+}
+`
+
+const validationApi = `
+type Color enum { Red = "red"; Green = "green" }
+
+type Item {
+	Weight float64 ` + "`" + `json:"weight,range=(0:100]"` + "`" + `
+}
+
+type Request {
+	Name string ` + "`" + `path:"name,options=you|me"` + "`" + `
+	Age int ` + "`" + `json:"age,optional,range=[1:150],default=18"` + "`" + `
+	Score *int64 ` + "`" + `json:"score,optional,range=[0:]"` + "`" + `
+	Color Color ` + "`" + `json:"color,options=red|green"` + "`" + `
+	Items []*Item ` + "`" + `json:"items,optional"` + "`" + `
+}
+
+service A-api {
+  @handler GreetHandler
+  post /greet/from/:name(Request)
+}
+`
+
 func TestParser(t *testing.T) {
 	filename := "greet.api"
 	err := ioutil.WriteFile(filename, []byte(testApiTemplate), os.ModePerm)
@@ -520,6 +688,280 @@ func TestNestTypeApi(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestAliasTypeApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(aliasTypeApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, len(api.Types), 8)
+
+	code, err := BuildTypes(api.Types)
+	assert.Nil(t, err)
+	assert.Contains(t, code, "type UserID int64")
+	assert.Contains(t, code, "type UserIDs []UserID")
+	assert.Contains(t, code, "type Nickname = string")
+
+	code, err = BuildValidations(api)
+	assert.Nil(t, err)
+	assert.Contains(t, code, "func (r *Request) Validate() error")
+	assert.Contains(t, code, "func (r *UpdateRequest) Validate() error {\nreturn (*Request)(r).Validate()\n}")
+	assert.NotContains(t, code, "func (r *DeleteRequest) Validate() error")
+
+	validate(t, filename)
+}
+
+func TestEnumTypeApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(enumTypeApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, len(api.Types), 3)
+
+	code, err := BuildTypes(api.Types)
+	assert.Nil(t, err)
+	assert.Contains(t, code, "type OrderStatus int64")
+	assert.Contains(t, code, "OrderStatusPaid OrderStatus = 2")
+	assert.Contains(t, code, `ColorRed Color = "red" // red comment`)
+
+	validate(t, filename)
+}
+
+func TestValidationApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(validationApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+
+	code, err := BuildValidations(api)
+	assert.Nil(t, err)
+	assert.Contains(t, code, "func (r *Request) Validate() error")
+	assert.Contains(t, code, "func (r *Item) Validate() error")
+	assert.Contains(t, code, `if r.Name != "you" && r.Name != "me" {`)
+	assert.Contains(t, code, "if r.Age < 1 || r.Age > 150 {")
+	assert.Contains(t, code, "if *r.Score < 0 {")
+	assert.Contains(t, code, "if r.Weight <= 0 || r.Weight > 100 {")
+
+	validate(t, filename)
+}
+
+func TestPrefixApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(prefixApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1", api.Service.Groups[0].GetPrefix())
+
+	groups, err := getRoutes(api.Service)
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1", groups[0].prefix)
+	assert.Equal(t, "/api/v2", groups[1].prefix)
+	assert.Equal(t, "/greet/from/:name", groups[1].routes[0].path)
+
+	validate(t, filename)
+}
+
+func TestMultipleServiceApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(multipleServiceApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(api.Services))
+	assert.Equal(t, "RegisterHandlers", registerHandlersName(0, api.Services[0]))
+	assert.Equal(t, "RegisterUserAdminHandlers", registerHandlersName(1, api.Services[1]))
+
+	dir := "_multiple"
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	err = DoGenProject(filename, dir, "gozero")
+	assert.Nil(t, err)
+	for _, file := range []string{
+		"user.go",
+		"cmd/user-admin/user-admin.go",
+		"etc/user-api.yaml",
+		"etc/user-admin.yaml",
+		"internal/handler/routes.go",
+		"internal/handler/useradminroutes.go",
+	} {
+		assert.FileExists(t, filepath.Join(dir, file))
+	}
+
+	etc, err := ioutil.ReadFile(filepath.Join(dir, "etc/user-admin.yaml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(etc), "Port: 8889")
+
+	code, err := ioutil.ReadFile(filepath.Join(dir, "cmd/user-admin/user-admin.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "handler.RegisterUserAdminHandlers(server, ctx)")
+
+	validate(t, filename)
+}
+
+func TestMergeApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(mergeApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	dir := "_merge"
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, DoGenProject(filename, dir, "gozero"))
+
+	assert.FileExists(t, filepath.Join(dir, "internal/logic/user/greetlogic_test.go"))
+	greetFile := filepath.Join(dir, "internal/logic/user/greetlogic.go")
+	code, err := ioutil.ReadFile(greetFile)
+	assert.Nil(t, err)
+	code = []byte(strings.Replace(string(code), logicTodo+"\n\n\treturn &types.Response{}, nil",
+		"return &types.Response{Message: \"hello \" + req.Name}, nil", 1))
+	assert.Nil(t, ioutil.WriteFile(greetFile, code, os.ModePerm))
+
+	err = ioutil.WriteFile(filename, []byte(mergedApi), os.ModePerm)
+	assert.Nil(t, err)
+	assert.Nil(t, DoGenProject(filename, dir, "gozero", WithMerge(true)))
+
+	code, err = ioutil.ReadFile(greetFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "func (l *GreetLogic) Greet() error {")
+	assert.Contains(t, string(code), `"hello " + req.Name`, "the body written by user is kept")
+
+	code, err = ioutil.ReadFile(filepath.Join(dir, "internal/logic/user/pinglogic.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "func (l *PingLogic) Ping() (*types.Response, error) {")
+	assert.Contains(t, string(code), "return &types.Response{}, nil")
+
+	code, err = ioutil.ReadFile(filepath.Join(dir, "internal/handler/user/greethandler.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "err := l.Greet()")
+	assert.FileExists(t, filepath.Join(dir, "internal/logic/user/addedlogic.go"))
+	assert.FileExists(t, filepath.Join(dir, "internal/logic/user/addedlogic_test.go"))
+
+	orphans, err := findOrphanLogic(dir, map[string]struct{}{
+		"internal/logic/user/greetlogic.go": {},
+		"internal/logic/user/pinglogic.go":  {},
+		"internal/logic/user/addedlogic.go": {},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "internal/logic/user/removedlogic.go")}, orphans)
+}
+
+func TestMergeApiRequestChanged(t *testing.T) {
+	filename := "greet.api"
+	api := `
+type Request {
+	Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+type Response {
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+service user-api {
+  @handler GreetHandler
+  get /greet/from/:name(Request) returns (Response)
+  @handler PingHandler
+  get /ping/:name(Request) returns (Response)
+}
+`
+	err := ioutil.WriteFile(filename, []byte(api), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	dir := "_merge_request"
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, DoGenProject(filename, dir, "gozero"))
+
+	pingTestFile := filepath.Join(dir, "internal/logic/pinglogic_test.go")
+	code, err := ioutil.ReadFile(pingTestFile)
+	assert.Nil(t, err)
+	code = []byte(strings.Replace(string(code), `name: "zero value request",`,
+		`name: "empty name",`, 1))
+	assert.Nil(t, ioutil.WriteFile(pingTestFile, code, os.ModePerm))
+
+	api = strings.Replace(api, "get /greet/from/:name(Request) returns (Response)",
+		"get /greet/from/:id(GreetRequest)", 1) + `
+type GreetRequest {
+	Id int64 ` + "`" + `path:"id"` + "`" + `
+}
+`
+	err = ioutil.WriteFile(filename, []byte(api), os.ModePerm)
+	assert.Nil(t, err)
+	assert.Nil(t, DoGenProject(filename, dir, "gozero", WithMerge(true)))
+
+	code, err = ioutil.ReadFile(filepath.Join(dir, "internal/logic/greetlogic_test.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), "req     types.GreetRequest")
+	assert.Contains(t, string(code), "err := l.Greet(tt.req)")
+	assert.NotContains(t, string(code), "_, err")
+
+	code, err = ioutil.ReadFile(pingTestFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(code), `name: "empty name",`, "the test written by user is kept")
+
+	_, err = execx.Run("go vet ./...", dir)
+	assert.Nil(t, err)
+}
+
+func TestMergeLogicMethod(t *testing.T) {
+	generated := `package logic
+
+import (
+	"context"
+
+	"demo/internal/types"
+)
+
+type GreetLogic struct {
+	ctx context.Context
+}
+
+func (l *GreetLogic) Greet(req types.Request) (*types.Response, error) {
+	// todo: add your logic here and delete this line
+
+	return &types.Response{}, nil
+}
+`
+	existing := `package logic
+
+import (
+	"context"
+)
+
+type GreetLogic struct {
+	ctx context.Context
+}
+`
+	code, stale, err := mergeLogicMethod(existing, generated, "GreetLogic", "Greet")
+	assert.Nil(t, err)
+	assert.False(t, stale)
+	assert.Equal(t, strings.Replace(generated, "\n\n\t\"demo", "\n\t\"demo", 1), code,
+		"the missing method and import are added")
+
+	existing = strings.Replace(generated, logicTodo+"\n\n\treturn &types.Response{}, nil", "return nil, nil", 1)
+	generated = strings.Replace(generated, "(req types.Request) (*types.Response, error)", "() error", 1)
+	generated = strings.Replace(generated, "return &types.Response{}, nil", "return nil", 1)
+	code, stale, err = mergeLogicMethod(existing, generated, "GreetLogic", "Greet")
+	assert.Nil(t, err)
+	assert.True(t, stale)
+	assert.Contains(t, code, "func (l *GreetLogic) Greet() error {\n\treturn nil, nil\n}")
+	assert.NotContains(t, code, "demo/internal/types", "the unused import is removed")
+}
+
 func TestCamelStyle(t *testing.T) {
 	filename := "greet.api"
 	err := ioutil.WriteFile(filename, []byte(testApiTemplate), os.ModePerm)
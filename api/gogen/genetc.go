@@ -20,14 +20,22 @@ Port: {{.port}}
 )
 
 func genEtc(dir string, cfg *config.Config, api *spec.ApiSpec) error {
-	filename, err := format.FileNamingFormat(cfg.NamingFormat, api.Service.Name)
+	for index, service := range getServices(api) {
+		if err := genServiceEtc(dir, cfg, service, defaultPort+index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genServiceEtc(dir string, cfg *config.Config, service spec.Service, port int) error {
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, service.Name)
 	if err != nil {
 		return err
 	}
 
-	service := api.Service
 	host := "0.0.0.0"
-	port := strconv.Itoa(defaultPort)
 	serviceName := service.Name
 	if i := strings.Index(serviceName, "service"); i > 0 {
 		serviceName = strings.TrimSuffix(serviceName[:i], "-")
@@ -44,7 +52,7 @@ func genEtc(dir string, cfg *config.Config, api *spec.ApiSpec) error {
 		data: map[string]string{
 			"serviceName": serviceName,
 			"host":        host,
-			"port":        port,
+			"port":        strconv.Itoa(port),
 		},
 	})
 }
@@ -32,7 +32,12 @@ func {{.HandlerName}}(ctx *svc.ServiceContext) http.HandlerFunc {
 			return
 		}
 
-		{{end}}l := {{.LogicName}}.New{{.LogicType}}(r.Context(), ctx)
+		{{if .HasValidation}}if err := req.Validate(); err != nil {
+			httpx.Error(w, err)
+			return
+		}
+
+		{{end}}{{end}}l := {{.LogicName}}.New{{.LogicType}}(r.Context(), ctx)
 		{{if .HasResp}}resp, {{end}}err := l.{{.Call}}({{if .HasRequest}}req{{end}})
 		if err != nil {
 			httpx.Error(w, err)
@@ -59,11 +64,12 @@ type handlerInfo struct {
 	Call           string
 	HasResp        bool
 	HasRequest     bool
+	HasValidation  bool
 	HasSecurity    bool
 	After1_1_10    bool
 }
 
-func genHandler(dir, rootPkg string, cfg *config.Config, group spec.Group, route spec.Route) error {
+func genHandler(dir, rootPkg string, cfg *config.Config, group spec.Group, route spec.Route, merge bool) error {
 	handler := getHandlerName(route)
 	handlerPath := getHandlerFolderPath(group, route)
 	pkgName := handlerPath[strings.LastIndex(handlerPath, "/")+1:]
@@ -96,7 +102,7 @@ func genHandler(dir, rootPkg string, cfg *config.Config, group spec.Group, route
 	goctlVersion := version.GetGoctlVersion()
 	// todo(anqiansong): This will be removed after a certain number of production versions of goctl (probably 5)
 	after1_1_10 := version.IsVersionGreaterThan(goctlVersion, "1.1.10")
-	return doGenToFile(dir, handler, cfg, group, route, handlerInfo{
+	return doGenToFile(dir, handler, cfg, group, route, merge, handlerInfo{
 		PkgName:        pkgName,
 		ImportPackages: genHandlerImports(group, route, parentPkg),
 		HandlerName:    handler,
@@ -111,18 +117,26 @@ func genHandler(dir, rootPkg string, cfg *config.Config, group spec.Group, route
 		Call:           strings.Title(strings.TrimSuffix(handler, "Handler")),
 		HasResp:        len(route.ResponseTypeName()) > 0,
 		HasRequest:     len(route.RequestTypeName()) > 0,
+		HasValidation:  hasValidation(route.RequestType),
 		HasSecurity:    hasSecurity,
 		After1_1_10:    after1_1_10,
 	})
 }
 
 func doGenToFile(dir, handler string, cfg *config.Config, group spec.Group,
-	route spec.Route, handleObj handlerInfo) error {
+	route spec.Route, merge bool, handleObj handlerInfo) error {
 	filename, err := format.FileNamingFormat(cfg.NamingFormat, handler)
 	if err != nil {
 		return err
 	}
 
+	// the handler is the glue of request and logic, it's regenerated like routes
+	if merge {
+		if err := util.RemoveIfExist(path.Join(dir, getHandlerFolderPath(group, route), filename+".go")); err != nil {
+			return err
+		}
+	}
+
 	return genFile(fileGenConfig{
 		dir:             dir,
 		subdir:          getHandlerFolderPath(group, route),
@@ -135,10 +149,10 @@ func doGenToFile(dir, handler string, cfg *config.Config, group spec.Group,
 	})
 }
 
-func genHandlers(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error {
+func genHandlers(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec, merge bool) error {
 	for _, group := range api.Service.Groups {
 		for _, route := range group.Routes {
-			if err := genHandler(dir, rootPkg, cfg, group, route); err != nil {
+			if err := genHandler(dir, rootPkg, cfg, group, route, merge); err != nil {
 				return err
 			}
 		}
@@ -5,6 +5,8 @@ import (
 	"path"
 	"strings"
 
+	"github.com/logrusorgru/aurora"
+
 	"github.com/weitrue/goctl/api/spec"
 	"github.com/weitrue/goctl/config"
 	ctlutil "github.com/weitrue/goctl/util"
@@ -39,21 +41,74 @@ func (l *{{.logic}}) {{.function}}({{.request}}) {{.responseType}} {
 }
 `
 
-func genLogic(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error {
+const logicTestTemplate = `package {{.pkgName}}
+
+import (
+	{{.imports}}
+)
+
+func Test{{.logic}}_{{.function}}(t *testing.T) {
+	// the test config, fill the fields which the logic depends on
+	var c config.Config
+	svcCtx := svc.NewServiceContext(c)
+
+	tests := []struct {
+		name    string{{if .hasRequest}}
+		req     {{.requestType}}{{end}}
+		wantErr bool
+	}{
+		{
+			name: "zero value request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New{{.logic}}(context.Background(), svcCtx)
+			{{if .hasResponse}}_, {{end}}err := l.{{.function}}({{if .hasRequest}}tt.req{{end}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("{{.function}}() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+`
+
+func genLogic(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec, merge bool) error {
+	files := make(map[string]struct{})
 	for _, g := range api.Service.Groups {
 		for _, r := range g.Routes {
-			err := genLogicByRoute(dir, rootPkg, cfg, g, r)
+			err := genLogicByRoute(dir, rootPkg, cfg, g, r, merge)
 			if err != nil {
 				return err
 			}
+
+			file, err := getLogicFilePath(cfg, g, r)
+			if err != nil {
+				return err
+			}
+			files[file] = struct{}{}
 		}
 	}
+
+	if !merge {
+		return nil
+	}
+
+	orphans, err := findOrphanLogic(dir, files)
+	if err != nil {
+		return err
+	}
+	for _, each := range orphans {
+		fmt.Println(aurora.Yellow(fmt.Sprintf("%s is orphaned, its route is removed from api", each)))
+	}
+
 	return nil
 }
 
-func genLogicByRoute(dir, rootPkg string, cfg *config.Config, group spec.Group, route spec.Route) error {
+func genLogicByRoute(dir, rootPkg string, cfg *config.Config, group spec.Group, route spec.Route, merge bool) error {
 	logic := getLogicName(route)
-	goFile, err := format.FileNamingFormat(cfg.NamingFormat, logic)
+	logicFile, err := getLogicFilePath(cfg, group, route)
 	if err != nil {
 		return err
 	}
@@ -82,26 +137,77 @@ func genLogicByRoute(dir, rootPkg string, cfg *config.Config, group spec.Group,
 		summary = strings.TrimSuffix(strings.TrimPrefix(route.AtDoc.Properties["summary"], "\""), "\"")
 	}
 
-	subDir := getLogicFolderPath(group, route)
-	return genFile(fileGenConfig{
+	subDir, filename := path.Split(logicFile)
+	subDir = strings.TrimSuffix(subDir, "/")
+	pkgName := subDir[strings.LastIndex(subDir, "/")+1:]
+	function := strings.Title(strings.TrimSuffix(logic, "Logic"))
+	c := fileGenConfig{
 		dir:             dir,
 		subdir:          subDir,
-		filename:        goFile + ".go",
+		filename:        filename,
 		templateName:    "logicTemplate",
 		category:        category,
 		templateFile:    logicTemplateFile,
 		builtinTemplate: logicTemplate,
 		data: map[string]string{
-			"pkgName":      subDir[strings.LastIndex(subDir, "/")+1:],
+			"pkgName":      pkgName,
 			"imports":      imports,
 			"logic":        strings.Title(logic),
-			"function":     strings.Title(strings.TrimSuffix(logic, "Logic")),
+			"function":     function,
 			"responseType": responseString,
 			"returnString": returnString,
 			"request":      requestString,
 			"summary":      summary,
 		},
-	})
+	}
+	// the test is scaffolded along with the new logic file, and it's regenerated in merge mode
+	// if it's still the scaffold of the previous signature
+	created := !ctlutil.FileExists(path.Join(dir, logicFile))
+	if merge {
+		err = mergeLogic(c, strings.Title(logic), function)
+	} else {
+		err = genFile(c)
+	}
+	if err != nil {
+		return err
+	}
+
+	testConfig := fileGenConfig{
+		dir:             dir,
+		subdir:          subDir,
+		filename:        strings.TrimSuffix(filename, ".go") + "_test.go",
+		templateName:    "logicTestTemplate",
+		category:        category,
+		templateFile:    logicTestTemplateFile,
+		builtinTemplate: logicTestTemplate,
+		data: map[string]interface{}{
+			"pkgName":     pkgName,
+			"imports":     genLogicTestImports(route, rootPkg),
+			"logic":       strings.Title(logic),
+			"function":    function,
+			"hasRequest":  len(requestString) > 0,
+			"requestType": requestGoTypeName(route, typesPacket),
+			"hasResponse": len(route.ResponseTypeName()) > 0,
+		},
+	}
+	if created {
+		return genFile(testConfig)
+	}
+	if merge {
+		return mergeLogicTest(testConfig, function, fmt.Sprintf("Test%s_%s", strings.Title(logic), function))
+	}
+
+	return nil
+}
+
+// getLogicFilePath returns the path of logic file which is relative to the project dir
+func getLogicFilePath(cfg *config.Config, group spec.Group, route spec.Route) (string, error) {
+	goFile, err := format.FileNamingFormat(cfg.NamingFormat, getLogicName(route))
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(getLogicFolderPath(group, route), goFile+".go"), nil
 }
 
 func getLogicFolderPath(group spec.Group, route spec.Route) string {
@@ -127,3 +233,13 @@ func genLogicImports(route spec.Route, parentPkg string) string {
 	}
 	return strings.Join(imports, "\n\t")
 }
+
+func genLogicTestImports(route spec.Route, parentPkg string) string {
+	imports := []string{`"context"`, `"testing"` + "\n"}
+	imports = append(imports, fmt.Sprintf("\"%s\"", ctlutil.JoinPackages(parentPkg, configDir)))
+	imports = append(imports, fmt.Sprintf("\"%s\"", ctlutil.JoinPackages(parentPkg, contextDir)))
+	if len(route.RequestTypeName()) > 0 {
+		imports = append(imports, fmt.Sprintf("\"%s\"", ctlutil.JoinPackages(parentPkg, typesDir)))
+	}
+	return strings.Join(imports, "\n\t")
+}
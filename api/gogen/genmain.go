@@ -2,6 +2,7 @@ package gogen
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/weitrue/goctl/api/spec"
@@ -32,7 +33,7 @@ func main() {
 	server := rest.MustNewServer(c.RestConf)
 	defer server.Stop()
 
-	handler.RegisterHandlers(server, ctx)
+	handler.{{.registerHandlers}}(server, ctx)
 
 	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
 	server.Start()
@@ -40,7 +41,19 @@ func main() {
 `
 
 func genMain(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error {
-	name := strings.ToLower(api.Service.Name)
+	for index, service := range getServices(api) {
+		if err := genServiceMain(dir, rootPkg, cfg, index, service); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// genServiceMain generates the main file of service, the main file of first service is placed in
+// the project root and the others are placed in cmd/<service> since they are separate programs
+func genServiceMain(dir, rootPkg string, cfg *config.Config, index int, service spec.Service) error {
+	name := strings.ToLower(service.Name)
 	filename, err := format.FileNamingFormat(cfg.NamingFormat, name)
 	if err != nil {
 		return err
@@ -51,17 +64,23 @@ func genMain(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error {
 		filename = strings.ReplaceAll(filename, "-api", "")
 	}
 
+	var subdir string
+	if index > 0 {
+		subdir = path.Join(cmdDir, filename)
+	}
+
 	return genFile(fileGenConfig{
 		dir:             dir,
-		subdir:          "",
+		subdir:          subdir,
 		filename:        filename + ".go",
 		templateName:    "mainTemplate",
 		category:        category,
 		templateFile:    mainTemplateFile,
 		builtinTemplate: mainTemplate,
 		data: map[string]string{
-			"importPackages": genMainImports(rootPkg),
-			"serviceName":    configName,
+			"importPackages":   genMainImports(rootPkg),
+			"serviceName":      configName,
+			"registerHandlers": registerHandlersName(index, service),
 		},
 	})
 }
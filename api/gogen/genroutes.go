@@ -2,7 +2,6 @@ package gogen
 
 import (
 	"fmt"
-	"os"
 	"path"
 	"sort"
 	"strings"
@@ -12,6 +11,7 @@ import (
 	"github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/format"
+	"github.com/weitrue/goctl/util/stringx"
 	"github.com/weitrue/goctl/vars"
 	"github.com/zeromicro/go-zero/core/collection"
 )
@@ -27,13 +27,13 @@ import (
 	{{.importPackages}}
 )
 
-func RegisterHandlers(engine *rest.Server, serverCtx *svc.ServiceContext) {
+func {{.registerHandlers}}(engine *rest.Server, serverCtx *svc.ServiceContext) {
 	{{.routesAdditions}}
 }
 `
 	routesAdditionTemplate = `
 	engine.AddRoutes(
-		{{.routes}} {{.jwt}}{{.signature}}
+		{{.routes}} {{.jwt}}{{.signature}}{{.prefix}}
 	)
 `
 )
@@ -54,6 +54,7 @@ type (
 		signatureEnabled bool
 		authName         string
 		middlewares      []string
+		prefix           string
 	}
 	route struct {
 		method  string
@@ -63,8 +64,23 @@ type (
 )
 
 func genRoutes(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error {
+	for index, service := range getServices(api) {
+		name := routesFilename
+		if index > 0 {
+			name = stringx.From(serviceCamelName(service)).Untitle() + "Routes"
+		}
+
+		if err := genServiceRoutes(dir, rootPkg, cfg, service, name, registerHandlersName(index, service)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genServiceRoutes(dir, rootPkg string, cfg *config.Config, service spec.Service, name, registerHandlers string) error {
 	var builder strings.Builder
-	groups, err := getRoutes(api)
+	groups, err := getRoutes(service)
 	if err != nil {
 		return err
 	}
@@ -92,6 +108,11 @@ func genRoutes(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error
 			signature = "\n rest.WithSignature(serverCtx.Config.Signature),"
 		}
 
+		var prefix string
+		if len(g.prefix) > 0 {
+			prefix = fmt.Sprintf("\n rest.WithPrefix(%q),", g.prefix)
+		}
+
 		var routes string
 		if len(g.middlewares) > 0 {
 			gbuilder.WriteString("\n}...,")
@@ -111,19 +132,22 @@ func genRoutes(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error
 			"routes":    routes,
 			"jwt":       jwt,
 			"signature": signature,
+			"prefix":    prefix,
 		}); err != nil {
 			return err
 		}
 	}
 
-	routeFilename, err := format.FileNamingFormat(cfg.NamingFormat, routesFilename)
+	routeFilename, err := format.FileNamingFormat(cfg.NamingFormat, name)
 	if err != nil {
 		return err
 	}
 	routeFilename = routeFilename + ".go"
 
 	filename := path.Join(dir, handlerDir, routeFilename)
-	os.Remove(filename)
+	if err := util.RemoveIfExist(filename); err != nil {
+		return err
+	}
 
 	return genFile(fileGenConfig{
 		dir:             dir,
@@ -134,16 +158,17 @@ func genRoutes(dir, rootPkg string, cfg *config.Config, api *spec.ApiSpec) error
 		templateFile:    "",
 		builtinTemplate: routesTemplate,
 		data: map[string]string{
-			"importPackages":  genRouteImports(rootPkg, api),
-			"routesAdditions": strings.TrimSpace(builder.String()),
+			"importPackages":   genRouteImports(rootPkg, service),
+			"registerHandlers": registerHandlers,
+			"routesAdditions":  strings.TrimSpace(builder.String()),
 		},
 	})
 }
 
-func genRouteImports(parentPkg string, api *spec.ApiSpec) string {
+func genRouteImports(parentPkg string, service spec.Service) string {
 	importSet := collection.NewSet()
 	importSet.AddStr(fmt.Sprintf("\"%s\"", util.JoinPackages(parentPkg, contextDir)))
-	for _, group := range api.Service.Groups {
+	for _, group := range service.Groups {
 		for _, route := range group.Routes {
 			folder := route.GetAnnotation(groupProperty)
 			if len(folder) == 0 {
@@ -162,10 +187,10 @@ func genRouteImports(parentPkg string, api *spec.ApiSpec) string {
 	return fmt.Sprintf("%s\n\n\t%s", depSection, projectSection)
 }
 
-func getRoutes(api *spec.ApiSpec) ([]group, error) {
+func getRoutes(service spec.Service) ([]group, error) {
 	var routes []group
 
-	for _, g := range api.Service.Groups {
+	for _, g := range service.Groups {
 		var groupedRoutes group
 		for _, r := range g.Routes {
 			handler := getHandlerName(r)
@@ -201,6 +226,7 @@ func getRoutes(api *spec.ApiSpec) ([]group, error) {
 				groupedRoutes.middlewares = append(groupedRoutes.middlewares, item)
 			}
 		}
+		groupedRoutes.prefix = g.GetPrefix()
 		routes = append(routes, groupedRoutes)
 	}
 
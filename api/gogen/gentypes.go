@@ -3,7 +3,6 @@ package gogen
 import (
 	"fmt"
 	"io"
-	"os"
 	"path"
 	"strings"
 
@@ -20,8 +19,10 @@ const (
 package types{{if .containsTime}}
 import (
 	"time"
-){{end}}
+){{end}}{{if .containsValidation}}
+import "fmt"{{end}}
 {{.types}}
+{{.validations}}
 `
 )
 
@@ -49,6 +50,11 @@ func genTypes(dir string, cfg *config.Config, api *spec.ApiSpec) error {
 		return err
 	}
 
+	validations, err := BuildValidations(api)
+	if err != nil {
+		return err
+	}
+
 	typeFilename, err := format.FileNamingFormat(cfg.NamingFormat, typesFile)
 	if err != nil {
 		return err
@@ -56,7 +62,9 @@ func genTypes(dir string, cfg *config.Config, api *spec.ApiSpec) error {
 
 	typeFilename = typeFilename + ".go"
 	filename := path.Join(dir, typesDir, typeFilename)
-	os.Remove(filename)
+	if err := util.RemoveIfExist(filename); err != nil {
+		return err
+	}
 
 	return genFile(fileGenConfig{
 		dir:             dir,
@@ -67,13 +75,29 @@ func genTypes(dir string, cfg *config.Config, api *spec.ApiSpec) error {
 		templateFile:    "",
 		builtinTemplate: typesTemplate,
 		data: map[string]interface{}{
-			"types":        val,
-			"containsTime": false,
+			"types":              val,
+			"containsTime":       false,
+			"validations":        validations,
+			"containsValidation": strings.Contains(validations, "fmt."),
 		},
 	})
 }
 
 func writeType(writer io.Writer, tp spec.Type) error {
+	if aliasType, ok := tp.(spec.AliasType); ok {
+		assign := " "
+		if aliasType.Assign {
+			assign = " = "
+		}
+
+		_, err := fmt.Fprintf(writer, "type %s%s%s", util.Title(tp.Name()), assign, aliasType.Value.Name())
+		return err
+	}
+
+	if enumType, ok := tp.(spec.EnumType); ok {
+		return writeEnum(writer, enumType)
+	}
+
 	structType, ok := tp.(spec.DefineStruct)
 	if !ok {
 		return fmt.Errorf("unspport struct type: %s", tp.Name())
@@ -96,3 +120,36 @@ func writeType(writer io.Writer, tp spec.Type) error {
 	fmt.Fprintf(writer, "}")
 	return nil
 }
+
+func writeEnum(writer io.Writer, enumType spec.EnumType) error {
+	name := util.Title(enumType.Name())
+	if _, err := fmt.Fprintf(writer, "type %s %s\n\nconst (\n", name, enumType.Value.Name()); err != nil {
+		return err
+	}
+
+	for _, member := range enumType.Members {
+		for _, doc := range member.Docs {
+			if _, err := fmt.Fprintf(writer, "\t%s\n", doc); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(writer, "\t%s%s %s = %s", name, util.Title(member.Name), name,
+			member.Value); err != nil {
+			return err
+		}
+
+		if len(member.Comment) > 0 {
+			if _, err := fmt.Fprintf(writer, " %s", member.Comment); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprint(writer, "\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprint(writer, ")")
+	return err
+}
@@ -0,0 +1,237 @@
+package gogen
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/weitrue/goctl/api/spec"
+	apiutil "github.com/weitrue/goctl/api/util"
+	"github.com/weitrue/goctl/util"
+)
+
+// BuildValidations generates the Validate methods of request types from the tag options,
+// the structures referred by request types are validated too. A type declared on a structure,
+// such as type CreateReq UserReq, delegates its Validate method to the structure.
+func BuildValidations(api *spec.ApiSpec) (string, error) {
+	types := make(map[string]spec.DefineStruct)
+	for _, tp := range api.Types {
+		if v, ok := tp.(spec.DefineStruct); ok {
+			types[v.Name()] = v
+		}
+	}
+
+	var names []string
+	validated := make(map[string]bool)
+	aliases := make(map[string]string)
+	var collect func(tp spec.Type)
+	collect = func(tp spec.Type) {
+		switch v := tp.(type) {
+		case spec.DefineStruct:
+			defined, ok := types[v.Name()]
+			if !ok || validated[v.Name()] {
+				return
+			}
+
+			validated[v.Name()] = true
+			names = append(names, v.Name())
+			for _, member := range defined.Members {
+				collect(member.Type)
+			}
+		case spec.AliasType:
+			collect(v.Value)
+			// the alias declared with = is the same type as its value, it has the methods of value
+			defined, ok := validatedStruct(v)
+			if v.Assign || !ok || !validated[defined.Name()] || validated[v.Name()] {
+				return
+			}
+
+			validated[v.Name()] = true
+			names = append(names, v.Name())
+			aliases[v.Name()] = defined.Name()
+		case spec.PointerType:
+			collect(v.Type)
+		case spec.ArrayType:
+			collect(v.Value)
+		case spec.MapType:
+			collect(v.Value)
+		}
+	}
+	for _, route := range api.Service.Routes() {
+		if route.RequestType != nil {
+			collect(route.RequestType)
+		}
+	}
+
+	var builder strings.Builder
+	for _, name := range names {
+		builder.WriteString("\n")
+		if target, ok := aliases[name]; ok {
+			writeAliasValidation(&builder, name, target)
+			continue
+		}
+
+		if err := writeValidation(&builder, types[name], validated); err != nil {
+			return "", apiutil.WrapErr(err, "Type "+name+" generate validation error")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func writeValidation(writer io.Writer, tp spec.DefineStruct, validated map[string]bool) error {
+	fmt.Fprintf(writer, "func (r *%s) Validate() error {\n", util.Title(tp.Name()))
+	for _, member := range tp.Members {
+		if member.IsInline {
+			if validated[member.Type.Name()] {
+				writeNestedValidation(writer, "r."+util.Title(member.Type.Name()))
+			}
+			continue
+		}
+
+		field := "r." + util.Title(member.Name)
+		rule, err := member.GetValidationRule()
+		if err != nil {
+			return err
+		}
+
+		if !rule.IsEmpty() {
+			name, err := member.GetPropertyName()
+			if err != nil {
+				return err
+			}
+
+			writeRuleValidation(writer, field, name, member.Type, rule)
+		}
+
+		switch v := member.Type.(type) {
+		case spec.DefineStruct:
+			if validated[v.Name()] {
+				writeNestedValidation(writer, field)
+			}
+		case spec.PointerType:
+			if _, ok := v.Type.(spec.DefineStruct); ok && validated[v.Type.Name()] {
+				fmt.Fprintf(writer, "if %s != nil {\n", field)
+				writeNestedValidation(writer, field)
+				fmt.Fprint(writer, "}\n")
+			}
+		case spec.ArrayType:
+			writeElementValidation(writer, field, v.Value, validated)
+		case spec.MapType:
+			writeElementValidation(writer, field, v.Value, validated)
+		}
+	}
+	fmt.Fprint(writer, "return nil\n}\n")
+	return nil
+}
+
+func writeAliasValidation(writer io.Writer, name, target string) {
+	fmt.Fprintf(writer, "func (r *%s) Validate() error {\nreturn (*%s)(r).Validate()\n}\n",
+		util.Title(name), util.Title(target))
+}
+
+// validatedStruct returns the structure which tp is declared on, it returns false
+// if tp is neither a structure nor an alias of structure, such types have no Validate method.
+func validatedStruct(tp spec.Type) (spec.DefineStruct, bool) {
+	for {
+		switch v := tp.(type) {
+		case spec.DefineStruct:
+			return v, true
+		case spec.AliasType:
+			tp = v.Value
+		default:
+			return spec.DefineStruct{}, false
+		}
+	}
+}
+
+func writeRuleValidation(writer io.Writer, field, name string, tp spec.Type, rule *spec.ValidationRule) {
+	value := field
+	_, isPointer := tp.(spec.PointerType)
+	if isPointer {
+		value = "*" + field
+		fmt.Fprintf(writer, "if %s != nil {\n", field)
+	} else if rule.Optional {
+		// the absent optional member keeps the zero value
+		zero := "0"
+		if rule.Kind == "string" {
+			zero = `""`
+		}
+		fmt.Fprintf(writer, "if %s != %s {\n", field, zero)
+	}
+
+	if len(rule.Options) > 0 {
+		var conditions []string
+		for _, item := range rule.Options {
+			if rule.Kind == "string" {
+				item = strconv.Quote(item)
+			}
+			conditions = append(conditions, fmt.Sprintf("%s != %s", value, item))
+		}
+
+		fmt.Fprintf(writer, "if %s {\n", strings.Join(conditions, " && "))
+		writeValidationError(writer, fmt.Sprintf("%s must be one of [%s]", name,
+			strings.Join(rule.Options, " ")), value)
+		fmt.Fprint(writer, "}\n")
+	}
+
+	if rule.Range != nil {
+		var conditions []string
+		if len(rule.Range.Left) > 0 {
+			operator := "<="
+			if rule.Range.LeftInclude {
+				operator = "<"
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", value, operator, rule.Range.Left))
+		}
+		if len(rule.Range.Right) > 0 {
+			operator := ">="
+			if rule.Range.RightInclude {
+				operator = ">"
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", value, operator, rule.Range.Right))
+		}
+
+		fmt.Fprintf(writer, "if %s {\n", strings.Join(conditions, " || "))
+		writeValidationError(writer, fmt.Sprintf("%s must be in range %s", name, rule.Range), value)
+		fmt.Fprint(writer, "}\n")
+	}
+
+	if isPointer || rule.Optional {
+		fmt.Fprint(writer, "}\n")
+	}
+}
+
+func writeValidationError(writer io.Writer, msg, value string) {
+	msg = strings.ReplaceAll(msg, "%", "%%") + ", but got %v"
+	fmt.Fprintf(writer, "return fmt.Errorf(%s, %s)\n", strconv.Quote(msg), value)
+}
+
+func writeElementValidation(writer io.Writer, field string, tp spec.Type, validated map[string]bool) {
+	switch v := tp.(type) {
+	case spec.DefineStruct:
+		if validated[v.Name()] {
+			fmt.Fprintf(writer, "for _, item := range %s {\n", field)
+			writeNestedValidation(writer, "item")
+			fmt.Fprint(writer, "}\n")
+		}
+	case spec.PointerType:
+		if _, ok := v.Type.(spec.DefineStruct); ok && validated[v.Type.Name()] {
+			fmt.Fprintf(writer, "for _, item := range %s {\n", field)
+			fmt.Fprint(writer, "if item == nil {\ncontinue\n}\n")
+			writeNestedValidation(writer, "item")
+			fmt.Fprint(writer, "}\n")
+		}
+	}
+}
+
+func writeNestedValidation(writer io.Writer, field string) {
+	fmt.Fprintf(writer, "if err := %s.Validate(); err != nil {\nreturn err\n}\n", field)
+}
+
+// hasValidation returns true if the Validate method is generated for the request type tp
+func hasValidation(tp spec.Type) bool {
+	_, ok := validatedStruct(tp)
+	return ok
+}
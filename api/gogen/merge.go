@@ -0,0 +1,493 @@
+package gogen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/preview"
+)
+
+// logicTodo marks the logic body which is not written by user yet
+const logicTodo = "// todo: add your logic here and delete this line"
+
+type textEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// mergeLogic generates the logic file if it doesn't exist, otherwise merges the generated
+// method into the existing file
+func mergeLogic(c fileGenConfig, logic, function string) error {
+	file := path.Join(c.dir, c.subdir, c.filename)
+	if !util.FileExists(file) {
+		return genFile(c)
+	}
+
+	generated, err := renderFile(c)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	merged, stale, err := mergeLogicMethod(string(existing), generated, logic, function)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	if stale {
+		fmt.Println(aurora.Yellow(fmt.Sprintf("%s: the signature of %s is changed, please update its body",
+			file, function)))
+	}
+	if merged == string(existing) {
+		return nil
+	}
+
+	if !preview.Enabled() {
+		fmt.Printf("%s merged\n", file)
+	}
+	return util.WriteFile(file, []byte(merged), os.ModePerm)
+}
+
+// mergeLogicMethod replaces the signature of method in existing code with the generated one,
+// the body is kept unless it's still the todo stub, and stale is true if the kept body may
+// not match the new signature. The method is appended if it's missing, and the imports are
+// added or removed according to the merged code.
+func mergeLogicMethod(existing, generated, logic, function string) (code string, stale bool, err error) {
+	fset := token.NewFileSet()
+	genFile, err := parser.ParseFile(fset, "", generated, parser.ParseComments)
+	if err != nil {
+		return "", false, err
+	}
+
+	genDecl := findMethod(genFile, logic, function)
+	if genDecl == nil {
+		// the customized template may not declare the method
+		return existing, false, nil
+	}
+
+	oldFile, err := parser.ParseFile(fset, "", existing, parser.ParseComments)
+	if err != nil {
+		return "", false, err
+	}
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+	genSignature := generated[offset(genDecl.Type.Params.Pos()):offset(genDecl.Type.End())]
+
+	var edits []textEdit
+	var replaced map[string]struct{}
+	if oldDecl := findMethod(oldFile, logic, function); oldDecl != nil {
+		start, end := offset(oldDecl.Type.Params.Pos()), offset(oldDecl.Type.End())
+		if existing[start:end] == genSignature {
+			return existing, false, nil
+		}
+
+		edits = append(edits, textEdit{start: start, end: end, text: genSignature})
+		replaced = usedPackages(oldDecl.Type)
+		body := existing[offset(oldDecl.Body.Pos()):offset(oldDecl.Body.End())]
+		if len(oldDecl.Body.List) <= 1 && strings.Contains(body, logicTodo) {
+			edits = append(edits, textEdit{
+				start: offset(oldDecl.Body.Pos()),
+				end:   offset(oldDecl.Body.End()),
+				text:  generated[offset(genDecl.Body.Pos()):offset(genDecl.Body.End())],
+			})
+		} else {
+			stale = true
+		}
+	} else {
+		start := genDecl.Pos()
+		if genDecl.Doc != nil {
+			start = genDecl.Doc.Pos()
+		}
+		edits = append(edits, textEdit{
+			start: len(existing),
+			end:   len(existing),
+			text:  "\n" + generated[offset(start):offset(genDecl.End())] + "\n",
+		})
+	}
+
+	code, err = fixImports(applyEdits(existing, edits), generated, genFile, replaced, fset)
+	if err != nil {
+		return "", false, err
+	}
+
+	return formatCode(code), stale, nil
+}
+
+// testedSignature describes the signature of logic method which is called by the test scaffold
+type testedSignature struct {
+	hasRequest  bool
+	requestType string
+	hasResponse bool
+}
+
+// mergeLogicTest replaces the test of logic method with the generated one if it's still the scaffold
+// of the previous signature, the test written by user is kept and reported if the signature is changed
+func mergeLogicTest(c fileGenConfig, function, test string) error {
+	file := path.Join(c.dir, c.subdir, c.filename)
+	if !util.FileExists(file) {
+		return nil
+	}
+
+	generated, err := renderFile(c)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	data := c.data.(map[string]interface{})
+	signature := testedSignature{
+		hasRequest:  data["hasRequest"].(bool),
+		requestType: data["requestType"].(string),
+		hasResponse: data["hasResponse"].(bool),
+	}
+	scaffold := func(previous testedSignature) (string, error) {
+		scaffoldData := make(map[string]interface{})
+		for k, v := range data {
+			scaffoldData[k] = v
+		}
+		scaffoldData["hasRequest"] = previous.hasRequest
+		scaffoldData["requestType"] = previous.requestType
+		scaffoldData["hasResponse"] = previous.hasResponse
+		scaffoldConfig := c
+		scaffoldConfig.data = scaffoldData
+		return renderFile(scaffoldConfig)
+	}
+
+	merged, stale, err := mergeLogicTestFunc(string(existing), generated, function, test, signature, scaffold)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	if stale {
+		fmt.Println(aurora.Yellow(fmt.Sprintf("%s: the signature of %s is changed, please update %s",
+			file, function, test)))
+	}
+	if merged == string(existing) {
+		return nil
+	}
+
+	if !preview.Enabled() {
+		fmt.Printf("%s merged\n", file)
+	}
+	return util.WriteFile(file, []byte(merged), os.ModePerm)
+}
+
+// mergeLogicTestFunc replaces the test function in existing code with the generated one if it's
+// the same as the scaffold of the signature which it calls, stale is true if the test is written
+// by user and the signature is changed.
+func mergeLogicTestFunc(existing, generated, function, test string, signature testedSignature,
+	scaffold func(testedSignature) (string, error)) (code string, stale bool, err error) {
+	fset := token.NewFileSet()
+	genFile, err := parser.ParseFile(fset, "", generated, parser.ParseComments)
+	if err != nil {
+		return "", false, err
+	}
+
+	genDecl := findFunc(genFile, test)
+	if genDecl == nil {
+		// the customized template may not declare the test
+		return existing, false, nil
+	}
+
+	oldFile, err := parser.ParseFile(fset, "", existing, parser.ParseComments)
+	if err != nil {
+		return "", false, err
+	}
+
+	oldDecl := findFunc(oldFile, test)
+	if oldDecl == nil {
+		return existing, false, nil
+	}
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+	previous := findTestedSignature(oldDecl, existing, function, offset)
+	if previous == signature {
+		return existing, false, nil
+	}
+
+	previousCode, err := scaffold(previous)
+	if err != nil {
+		return "", false, err
+	}
+
+	previousFile, err := parser.ParseFile(fset, "", previousCode, parser.ParseComments)
+	if err != nil {
+		return "", false, err
+	}
+
+	previousDecl := findFunc(previousFile, test)
+	if previousDecl == nil || previousCode[offset(previousDecl.Pos()):offset(previousDecl.End())] !=
+		existing[offset(oldDecl.Pos()):offset(oldDecl.End())] {
+		return existing, true, nil
+	}
+
+	edit := textEdit{
+		start: offset(oldDecl.Pos()),
+		end:   offset(oldDecl.End()),
+		text:  generated[offset(genDecl.Pos()):offset(genDecl.End())],
+	}
+	code, err = fixImports(applyEdits(existing, []textEdit{edit}), generated, genFile, usedPackages(oldDecl), fset)
+	if err != nil {
+		return "", false, err
+	}
+
+	return formatCode(code), false, nil
+}
+
+// findTestedSignature returns the signature which is called by the test, the request type is
+// the type of req field in the test cases, and the response is received if the call returns two values
+func findTestedSignature(decl *ast.FuncDecl, code, function string, offset func(token.Pos) int) testedSignature {
+	var signature testedSignature
+	ast.Inspect(decl, func(node ast.Node) bool {
+		switch v := node.(type) {
+		case *ast.StructType:
+			for _, field := range v.Fields.List {
+				if len(field.Names) == 1 && field.Names[0].Name == "req" {
+					signature.hasRequest = true
+					signature.requestType = code[offset(field.Type.Pos()):offset(field.Type.End())]
+				}
+			}
+		case *ast.AssignStmt:
+			if len(v.Rhs) != 1 {
+				return true
+			}
+
+			if call, ok := v.Rhs[0].(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == function {
+					signature.hasResponse = len(v.Lhs) > 1
+				}
+			}
+		}
+		return true
+	})
+
+	return signature
+}
+
+// fixImports adds the generated imports which are used by code, and removes the imports
+// which were used by the replaced signature only
+func fixImports(code, generated string, genFile *ast.File, replaced map[string]struct{},
+	fset *token.FileSet) (string, error) {
+	file, err := parser.ParseFile(fset, "", code, 0)
+	if err != nil {
+		return "", err
+	}
+
+	used := usedPackages(file)
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	existing := make(map[string]struct{})
+	var edits []textEdit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			existing[importPath(imp)] = struct{}{}
+			name := importName(imp)
+			if _, ok := replaced[name]; !ok {
+				continue
+			}
+			if _, ok := used[name]; ok {
+				continue
+			}
+
+			if !gen.Lparen.IsValid() {
+				edits = append(edits, lineEdit(code, offset(gen.Pos()), offset(gen.End())))
+			} else {
+				edits = append(edits, lineEdit(code, offset(imp.Pos()), offset(imp.End())))
+			}
+		}
+	}
+
+	var missing []string
+	for _, imp := range genFile.Imports {
+		if _, ok := existing[importPath(imp)]; ok {
+			continue
+		}
+		if _, ok := used[importName(imp)]; ok {
+			missing = append(missing, generated[offset(imp.Pos()):offset(imp.End())])
+		}
+	}
+
+	if len(missing) > 0 {
+		edits = append(edits, importEdit(code, file, fset, missing))
+	}
+
+	return applyEdits(code, edits), nil
+}
+
+// importEdit inserts the imports into the first import declaration, or after the package clause
+func importEdit(code string, file *ast.File, fset *token.FileSet, imports []string) textEdit {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		if gen.Lparen.IsValid() {
+			rparen := fset.Position(gen.Rparen).Offset
+			return textEdit{start: rparen, end: rparen, text: "\t" + strings.Join(imports, "\n\t") + "\n"}
+		}
+
+		end := fset.Position(gen.End()).Offset
+		return textEdit{start: end, end: end, text: "\nimport " + strings.Join(imports, "\nimport ")}
+	}
+
+	end := fset.Position(file.Name.End()).Offset
+	return textEdit{start: end, end: end, text: "\n\nimport (\n\t" + strings.Join(imports, "\n\t") + "\n)"}
+}
+
+// lineEdit removes the text between start and end, and the line if nothing else is left
+func lineEdit(code string, start, end int) textEdit {
+	lineStart := strings.LastIndex(code[:start], "\n") + 1
+	lineEnd := strings.Index(code[end:], "\n")
+	if lineEnd < 0 {
+		lineEnd = len(code)
+	} else {
+		lineEnd += end + 1
+	}
+
+	if len(strings.TrimSpace(code[lineStart:start]+code[end:lineEnd])) == 0 {
+		return textEdit{start: lineStart, end: lineEnd}
+	}
+
+	return textEdit{start: start, end: end}
+}
+
+func applyEdits(code string, edits []textEdit) string {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+
+	for _, each := range edits {
+		code = code[:each.start] + each.text + code[each.end:]
+	}
+
+	return code
+}
+
+// findMethod returns the method whose receiver is logic or *logic
+func findMethod(file *ast.File, logic, function string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Name.Name != function {
+			continue
+		}
+
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if ident, ok := recv.(*ast.Ident); ok && ident.Name == logic {
+			return fn
+		}
+	}
+
+	return nil
+}
+
+// findFunc returns the function which is not a method
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+
+	return nil
+}
+
+// usedPackages returns the names which are used as the package of selector, such as types in types.Request
+func usedPackages(node ast.Node) map[string]struct{} {
+	used := make(map[string]struct{})
+	ast.Inspect(node, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	return used
+}
+
+func importPath(imp *ast.ImportSpec) string {
+	value, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return imp.Path.Value
+	}
+
+	return value
+}
+
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+
+	return path.Base(importPath(imp))
+}
+
+// findOrphanLogic returns the logic files which are not generated by any route of api,
+// the files of test are ignored
+func findOrphanLogic(dir string, files map[string]struct{}) ([]string, error) {
+	root := filepath.Join(dir, logicDir)
+	if !util.FileExists(root) {
+		return nil, nil
+	}
+
+	var orphans []string
+	err := filepath.Walk(root, func(fpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(fpath) != ".go" || strings.HasSuffix(fpath, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, fpath)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[filepath.ToSlash(rel)]; !ok {
+			orphans = append(orphans, fpath)
+		}
+
+		return nil
+	})
+
+	return orphans, err
+}
@@ -8,22 +8,24 @@ import (
 )
 
 const (
-	category            = "api"
-	configTemplateFile  = "config.tpl"
-	contextTemplateFile = "context.tpl"
-	etcTemplateFile     = "etc.tpl"
-	handlerTemplateFile = "handler.tpl"
-	logicTemplateFile   = "logic.tpl"
-	mainTemplateFile    = "main.tpl"
+	category              = "api"
+	configTemplateFile    = "config.tpl"
+	contextTemplateFile   = "context.tpl"
+	etcTemplateFile       = "etc.tpl"
+	handlerTemplateFile   = "handler.tpl"
+	logicTemplateFile     = "logic.tpl"
+	logicTestTemplateFile = "logic_test.tpl"
+	mainTemplateFile      = "main.tpl"
 )
 
 var templates = map[string]string{
-	configTemplateFile:  configTemplate,
-	contextTemplateFile: contextTemplate,
-	etcTemplateFile:     etcTemplate,
-	handlerTemplateFile: handlerTemplate,
-	logicTemplateFile:   logicTemplate,
-	mainTemplateFile:    mainTemplate,
+	configTemplateFile:    configTemplate,
+	contextTemplateFile:   contextTemplate,
+	etcTemplateFile:       etcTemplate,
+	handlerTemplateFile:   handlerTemplate,
+	logicTemplateFile:     logicTemplate,
+	logicTestTemplateFile: logicTestTemplate,
+	mainTemplateFile:      mainTemplate,
 }
 
 // Category returns the category of the api files.
@@ -13,6 +13,7 @@ import (
 	"github.com/weitrue/goctl/api/util"
 	ctlutil "github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/ctx"
+	"github.com/weitrue/goctl/util/stringx"
 	"github.com/zeromicro/go-zero/core/collection"
 )
 
@@ -37,13 +38,25 @@ func genFile(c fileGenConfig) error {
 	}
 	defer fp.Close()
 
+	code, err := renderFile(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = fp.WriteString(code)
+	return err
+}
+
+// renderFile executes the template of file and returns the formatted code
+func renderFile(c fileGenConfig) (string, error) {
 	var text string
+	var err error
 	if len(c.category) == 0 || len(c.templateFile) == 0 {
 		text = c.builtinTemplate
 	} else {
 		text, err = ctlutil.LoadTemplate(c.category, c.templateFile, c.builtinTemplate)
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
 
@@ -51,12 +64,10 @@ func genFile(c fileGenConfig) error {
 	buffer := new(bytes.Buffer)
 	err = t.Execute(buffer, c.data)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	code := formatCode(buffer.String())
-	_, err = fp.WriteString(code)
-	return err
+	return formatCode(buffer.String()), nil
 }
 
 func getParentPackage(dir string) (string, error) {
@@ -87,6 +98,31 @@ func writeProperty(writer io.Writer, name, tag, comment string, tp spec.Type, in
 	return err
 }
 
+// getServices returns the services declared in api, the api which is not parsed from file
+// only has the merged service
+func getServices(api *spec.ApiSpec) []spec.Service {
+	if len(api.Services) == 0 {
+		return []spec.Service{api.Service}
+	}
+
+	return api.Services
+}
+
+// serviceCamelName converts the service name like user-admin into UserAdmin
+func serviceCamelName(service spec.Service) string {
+	return stringx.From(strings.ReplaceAll(service.Name, "-", "_")).ToCamel()
+}
+
+// registerHandlersName returns the name of function which registers the handlers of service,
+// the first service keeps RegisterHandlers
+func registerHandlersName(index int, service spec.Service) string {
+	if index == 0 {
+		return "RegisterHandlers"
+	}
+
+	return fmt.Sprintf("Register%sHandlers", serviceCamelName(service))
+}
+
 func getAuths(api *spec.ApiSpec) []string {
 	authNames := collection.NewSet()
 	for _, g := range api.Service.Groups {
@@ -159,6 +195,16 @@ func golangExpr(ty spec.Type, pkg ...string) string {
 		}
 
 		return fmt.Sprintf("%s.%s", pkg[0], strings.Title(v.RawName))
+	case spec.AliasType, spec.EnumType:
+		if len(pkg) > 1 {
+			panic("package cannot be more than 1")
+		}
+
+		if len(pkg) == 0 {
+			return v.Name()
+		}
+
+		return fmt.Sprintf("%s.%s", pkg[0], strings.Title(v.Name()))
 	case spec.ArrayType:
 		if len(pkg) > 1 {
 			panic("package cannot be more than 1")
@@ -2,6 +2,7 @@ package gogen
 
 const (
 	interval      = "internal/"
+	cmdDir        = "cmd"
 	typesPacket   = "types"
 	configDir     = interval + "config"
 	contextDir    = interval + "svc"
@@ -60,6 +60,59 @@ public class {{.className}} extends {{.superClassName}} {
 {{.indent}}public void set{{.property}}({{.type}} {{.propertyValue}}) {
 {{.indent}}	this.{{.tagValue}} = {{.propertyValue}};
 {{.indent}}}
+`
+	enumTemplate = `// Code generated by goctl. DO NOT EDIT.
+package com.xhb.logic.http.packet.{{.packet}}.model;
+
+import com.google.gson.JsonDeserializationContext;
+import com.google.gson.JsonDeserializer;
+import com.google.gson.JsonElement;
+import com.google.gson.JsonPrimitive;
+import com.google.gson.JsonSerializationContext;
+import com.google.gson.JsonSerializer;
+import com.google.gson.annotations.JsonAdapter;
+
+import java.lang.reflect.Type;
+
+import org.jetbrains.annotations.Nullable;
+
+@JsonAdapter({{.className}}.Adapter.class)
+public enum {{.className}} {
+{{.members}}
+
+	private final {{.valueType}} value;
+
+	{{.className}}({{.valueType}} value) {
+		this.value = value;
+	}
+
+	public {{.valueType}} getValue() {
+		return this.value;
+	}
+
+	@Nullable
+	public static {{.className}} fromValue({{.valueType}} value) {
+		for ({{.className}} item : values()) {
+			if ({{if .isString}}item.value.equals(value){{else}}item.value == value{{end}}) {
+				return item;
+			}
+		}
+		return null;
+	}
+
+	// Adapter writes the enum by its value rather than its name, which is expected by the server
+	public static class Adapter implements JsonSerializer<{{.className}}>, JsonDeserializer<{{.className}}> {
+		@Override
+		public JsonElement serialize({{.className}} src, Type typeOfSrc, JsonSerializationContext context) {
+			return new JsonPrimitive(src.value);
+		}
+
+		@Override
+		public {{.className}} deserialize(JsonElement json, Type typeOfT, JsonDeserializationContext context) {
+			return fromValue(json.getAs{{.valueGetter}}());
+		}
+	}
+}
 `
 	httpResponseData = "import com.xhb.core.response.HttpResponseData;"
 	httpData         = "import com.xhb.core.packet.HttpData;"
@@ -103,6 +156,10 @@ func genComponents(dir, packetName string, api *spec.ApiSpec) error {
 }
 
 func (c *componentsContext) createComponent(dir, packetName string, ty spec.Type) error {
+	if enumType, ok := ty.(spec.EnumType); ok {
+		return c.createEnum(dir, packetName, enumType)
+	}
+
 	defineStruct, done, err := c.checkStruct(ty)
 	if done {
 		return err
@@ -173,7 +230,75 @@ func (c *componentsContext) createComponent(dir, packetName string, ty spec.Type
 	return err
 }
 
+func (c *componentsContext) createEnum(dir, packetName string, enumType spec.EnumType) error {
+	modelFile := util.Title(enumType.Name()) + ".java"
+	filename := path.Join(dir, modelDir, modelFile)
+	if err := util.RemoveOrQuit(filename); err != nil {
+		return err
+	}
+
+	valueType, err := specTypeToJava(enumType.Value)
+	if err != nil {
+		return err
+	}
+
+	var members strings.Builder
+	for index, member := range enumType.Members {
+		for _, doc := range member.Docs {
+			writeIndent(&members, 1)
+			members.WriteString(doc + util.NL)
+		}
+
+		writeIndent(&members, 1)
+		members.WriteString(fmt.Sprintf("%s(%s)", apiutil.ToUpper(apiutil.ToSnakeCase(member.Name)), member.Value))
+		if index == len(enumType.Members)-1 {
+			members.WriteString(";")
+		} else {
+			members.WriteString(",")
+		}
+
+		if len(member.Comment) > 0 {
+			members.WriteString(" " + member.Comment)
+		}
+
+		if index != len(enumType.Members)-1 {
+			members.WriteString(util.NL)
+		}
+	}
+
+	fp, created, err := apiutil.MaybeCreateFile(dir, modelDir, modelFile)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	defer fp.Close()
+
+	buffer := new(bytes.Buffer)
+	t := template.Must(template.New("enumType").Parse(enumTemplate))
+	err = t.Execute(buffer, map[string]interface{}{
+		"packet":      packetName,
+		"className":   util.Title(enumType.Name()),
+		"members":     members.String(),
+		"valueType":   valueType,
+		"isString":    valueType == "String",
+		"valueGetter": util.Title(valueType),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fp.WriteString(formatSource(buffer.String()))
+	return err
+}
+
 func (c *componentsContext) checkStruct(ty spec.Type) (spec.DefineStruct, bool, error) {
+	// java has no type alias, the members refer to the underlying type directly
+	if _, ok := ty.(spec.AliasType); ok {
+		return spec.DefineStruct{}, true, nil
+	}
+
 	defineStruct, ok := ty.(spec.DefineStruct)
 	if !ok {
 		return spec.DefineStruct{}, true, errors.New("unsupported type %s" + ty.Name())
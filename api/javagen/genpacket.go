@@ -40,9 +40,13 @@ public class {{.packetName}} extends HttpPacket<{{.responseType}}> {
 `
 
 func genPacket(dir, packetName string, api *spec.ApiSpec) error {
-	for _, route := range api.Service.Routes() {
-		if err := createWith(dir, api, route, packetName); err != nil {
-			return err
+	for _, group := range api.Service.Groups {
+		for _, route := range group.Routes {
+			// the packet requests the path served by the server, which is joined with the prefix
+			route.Path = group.JoinPrefix(route.Path)
+			if err := createWith(dir, api, route, packetName); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -70,7 +70,7 @@ func indentString(indent int) string {
 
 func specTypeToJava(tp spec.Type) (string, error) {
 	switch v := tp.(type) {
-	case spec.DefineStruct:
+	case spec.DefineStruct, spec.EnumType:
 		return util.Title(tp.Name()), nil
 	case spec.PrimitiveType:
 		r, ok := primitiveType(tp.Name())
@@ -95,8 +95,9 @@ func specTypeToJava(tp spec.Type) (string, error) {
 			return "", err
 		}
 
+		_, isEnum := v.Value.(spec.EnumType)
 		s := getBaseType(valueType)
-		if len(s) == 0 {
+		if len(s) == 0 && !isEnum {
 			return s, errors.New("unsupported primitive type " + tp.Name())
 		}
 
@@ -105,6 +106,8 @@ func specTypeToJava(tp spec.Type) (string, error) {
 		return "Object", nil
 	case spec.PointerType:
 		return specTypeToJava(v.Type)
+	case spec.AliasType:
+		return specTypeToJava(v.Value)
 	}
 
 	return "", errors.New("unsupported primitive type " + tp.Name())
@@ -122,6 +125,8 @@ func getBaseType(valueType string) string {
 		return "Double[]"
 	case "boolean":
 		return "Boolean[]"
+	case "String":
+		return "String[]"
 	default:
 		return ""
 	}
@@ -7,6 +7,7 @@ import (
 	"text/template"
 
 	"github.com/iancoleman/strcase"
+	"github.com/weitrue/goctl/api/spec"
 	"github.com/weitrue/goctl/api/util"
 )
 
@@ -16,6 +17,11 @@ var funcsMap = template.FuncMap{
 	"parseType":       parseType,
 	"add":             add,
 	"upperCase":       upperCase,
+	"upperSnakeCase":  upperSnakeCase,
+	"isAliasType":     isAliasType,
+	"aliasValueType":  aliasValueType,
+	"isEnumType":      isEnumType,
+	"hasEnumType":     hasEnumType,
 }
 
 func lowCamelCase(s string) string {
@@ -117,3 +123,44 @@ func add(a, i int) int {
 func upperCase(s string) string {
 	return strings.ToUpper(s)
 }
+
+func upperSnakeCase(s string) string {
+	return util.ToUpper(util.ToSnakeCase(s))
+}
+
+func isAliasType(tp spec.Type) bool {
+	_, ok := tp.(spec.AliasType)
+	return ok
+}
+
+// aliasValueType returns the kotlin type of an alias value, type alias can only be
+// declared at top level, so the data classes nested in object need to be qualified.
+func aliasValueType(tp spec.Type, object string) string {
+	switch v := tp.(type) {
+	case spec.DefineStruct, spec.EnumType:
+		return object + "." + v.Name()
+	case spec.ArrayType:
+		return "List<" + aliasValueType(v.Value, object) + ">"
+	case spec.MapType:
+		return "Map<String," + aliasValueType(v.Value, object) + ">"
+	case spec.PointerType:
+		return aliasValueType(v.Type, object)
+	}
+
+	return parseType(tp.Name())
+}
+
+func isEnumType(tp spec.Type) bool {
+	_, ok := tp.(spec.EnumType)
+	return ok
+}
+
+func hasEnumType(types []spec.Type) bool {
+	for _, tp := range types {
+		if isEnumType(tp) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -75,25 +75,48 @@ suspend fun apiRequest(
 `
 	apiTemplate = `package {{with .Info}}{{.Desc}}{{end}}
 
-import com.google.gson.Gson
+import com.google.gson.Gson{{if hasEnumType .Types}}
+import com.google.gson.JsonDeserializationContext
+import com.google.gson.JsonDeserializer
+import com.google.gson.JsonElement
+import com.google.gson.JsonPrimitive
+import com.google.gson.JsonSerializationContext
+import com.google.gson.JsonSerializer
+import com.google.gson.annotations.JsonAdapter
+import java.lang.reflect.Type{{end}}
+{{range .Types}}{{if isAliasType .}}
+typealias {{.Name}} = {{aliasValueType .Value $.Info.Title}}{{end}}{{end}}
 
 object {{with .Info}}{{.Title}}{{end}}{
-	{{range .Types}}
+	{{range .Types}}{{if isAliasType .}}{{else if isEnumType .}}{{$valueType := parseType .Value.Name}}{{$length := (len .Members)}}
+	@JsonAdapter({{.Name}}.Adapter::class)
+	enum class {{.Name}}(val value: {{$valueType}}) {
+		{{- range $i,$item := .Members}}
+		{{upperSnakeCase .Name}}({{.Value}}){{if ne $i (add $length -1)}},{{else}};{{end}}{{end}}
+
+		class Adapter : JsonSerializer<{{.Name}}>, JsonDeserializer<{{.Name}}> {
+			override fun serialize(src: {{.Name}}, typeOfSrc: Type, context: JsonSerializationContext): JsonElement =
+				JsonPrimitive(src.value)
+
+			override fun deserialize(json: JsonElement, typeOfT: Type, context: JsonDeserializationContext): {{.Name}} =
+				values().first { it.value == json.as{{$valueType}} }
+		}
+	}{{else}}
 	data class {{.Name}}({{$length := (len .Members)}}{{range $i,$item := .Members}}
-		val {{with $item}}{{lowCamelCase .Name}}: {{parseType .Type}}{{end}}{{if ne $i (add $length -1)}},{{end}}{{end}}
-	){{end}}
+		val {{with $item}}{{lowCamelCase .Name}}: {{parseType .Type.Name}}{{end}}{{if ne $i (add $length -1)}},{{end}}{{end}}
+	){{end}}{{end}}
 	{{with .Service}}
-	{{range .Routes}}suspend fun {{routeToFuncName .Method .Path}}({{with .RequestType}}{{if ne .Name ""}}
+	{{range $group := .Groups}}{{range .Routes}}suspend fun {{routeToFuncName .Method ($group.JoinPrefix .Path)}}({{with .RequestType}}{{if ne .Name ""}}
 		req:{{.Name}},{{end}}{{end}}
 		onOk: (({{with .ResponseType}}{{.Name}}{{end}}) -> Unit)? = null,
         onFail: ((String) -> Unit)? = null,
         eventually: (() -> Unit)? = null
     ){
-        apiRequest("{{upperCase .Method}}","{{.Path}}",{{with .RequestType}}{{if ne .Name ""}}body=req,{{end}}{{end}} onOk = { {{with .ResponseType}}
+        apiRequest("{{upperCase .Method}}","{{$group.JoinPrefix .Path}}",{{with .RequestType}}{{if ne .Name ""}}body=req,{{end}}{{end}} onOk = { {{with .ResponseType}}
             onOk?.invoke({{if ne .Name ""}}Gson().fromJson(it,{{.Name}}::class.java){{end}}){{end}}
         }, onFail = onFail, eventually =eventually)
     }
-	{{end}}{{end}}
+	{{end}}{{end}}{{end}}
 }`
 )
 
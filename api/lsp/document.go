@@ -0,0 +1,363 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/parser/g4/ast"
+	"github.com/weitrue/goctl/api/spec"
+)
+
+const diagnosticSource = "goctl"
+
+var errorPositionRegex = regexp.MustCompile(`(?s)^(.*?)\s*line (\d+):(\d+)\s+(.*)$`)
+
+type (
+	document struct {
+		uri  string
+		path string
+		text string
+		// analysis is the result of the last successful parsing, it is kept while the text
+		// is broken, so that the navigation still works as you type
+		analysis *analysis
+	}
+
+	// analysis indexes the type declarations and references of a document and its imported files
+	analysis struct {
+		types map[string]ast.TypeExpr
+		refs  []ast.Expr
+	}
+)
+
+func newDocument(uri, text string) *document {
+	return &document{
+		uri:  uri,
+		path: uriToPath(uri),
+		text: text,
+	}
+}
+
+// update parses the text and returns the diagnostics of document
+func (d *document) update(text string) []Diagnostic {
+	d.text = text
+	api, err := ast.NewParser().ParseContent(text, d.path)
+	if err != nil {
+		return []Diagnostic{d.errorDiagnostic(err)}
+	}
+
+	d.analysis = newAnalysis(api)
+
+	sp, err := parser.ParseContent(text, d.path)
+	if err != nil {
+		return []Diagnostic{d.errorDiagnostic(err)}
+	}
+
+	return d.validationDiagnostics(sp)
+}
+
+// errorDiagnostic converts the parsing error like "user.api line 3:5  msg" into diagnostic,
+// the error of imported file is reported at the import statement
+func (d *document) errorDiagnostic(err error) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: severityError,
+		Source:   diagnosticSource,
+		Message:  err.Error(),
+	}
+
+	match := errorPositionRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return diagnostic
+	}
+
+	line, _ := strconv.Atoi(match[2])
+	column, _ := strconv.Atoi(match[3])
+	file := cleanPath(match[1])
+	if len(file) == 0 || file == d.path {
+		diagnostic.Message = match[4]
+		diagnostic.Range = d.tokenRange(line-1, column)
+		return diagnostic
+	}
+
+	base := filepath.Base(file)
+	for index, each := range strings.Split(d.text, "\n") {
+		if column := strings.Index(each, base); column >= 0 && strings.Contains(each, `"`) {
+			diagnostic.Range = Range{
+				Start: Position{Line: index, Character: column},
+				End:   Position{Line: index, Character: column + len(base)},
+			}
+			break
+		}
+	}
+
+	return diagnostic
+}
+
+// validationDiagnostics reports the invalid tag options, such as range=[10:1]
+func (d *document) validationDiagnostics(sp *spec.ApiSpec) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for _, tp := range sp.Types {
+		ds, ok := tp.(spec.DefineStruct)
+		if !ok {
+			continue
+		}
+
+		for _, member := range ds.Members {
+			if member.IsInline {
+				continue
+			}
+
+			if _, err := member.GetValidationRule(); err != nil {
+				tag := d.fieldTag(ds.Name(), member.Name)
+				if tag == nil {
+					continue
+				}
+
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    exprRange(tag),
+					Severity: severityError,
+					Source:   diagnosticSource,
+					Message:  fmt.Sprintf("%s.%s: %s", ds.Name(), member.Name, err.Error()),
+				})
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+func (d *document) fieldTag(typeName, fieldName string) ast.Expr {
+	if d.analysis == nil {
+		return nil
+	}
+
+	st, ok := d.analysis.types[typeName].(*ast.TypeStruct)
+	if !ok || cleanPath(st.Name.Prefix()) != d.path {
+		return nil
+	}
+
+	for _, field := range st.Fields {
+		if field.Name != nil && field.Name.Text() == fieldName && field.Tag != nil {
+			return field.Tag
+		}
+	}
+
+	return nil
+}
+
+// tokenRange returns the range of identifier which starts at the position
+func (d *document) tokenRange(line, column int) Range {
+	start := Position{Line: line, Character: column}
+	end := Position{Line: line, Character: column + 1}
+	lines := strings.Split(d.text, "\n")
+	if line >= 0 && line < len(lines) {
+		text := []rune(lines[line])
+		index := column
+		for index < len(text) && isIdentRune(text[index]) {
+			index++
+		}
+		if index > column {
+			end.Character = index
+		}
+	}
+
+	return Range{Start: start, End: end}
+}
+
+// wordAt returns the identifier at the position
+func (d *document) wordAt(pos Position) string {
+	lines := strings.Split(d.text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return ""
+	}
+
+	text := []rune(lines[pos.Line])
+	if pos.Character < 0 || pos.Character > len(text) {
+		return ""
+	}
+
+	start, end := pos.Character, pos.Character
+	for start > 0 && isIdentRune(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isIdentRune(text[end]) {
+		end++
+	}
+
+	return string(text[start:end])
+}
+
+// offsetAt returns the rune offset of the position in text
+func (d *document) offsetAt(pos Position) int {
+	var offset int
+	for index, each := range strings.SplitAfter(d.text, "\n") {
+		if index == pos.Line {
+			line := []rune(each)
+			if pos.Character < len(line) {
+				return offset + pos.Character
+			}
+			return offset + len(line)
+		}
+		offset += len([]rune(each))
+	}
+
+	return offset
+}
+
+func newAnalysis(api *ast.Api) *analysis {
+	a := &analysis{
+		types: make(map[string]ast.TypeExpr),
+	}
+
+	for _, tp := range api.Type {
+		a.types[tp.NameExpr().Text()] = tp
+		switch v := tp.(type) {
+		case *ast.TypeStruct:
+			for _, field := range v.Fields {
+				a.refs = append(a.refs, dataTypeRefs(field.DataType)...)
+			}
+		case *ast.TypeAlias:
+			a.refs = append(a.refs, dataTypeRefs(v.DataType)...)
+		}
+	}
+
+	for _, service := range api.Service {
+		for _, route := range service.ServiceApi.ServiceRoute {
+			if route.Route.Req != nil {
+				a.refs = append(a.refs, dataTypeRefs(route.Route.Req.Name)...)
+			}
+			if route.Route.Reply != nil {
+				a.refs = append(a.refs, dataTypeRefs(route.Route.Reply.Name)...)
+			}
+		}
+	}
+
+	return a
+}
+
+func dataTypeRefs(dt ast.DataType) []ast.Expr {
+	switch v := dt.(type) {
+	case *ast.Literal:
+		return []ast.Expr{v.Literal}
+	case *ast.Pointer:
+		return []ast.Expr{v.Name}
+	case *ast.Array:
+		return dataTypeRefs(v.Literal)
+	case *ast.Map:
+		return dataTypeRefs(v.Value)
+	default:
+		return nil
+	}
+}
+
+// definition returns the declaration of type
+func (a *analysis) definition(name string) (ast.TypeExpr, bool) {
+	tp, ok := a.types[name]
+	return tp, ok
+}
+
+// references returns the expressions which refer to the type
+func (a *analysis) references(name string) []ast.Expr {
+	var result []ast.Expr
+	for _, each := range a.refs {
+		if each.Text() == name {
+			result = append(result, each)
+		}
+	}
+
+	return result
+}
+
+// render returns the declaration of type in api syntax
+func render(tp ast.TypeExpr) string {
+	var builder strings.Builder
+	for _, doc := range tp.Doc() {
+		builder.WriteString(doc.Text() + "\n")
+	}
+
+	switch v := tp.(type) {
+	case *ast.TypeStruct:
+		fmt.Fprintf(&builder, "type %s {\n", v.Name.Text())
+		for _, field := range v.Fields {
+			builder.WriteString("\t")
+			if !field.IsAnonymous {
+				builder.WriteString(field.Name.Text() + " ")
+			}
+			builder.WriteString(field.DataType.Expr().Text())
+			if field.Tag != nil {
+				builder.WriteString(" " + field.Tag.Text())
+			}
+			if field.CommentExpr != nil {
+				builder.WriteString(" " + field.CommentExpr.Text())
+			}
+			builder.WriteString("\n")
+		}
+		builder.WriteString("}")
+	case *ast.TypeAlias:
+		builder.WriteString("type " + v.Name.Text())
+		if v.Assign != nil {
+			builder.WriteString(" =")
+		}
+		builder.WriteString(" " + v.DataType.Expr().Text())
+	case *ast.TypeEnum:
+		fmt.Fprintf(&builder, "type %s enum {\n", v.Name.Text())
+		for _, member := range v.Members {
+			fmt.Fprintf(&builder, "\t%s = %s\n", member.Name.Text(), member.Value.Text())
+		}
+		builder.WriteString("}")
+	}
+
+	return builder.String()
+}
+
+func exprLocation(expr ast.Expr) Location {
+	return Location{
+		URI:   pathToURI(cleanPath(expr.Prefix())),
+		Range: exprRange(expr),
+	}
+}
+
+// exprRange converts the position of expression, whose line is one-based and column is zero-based
+func exprRange(expr ast.Expr) Range {
+	line := expr.Line() - 1
+	return Range{
+		Start: Position{Line: line, Character: expr.Column()},
+		End:   Position{Line: line, Character: expr.Column() + len([]rune(expr.Text()))},
+	}
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// cleanPath removes the quotes of import value which are kept in the prefix of expression
+func cleanPath(path string) string {
+	path = strings.TrimSpace(strings.ReplaceAll(path, `"`, ""))
+	if len(path) == 0 {
+		return ""
+	}
+
+	return filepath.Clean(path)
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	u := url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(path),
+	}
+	return u.String()
+}
@@ -0,0 +1,129 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	jsonrpcVersion = "2.0"
+
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+type (
+	// message is the union of request, response and notification of JSON-RPC 2.0,
+	// a notification has no id
+	message struct {
+		Jsonrpc string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id,omitempty"`
+		Method  string           `json:"method,omitempty"`
+		Params  json.RawMessage  `json:"params,omitempty"`
+		Result  interface{}      `json:"result,omitempty"`
+		Error   *responseError   `json:"error,omitempty"`
+	}
+
+	responseError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// conn reads and writes the messages which are framed by the Content-Length header
+	conn struct {
+		reader *bufio.Reader
+		writer io.Writer
+		lock   sync.Mutex
+	}
+)
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		reader: bufio.NewReader(r),
+		writer: w,
+	}
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+func (c *conn) read() (*message, error) {
+	header, err := textproto.NewReader(c.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length <= 0 {
+		return nil, errors.New("invalid Content-Length header")
+	}
+
+	data := make([]byte, length)
+	if _, err = io.ReadFull(c.reader, data); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err = json.Unmarshal(data, &msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+
+	return &msg, nil
+}
+
+func (c *conn) write(msg *message) error {
+	msg.Jsonrpc = jsonrpcVersion
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, err = fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(data)); err != nil {
+		return err
+	}
+
+	_, err = c.writer.Write(data)
+	return err
+}
+
+func (c *conn) reply(id *json.RawMessage, result interface{}, err error) error {
+	msg := &message{ID: id}
+	if err != nil {
+		var rpcErr *responseError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		msg.Error = rpcErr
+	} else if result == nil {
+		// the result is required in a successful response, null is a valid value
+		msg.Result = json.RawMessage("null")
+	} else {
+		msg.Result = result
+	}
+
+	return c.write(msg)
+}
+
+func (c *conn) notify(method string, params interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return c.write(&message{
+		Method: method,
+		Params: data,
+	})
+}
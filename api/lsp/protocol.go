@@ -0,0 +1,142 @@
+package lsp
+
+// the subset of Language Server Protocol 3.16 which is used by api language server,
+// see https://microsoft.github.io/language-server-protocol/specifications/specification-3-16/
+
+const (
+	textDocumentSyncFull = 1
+
+	severityError = 1
+
+	completionKindClass   = 7
+	completionKindKeyword = 14
+
+	markupKindMarkdown = "markdown"
+)
+
+type (
+	// Position is zero-based, the character counts the runes of line
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+
+	Range struct {
+		Start Position `json:"start"`
+		End   Position `json:"end"`
+	}
+
+	Location struct {
+		URI   string `json:"uri"`
+		Range Range  `json:"range"`
+	}
+
+	Diagnostic struct {
+		Range    Range  `json:"range"`
+		Severity int    `json:"severity"`
+		Source   string `json:"source"`
+		Message  string `json:"message"`
+	}
+
+	TextEdit struct {
+		Range   Range  `json:"range"`
+		NewText string `json:"newText"`
+	}
+
+	TextDocumentIdentifier struct {
+		URI string `json:"uri"`
+	}
+
+	TextDocumentItem struct {
+		URI        string `json:"uri"`
+		LanguageID string `json:"languageId"`
+		Version    int    `json:"version"`
+		Text       string `json:"text"`
+	}
+
+	TextDocumentPositionParams struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+		Position     Position               `json:"position"`
+	}
+
+	DidOpenTextDocumentParams struct {
+		TextDocument TextDocumentItem `json:"textDocument"`
+	}
+
+	DidChangeTextDocumentParams struct {
+		TextDocument   TextDocumentIdentifier `json:"textDocument"`
+		ContentChanges []struct {
+			// Range is not supported since the server declares the full synchronization
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+
+	DidSaveTextDocumentParams struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+	}
+
+	DidCloseTextDocumentParams struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+	}
+
+	ReferenceParams struct {
+		TextDocumentPositionParams
+		Context struct {
+			IncludeDeclaration bool `json:"includeDeclaration"`
+		} `json:"context"`
+	}
+
+	DocumentFormattingParams struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+	}
+
+	PublishDiagnosticsParams struct {
+		URI         string       `json:"uri"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}
+
+	CompletionItem struct {
+		Label  string `json:"label"`
+		Kind   int    `json:"kind"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	MarkupContent struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	}
+
+	Hover struct {
+		Contents MarkupContent `json:"contents"`
+		Range    *Range        `json:"range,omitempty"`
+	}
+
+	InitializeResult struct {
+		Capabilities ServerCapabilities `json:"capabilities"`
+		ServerInfo   ServerInfo         `json:"serverInfo"`
+	}
+
+	ServerInfo struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	ServerCapabilities struct {
+		TextDocumentSync           TextDocumentSyncOptions `json:"textDocumentSync"`
+		CompletionProvider         CompletionOptions       `json:"completionProvider"`
+		HoverProvider              bool                    `json:"hoverProvider"`
+		DefinitionProvider         bool                    `json:"definitionProvider"`
+		ReferencesProvider         bool                    `json:"referencesProvider"`
+		DocumentFormattingProvider bool                    `json:"documentFormattingProvider"`
+	}
+
+	TextDocumentSyncOptions struct {
+		OpenClose bool `json:"openClose"`
+		Change    int  `json:"change"`
+		Save      bool `json:"save"`
+	}
+
+	CompletionOptions struct {
+		TriggerCharacters []string `json:"triggerCharacters"`
+	}
+)
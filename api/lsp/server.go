@@ -0,0 +1,387 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/api/format"
+	"github.com/weitrue/goctl/internal/version"
+)
+
+const serverName = "goctl-api-lsp"
+
+var (
+	errShutdown = errors.New("server is shut down")
+
+	basicTypes = []string{
+		"bool", "string", "byte", "rune", "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "interface{}",
+	}
+
+	serverKeys = []CompletionItem{
+		{Label: "jwt", Kind: completionKindKeyword, Detail: "the name of jwt auth config, such as Auth"},
+		{Label: "group", Kind: completionKindKeyword, Detail: "the folder of handlers and logics"},
+		{Label: "middleware", Kind: completionKindKeyword, Detail: "the middlewares separated by comma"},
+		{Label: "prefix", Kind: completionKindKeyword, Detail: "the route prefix, such as /api/v1"},
+		{Label: "signature", Kind: completionKindKeyword, Detail: "enable the signature verification"},
+		{Label: "handler", Kind: completionKindKeyword, Detail: "the handler name of route"},
+	}
+)
+
+type (
+	handlerFunc func(s *Server, params json.RawMessage) (interface{}, error)
+
+	// Server is a language server of api files, it serves one client over a stream, such as stdio
+	Server struct {
+		conn     *conn
+		docs     map[string]*document
+		shutdown bool
+	}
+)
+
+var handlers = map[string]handlerFunc{
+	"initialize":                      (*Server).initialize,
+	"initialized":                     (*Server).ignore,
+	"shutdown":                        (*Server).onShutdown,
+	"textDocument/didOpen":            (*Server).didOpen,
+	"textDocument/didChange":          (*Server).didChange,
+	"textDocument/didSave":            (*Server).didSave,
+	"textDocument/didClose":           (*Server).didClose,
+	"textDocument/definition":         (*Server).definition,
+	"textDocument/references":         (*Server).references,
+	"textDocument/hover":              (*Server).hover,
+	"textDocument/completion":         (*Server).completion,
+	"textDocument/formatting":         (*Server).formatting,
+	"workspace/didChangeWatchedFiles": (*Server).ignore,
+}
+
+// LspCommand runs the language server over stdio, the editors start it as a subprocess
+func LspCommand(_ *cli.Context) error {
+	return NewServer(os.Stdin, os.Stdout).Serve()
+}
+
+// NewServer creates a Server which reads requests from r and writes responses to w
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn: newConn(r, w),
+		docs: make(map[string]*document),
+	}
+}
+
+// Serve handles the messages until the exit notification is received or the stream is closed
+func (s *Server) Serve() error {
+	for {
+		msg, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var rpcErr *responseError
+			if errors.As(err, &rpcErr) {
+				if err = s.conn.reply(nil, nil, rpcErr); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+
+		if err = s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	handler, ok := handlers[msg.Method]
+	var result interface{}
+	var err error
+	switch {
+	case s.shutdown:
+		err = &responseError{Code: codeInvalidParams, Message: errShutdown.Error()}
+	case !ok:
+		err = &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", msg.Method)}
+	default:
+		result, err = handler(s, msg.Params)
+	}
+
+	// the notification is never replied
+	if msg.ID == nil {
+		return nil
+	}
+
+	return s.conn.reply(msg.ID, result, err)
+}
+
+func (s *Server) initialize(_ json.RawMessage) (interface{}, error) {
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      true,
+			},
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"(", "[", "*", " "},
+			},
+			HoverProvider:              true,
+			DefinitionProvider:         true,
+			ReferencesProvider:         true,
+			DocumentFormattingProvider: true,
+		},
+		ServerInfo: ServerInfo{
+			Name:    serverName,
+			Version: version.BuildVersion,
+		},
+	}, nil
+}
+
+func (s *Server) ignore(_ json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
+
+func (s *Server) onShutdown(_ json.RawMessage) (interface{}, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) (interface{}, error) {
+	var p DidOpenTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc := newDocument(p.TextDocument.URI, p.TextDocument.Text)
+	s.docs[doc.uri] = doc
+	return nil, s.publish(doc, doc.update(p.TextDocument.Text))
+}
+
+func (s *Server) didChange(params json.RawMessage) (interface{}, error) {
+	var p DidChangeTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok || len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+
+	text := p.ContentChanges[len(p.ContentChanges)-1].Text
+	return nil, s.publish(doc, doc.update(text))
+}
+
+// didSave reparses all documents, since the imported files may be changed
+func (s *Server) didSave(_ json.RawMessage) (interface{}, error) {
+	for _, doc := range s.docs {
+		if err := s.publish(doc, doc.update(doc.text)); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Server) didClose(params json.RawMessage) (interface{}, error) {
+	var p DidCloseTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(s.docs, p.TextDocument.URI)
+	return nil, s.publish(doc, nil)
+}
+
+func (s *Server) definition(params json.RawMessage) (interface{}, error) {
+	doc, word, err := s.lookup(params)
+	if err != nil || doc == nil || doc.analysis == nil {
+		return nil, err
+	}
+
+	tp, ok := doc.analysis.definition(word)
+	if !ok {
+		return nil, nil
+	}
+
+	return exprLocation(tp.NameExpr()), nil
+}
+
+func (s *Server) references(params json.RawMessage) (interface{}, error) {
+	var p ReferenceParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok || doc.analysis == nil {
+		return nil, nil
+	}
+
+	word := doc.wordAt(p.Position)
+	tp, ok := doc.analysis.definition(word)
+	if !ok {
+		return nil, nil
+	}
+
+	locations := make([]Location, 0)
+	if p.Context.IncludeDeclaration {
+		locations = append(locations, exprLocation(tp.NameExpr()))
+	}
+	for _, each := range doc.analysis.references(word) {
+		locations = append(locations, exprLocation(each))
+	}
+
+	return locations, nil
+}
+
+func (s *Server) hover(params json.RawMessage) (interface{}, error) {
+	doc, word, err := s.lookup(params)
+	if err != nil || doc == nil || doc.analysis == nil {
+		return nil, err
+	}
+
+	tp, ok := doc.analysis.definition(word)
+	if !ok {
+		return nil, nil
+	}
+
+	return Hover{
+		Contents: MarkupContent{
+			Kind:  markupKindMarkdown,
+			Value: fmt.Sprintf("```api\n%s\n```", render(tp)),
+		},
+	}, nil
+}
+
+// completion offers the keys in @server block, otherwise the type names
+func (s *Server) completion(params json.RawMessage) (interface{}, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, nil
+	}
+
+	if inServerBlock(doc.text, doc.offsetAt(p.Position)) {
+		return serverKeys, nil
+	}
+
+	items := make([]CompletionItem, 0, len(basicTypes))
+	if doc.analysis != nil {
+		var names []string
+		for name := range doc.analysis.types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			items = append(items, CompletionItem{Label: name, Kind: completionKindClass})
+		}
+	}
+	for _, each := range basicTypes {
+		items = append(items, CompletionItem{Label: each, Kind: completionKindKeyword})
+	}
+
+	return items, nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (interface{}, error) {
+	var p DocumentFormattingParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, nil
+	}
+
+	formatted, err := format.ApiFormatByContent(doc.text)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted += "\n"
+	if formatted == doc.text {
+		return []TextEdit{}, nil
+	}
+
+	lines := strings.Split(doc.text, "\n")
+	return []TextEdit{
+		{
+			Range: Range{
+				End: Position{Line: len(lines) - 1, Character: len([]rune(lines[len(lines)-1]))},
+			},
+			NewText: formatted,
+		},
+	}, nil
+}
+
+func (s *Server) lookup(params json.RawMessage) (*document, string, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, "", err
+	}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, "", nil
+	}
+
+	return doc, doc.wordAt(p.Position), nil
+}
+
+func (s *Server) publish(doc *document, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = make([]Diagnostic, 0)
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// inServerBlock returns true if the offset is between @server( and )
+func inServerBlock(text string, offset int) bool {
+	runes := []rune(text)
+	if offset > len(runes) {
+		offset = len(runes)
+	}
+
+	before := string(runes[:offset])
+	index := strings.LastIndex(before, "@server")
+	if index < 0 {
+		return false
+	}
+
+	return !strings.Contains(before[index:], ")")
+}
+
+func unmarshalParams(params json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
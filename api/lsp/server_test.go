@@ -0,0 +1,254 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTypesApi = `type User {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+
+	testMainApi = `import "types.api"
+
+type Status enum {
+	Active = 1
+}
+
+type UserReq {
+	Id     int64  ` + "`path:\"id\"`" + `
+	Status Status ` + "`json:\"status\"`" + `
+}
+
+@server(
+	group: user
+)
+service user-api {
+	@handler getUser
+	get /users/:id (UserReq) returns (User)
+}
+`
+)
+
+type testClient struct {
+	input bytes.Buffer
+	id    int
+}
+
+func (c *testClient) send(method string, params interface{}) {
+	c.id++
+	c.write(&message{ID: rawID(c.id), Method: method}, params)
+}
+
+func (c *testClient) notify(method string, params interface{}) {
+	c.write(&message{Method: method}, params)
+}
+
+func (c *testClient) write(msg *message, params interface{}) {
+	data, _ := json.Marshal(params)
+	msg.Params = data
+	msg.Jsonrpc = jsonrpcVersion
+	body, _ := json.Marshal(msg)
+	fmt.Fprintf(&c.input, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func rawID(id int) *json.RawMessage {
+	raw := json.RawMessage(fmt.Sprint(id))
+	return &raw
+}
+
+type testMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// run serves the requests and returns the responses by id and the notifications in order
+func (c *testClient) run(t *testing.T) (map[int]testMessage, []testMessage) {
+	var output bytes.Buffer
+	assert.Nil(t, NewServer(&c.input, &output).Serve())
+
+	responses := make(map[int]testMessage)
+	var notifications []testMessage
+	reader := newConn(&output, nil)
+	for {
+		data, err := readRaw(reader)
+		if err != nil {
+			break
+		}
+
+		var msg testMessage
+		assert.Nil(t, json.Unmarshal(data, &msg))
+		if msg.ID != nil {
+			responses[*msg.ID] = msg
+		} else {
+			notifications = append(notifications, msg)
+		}
+	}
+
+	return responses, notifications
+}
+
+// readRaw reads a message and encodes it again, so that it can be decoded with the concrete types
+func readRaw(c *conn) ([]byte, error) {
+	msg, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(msg)
+}
+
+func prepare(t *testing.T) (string, string) {
+	dir, err := ioutil.TempDir("", "lsp")
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "types.api"), []byte(testTypesApi), os.ModePerm))
+	mainFile := filepath.Join(dir, "main.api")
+	assert.Nil(t, ioutil.WriteFile(mainFile, []byte(testMainApi), os.ModePerm))
+	return dir, mainFile
+}
+
+func TestServer(t *testing.T) {
+	dir, mainFile := prepare(t)
+	defer os.RemoveAll(dir)
+
+	uri := pathToURI(mainFile)
+	position := func(line, character int) TextDocumentPositionParams {
+		return TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     Position{Line: line, Character: character},
+		}
+	}
+
+	var c testClient
+	c.send("initialize", map[string]interface{}{})
+	c.notify("initialized", map[string]interface{}{})
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "api", Text: testMainApi},
+	})
+	// 2: the User in returns (User)
+	c.send("textDocument/definition", position(16, 36))
+	// 3: the Status type of field
+	c.send("textDocument/hover", position(8, 9))
+	// 4
+	c.send("textDocument/references", ReferenceParams{
+		TextDocumentPositionParams: position(2, 6),
+		Context: struct {
+			IncludeDeclaration bool `json:"includeDeclaration"`
+		}{IncludeDeclaration: true},
+	})
+	// 5: inside @server
+	c.send("textDocument/completion", position(12, 1))
+	// 6: type position
+	c.send("textDocument/completion", position(8, 8))
+	c.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": strings.Replace(testMainApi, "\tgroup: user", "group: user", 1)}},
+	})
+	// 7
+	c.send("textDocument/formatting", DocumentFormattingParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+	c.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 3},
+		"contentChanges": []map[string]string{{"text": strings.Replace(testMainApi, "(UserReq)", "(Missing)", 1)}},
+	})
+	c.send("shutdown", nil)
+	c.notify("exit", nil)
+
+	responses, notifications := c.run(t)
+
+	var init InitializeResult
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &init))
+	assert.True(t, init.Capabilities.DefinitionProvider)
+
+	var location Location
+	assert.Nil(t, json.Unmarshal(responses[2].Result, &location))
+	assert.Equal(t, pathToURI(filepath.Join(dir, "types.api")), location.URI)
+	assert.Equal(t, Range{Start: Position{Line: 0, Character: 5}, End: Position{Line: 0, Character: 9}}, location.Range)
+
+	var hover Hover
+	assert.Nil(t, json.Unmarshal(responses[3].Result, &hover))
+	assert.Equal(t, "```api\ntype Status enum {\n\tActive = 1\n}\n```", hover.Contents.Value)
+
+	var locations []Location
+	assert.Nil(t, json.Unmarshal(responses[4].Result, &locations))
+	assert.Equal(t, 2, len(locations))
+	assert.Equal(t, 2, locations[0].Range.Start.Line)
+	assert.Equal(t, 8, locations[1].Range.Start.Line)
+
+	var items []CompletionItem
+	assert.Nil(t, json.Unmarshal(responses[5].Result, &items))
+	assert.Equal(t, serverKeys, items)
+
+	assert.Nil(t, json.Unmarshal(responses[6].Result, &items))
+	assert.Equal(t, "Status", items[0].Label)
+	assert.Equal(t, "User", items[1].Label)
+
+	var edits []TextEdit
+	assert.Nil(t, json.Unmarshal(responses[7].Result, &edits))
+	assert.Equal(t, 1, len(edits))
+	assert.Equal(t, testMainApi, edits[0].NewText)
+
+	assert.Equal(t, 3, len(notifications))
+	var diagnostics PublishDiagnosticsParams
+	assert.Nil(t, json.Unmarshal(notifications[0].Params, &diagnostics))
+	assert.Empty(t, diagnostics.Diagnostics)
+	assert.Nil(t, json.Unmarshal(notifications[2].Params, &diagnostics))
+	assert.Equal(t, 1, len(diagnostics.Diagnostics))
+	assert.Equal(t, 16, diagnostics.Diagnostics[0].Range.Start.Line)
+	assert.Contains(t, diagnostics.Diagnostics[0].Message, "Missing")
+}
+
+func TestDocumentDiagnostics(t *testing.T) {
+	dir, mainFile := prepare(t)
+	defer os.RemoveAll(dir)
+
+	doc := newDocument(pathToURI(mainFile), "")
+	diagnostics := doc.update(testMainApi)
+	assert.Empty(t, diagnostics)
+	assert.NotNil(t, doc.analysis)
+
+	diagnostics = doc.update(strings.Replace(testMainApi, "`json:\"status\"`", "`json:\"status,range=[10:1]\"`", 1))
+	assert.Equal(t, 1, len(diagnostics))
+	assert.Equal(t, 8, diagnostics[0].Range.Start.Line)
+	assert.Contains(t, diagnostics[0].Message, "UserReq.Status")
+
+	broken := strings.Replace(testMainApi, "service user-api {", "service user-api", 1)
+	diagnostics = doc.update(broken)
+	assert.Equal(t, 1, len(diagnostics))
+	assert.NotNil(t, doc.analysis, "the last analysis is kept")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "types.api"), []byte("type User {"), os.ModePerm))
+	diagnostics = doc.update(testMainApi)
+	assert.Equal(t, 1, len(diagnostics))
+	assert.Equal(t, 0, diagnostics[0].Range.Start.Line)
+	assert.Equal(t, 8, diagnostics[0].Range.Start.Character)
+}
+
+func TestRenderAlias(t *testing.T) {
+	dir, mainFile := prepare(t)
+	defer os.RemoveAll(dir)
+
+	doc := newDocument(pathToURI(mainFile), "")
+	diagnostics := doc.update(testMainApi + "\ntype UserID = int64\n\ntype UserIDs []int64\n")
+	assert.Empty(t, diagnostics)
+
+	tp, ok := doc.analysis.definition("UserID")
+	assert.True(t, ok)
+	assert.Equal(t, "type UserID = int64", render(tp))
+
+	tp, ok = doc.analysis.definition("UserIDs")
+	assert.True(t, ok)
+	assert.Equal(t, "type UserIDs []int64", render(tp))
+}
@@ -0,0 +1,229 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/urfave/cli"
+
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/spec"
+)
+
+const defaultPort = 8888
+
+type (
+	// typeSet indexes the declared types by name, the parser only resolves the member types
+	// for one level, so the referred types are looked up here
+	typeSet map[string]spec.Type
+
+	// Server serves the routes of api with synthetic responses
+	Server struct {
+		routes   []route
+		types    typeSet
+		validate bool
+		lock     sync.Mutex
+		faker    *faker
+	}
+
+	route struct {
+		method   string
+		path     string
+		segments []string
+		spec     spec.Route
+	}
+)
+
+// MockCommand serves a mock http server for the routes of api file
+func MockCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+
+	port := c.Int("port")
+	if port == 0 {
+		port = defaultPort
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	server := NewServer(api, c.Bool("validate"))
+	for _, each := range server.routes {
+		fmt.Printf("%-7s %s\n", each.method, each.path)
+	}
+
+	fmt.Println(aurora.Green(fmt.Sprintf("Mock server is listening on :%d", port)))
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), server)
+}
+
+// NewServer returns a mock Server of api, the incoming requests are validated against
+// the request types if validate is true
+func NewServer(api *spec.ApiSpec, validate bool) *Server {
+	types := make(typeSet)
+	for _, tp := range api.Types {
+		types[tp.Name()] = tp
+	}
+
+	s := &Server{
+		types:    types,
+		validate: validate,
+		faker:    newFaker(types, time.Now().UnixNano()),
+	}
+
+	services := api.Services
+	if len(services) == 0 {
+		services = []spec.Service{api.Service}
+	}
+	for _, service := range services {
+		for _, group := range service.Groups {
+			for _, r := range group.Routes {
+				path := group.JoinPrefix(r.Path)
+				s.routes = append(s.routes, route{
+					method:   strings.ToUpper(r.Method),
+					path:     path,
+					segments: strings.Split(strings.Trim(path, "/"), "/"),
+					spec:     r,
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+// ServeHTTP responds the matched route with a synthetic response, the CORS headers are set
+// so that the frontend pages can call the mock server directly
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "*")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	matched, params, allowed := s.match(r.Method, r.URL.Path)
+	if matched == nil {
+		if allowed {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		} else {
+			http.NotFound(w, r)
+		}
+		return
+	}
+
+	if s.validate && matched.spec.RequestType != nil {
+		if err := s.validateRequest(matched.spec.RequestType, r, params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if matched.spec.ResponseType == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	s.lock.Lock()
+	body := s.faker.value(matched.spec.ResponseType, "", nil, 0)
+	s.lock.Unlock()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (s *Server) validateRequest(tp spec.Type, r *http.Request, params map[string]string) error {
+	if defined, ok := tp.(spec.DefineStruct); ok {
+		req, err := parseRequest(r, params)
+		if err != nil {
+			return err
+		}
+
+		return s.types.validate(defined, req)
+	}
+
+	// the request is declared as a non-struct type, such as an array, it is decoded from the whole body
+	var body interface{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return fmt.Errorf("invalid json body: %w", err)
+	}
+
+	return s.types.validateValue(tp, "body", body, nil)
+}
+
+// match returns the route of method and path, the path variables like :id are returned in params,
+// allowed is true if the path matches a route with another method
+func (s *Server) match(method, path string) (matched *route, params map[string]string, allowed bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range s.routes {
+		each := &s.routes[i]
+		vars, ok := each.matchPath(segments)
+		if !ok {
+			continue
+		}
+
+		if each.method != method {
+			allowed = true
+			continue
+		}
+
+		// the static segments take precedence over the variables, like httpx router does
+		if matched == nil || len(vars) < len(params) {
+			matched, params = each, vars
+		}
+	}
+
+	return matched, params, allowed && matched == nil
+}
+
+func (r *route) matchPath(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, ":") {
+			vars[segment[1:]] = segments[i]
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
+
+func (s typeSet) resolve(name string) spec.Type {
+	return s[strings.TrimPrefix(name, "*")]
+}
+
+// alias returns the type under alias, the parser only resolves the alias for one level
+func (s typeSet) alias(tp spec.AliasType) spec.Type {
+	if resolved, ok := s.resolve(tp.Name()).(spec.AliasType); ok && resolved.Value != nil {
+		return resolved.Value
+	}
+
+	return tp.Value
+}
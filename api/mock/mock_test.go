@@ -0,0 +1,131 @@
+package mock
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/api/parser"
+)
+
+const testApi = `
+type (
+	Status enum {
+		Active = "active"
+		Banned = "banned"
+	}
+
+	Address {
+		City string ` + "`json:\"city\"`" + `
+	}
+
+	User {
+		Id       int64     ` + "`json:\"id\"`" + `
+		Name     string    ` + "`json:\"name\"`" + `
+		Email    string    ` + "`json:\"email\"`" + `
+		Age      int       ` + "`json:\"age,range=[20:30]\"`" + `
+		Gender   string    ` + "`json:\"gender,options=male|female\"`" + `
+		Status   Status    ` + "`json:\"status\"`" + `
+		Address  Address   ` + "`json:\"address\"`" + `
+		Tags     []string  ` + "`json:\"tags\"`" + `
+		Friends  []*User   ` + "`json:\"friends,optional\"`" + `
+	}
+
+	UserReq {
+		Id   int64  ` + "`path:\"id\"`" + `
+		Lang string ` + "`form:\"lang,options=en|zh,optional\"`" + `
+	}
+
+	CreateReq {
+		Name  string ` + "`json:\"name\"`" + `
+		Age   int    ` + "`json:\"age,range=[1:150]\"`" + `
+		Token string ` + "`header:\"X-Token\"`" + `
+	}
+)
+
+service user-api {
+	@handler getUser
+	get /users/:id (UserReq) returns (User)
+
+	@handler me
+	get /users/me returns (User)
+
+	@handler createUser
+	post /users (CreateReq)
+}
+`
+
+func TestServer(t *testing.T) {
+	filename := "user.api"
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(testApi), os.ModePerm))
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+
+	svr := httptest.NewServer(NewServer(api, true))
+	defer svr.Close()
+
+	resp, err := http.Get(svr.URL + "/users/1?lang=zh")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var user map[string]interface{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+
+	assert.Contains(t, user["email"], "@example.com")
+	assert.GreaterOrEqual(t, user["age"], float64(20))
+	assert.LessOrEqual(t, user["age"], float64(30))
+	assert.Contains(t, []interface{}{"male", "female"}, user["gender"])
+	assert.Contains(t, []interface{}{"active", "banned"}, user["status"])
+	assert.IsType(t, "", user["address"].(map[string]interface{})["city"])
+	assert.NotEmpty(t, user["tags"])
+	assert.IsType(t, "", user["tags"].([]interface{})[0])
+
+	resp, err = http.Get(svr.URL + "/users/me")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, each := range []struct {
+		method string
+		path   string
+		header string
+		body   string
+		status int
+		errMsg string
+	}{
+		{method: http.MethodGet, path: "/users/abc", status: http.StatusBadRequest, errMsg: `"id" expects int64`},
+		{method: http.MethodGet, path: "/users/1?lang=fr", status: http.StatusBadRequest, errMsg: "options"},
+		{method: http.MethodPost, path: "/users", header: "token", body: `{"name":"kevin","age":18}`,
+			status: http.StatusOK},
+		{method: http.MethodPost, path: "/users", header: "token", body: `{"age":18}`,
+			status: http.StatusBadRequest, errMsg: `"name" is not set`},
+		{method: http.MethodPost, path: "/users", header: "token", body: `{"name":"kevin","age":200}`,
+			status: http.StatusBadRequest, errMsg: "out of range"},
+		{method: http.MethodPost, path: "/users", body: `{"name":"kevin","age":18}`,
+			status: http.StatusBadRequest, errMsg: `"X-Token" is not set`},
+		{method: http.MethodPut, path: "/users", status: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/orders", status: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(each.method, svr.URL+each.path, strings.NewReader(each.body))
+		assert.Nil(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if len(each.header) > 0 {
+			req.Header.Set("X-Token", each.header)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		data, err := ioutil.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, each.status, resp.StatusCode, each.path)
+		assert.Contains(t, string(data), each.errMsg)
+	}
+}
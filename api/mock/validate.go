@@ -0,0 +1,294 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/weitrue/goctl/api/spec"
+)
+
+const (
+	pathTagKey     = "path"
+	formTagKey     = "form"
+	headerTagKey   = "header"
+	bodyTagKey     = "json"
+	optionalOption = "optional"
+)
+
+// request is the decoded incoming request which is validated against the request type
+type request struct {
+	path   map[string]string
+	form   map[string][]string
+	header http.Header
+	body   map[string]interface{}
+}
+
+func parseRequest(r *http.Request, path map[string]string) (*request, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	req := &request{
+		path:   path,
+		form:   r.Form,
+		header: r.Header,
+	}
+	if r.ContentLength == 0 || !strings.Contains(r.Header.Get("Content-Type"), "json") {
+		return req, nil
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&req.body); err != nil {
+		return nil, fmt.Errorf("invalid json body: %w", err)
+	}
+
+	return req, nil
+}
+
+// validate checks the members of tp in the same way as httpx.Parse, the absent required members,
+// the mismatched types and the values out of options or range are reported
+func (s typeSet) validate(tp spec.DefineStruct, req *request) error {
+	if defined, ok := s.resolve(tp.Name()).(spec.DefineStruct); ok {
+		tp = defined
+	}
+
+	for _, member := range tp.Members {
+		if member.IsInline {
+			inline, ok := s.resolve(member.Type.Name()).(spec.DefineStruct)
+			if !ok {
+				continue
+			}
+
+			if err := s.validate(inline, req); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tags, err := spec.Parse(member.Tag)
+		if err != nil {
+			return err
+		}
+
+		rule, err := member.GetValidationRule()
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags.Tags() {
+			if err = s.validateTag(member, tag, rule, req); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s typeSet) validateTag(member spec.Member, tag *spec.Tag, rule *spec.ValidationRule, req *request) error {
+	var value string
+	var ok bool
+	switch tag.Key {
+	case pathTagKey:
+		value, ok = req.path[tag.Name]
+	case formTagKey:
+		var values []string
+		values, ok = req.form[tag.Name]
+		if ok && len(values) > 0 {
+			value = values[0]
+		}
+	case headerTagKey:
+		value = req.header.Get(tag.Name)
+		ok = len(value) > 0
+		rule = &spec.ValidationRule{Optional: isOptional(tag), Kind: rule.Kind}
+	case bodyTagKey:
+		var body interface{}
+		body, ok = req.body[tag.Name]
+		if !ok {
+			return checkAbsent(tag, rule)
+		}
+
+		return s.validateValue(member.Type, tag.Name, body, rule)
+	default:
+		return nil
+	}
+
+	if !ok {
+		return checkAbsent(tag, rule)
+	}
+
+	if _, isArray := member.Type.(spec.ArrayType); isArray {
+		var items []interface{}
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return fmt.Errorf("field %q expects a json array, but got %q", tag.Name, value)
+		}
+
+		return nil
+	}
+
+	return checkPrimitive(tag.Name, value, rule)
+}
+
+func (s typeSet) validateValue(tp spec.Type, name string, value interface{}, rule *spec.ValidationRule) error {
+	switch v := tp.(type) {
+	case spec.PrimitiveType:
+		return checkJsonPrimitive(name, v.RawName, value, rule)
+	case spec.EnumType:
+		if resolved, ok := s.resolve(v.Name()).(spec.EnumType); ok {
+			v = resolved
+		}
+		if err := checkJsonPrimitive(name, v.Value.RawName, value, rule); err != nil {
+			return err
+		}
+
+		var literals []string
+		for _, member := range v.Members {
+			literal := member.Value
+			if unquoted, err := strconv.Unquote(literal); err == nil {
+				literal = unquoted
+			}
+			if literal == fmt.Sprint(value) {
+				return nil
+			}
+			literals = append(literals, literal)
+		}
+		if len(literals) == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("value %v for field %q is not defined in enum %s [%s]", value, name,
+			v.Name(), strings.Join(literals, " "))
+	case spec.AliasType:
+		if under := s.alias(v); under != nil {
+			return s.validateValue(under, name, value, rule)
+		}
+		return nil
+	case spec.PointerType:
+		if value == nil {
+			return nil
+		}
+
+		return s.validateValue(v.Type, name, value, rule)
+	case spec.DefineStruct:
+		body, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %q expects an object, but got %v", name, value)
+		}
+
+		return s.validate(v, &request{body: body})
+	case spec.ArrayType:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("field %q expects an array, but got %v", name, value)
+		}
+
+		for i, item := range items {
+			if err := s.validateValue(v.Value, fmt.Sprintf("%s[%d]", name, i), item, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	case spec.MapType:
+		items, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %q expects an object, but got %v", name, value)
+		}
+
+		for key, item := range items {
+			if err := s.validateValue(v.Value, name+"."+key, item, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	case spec.InterfaceType:
+		return nil
+	default:
+		if resolved := s.resolve(tp.Name()); resolved != nil {
+			return s.validateValue(resolved, name, value, rule)
+		}
+		return nil
+	}
+}
+
+func checkAbsent(tag *spec.Tag, rule *spec.ValidationRule) error {
+	if tag.Key != pathTagKey && (rule.Optional || rule.Default != nil) {
+		return nil
+	}
+
+	return fmt.Errorf("field %q is not set", tag.Name)
+}
+
+func checkJsonPrimitive(name, kind string, value interface{}, rule *spec.ValidationRule) error {
+	var valid bool
+	switch {
+	case kind == "string":
+		_, valid = value.(string)
+	case kind == "bool":
+		_, valid = value.(bool)
+	case isNumber(kind):
+		_, valid = value.(json.Number)
+	default:
+		valid = true
+	}
+	if !valid {
+		return fmt.Errorf("field %q expects %s, but got %v", name, kind, value)
+	}
+
+	return checkPrimitive(name, fmt.Sprint(value), rule)
+}
+
+// checkPrimitive checks the text value of a primitive member against its kind, options and range
+func checkPrimitive(name, value string, rule *spec.ValidationRule) error {
+	if rule == nil {
+		return nil
+	}
+
+	var err error
+	switch {
+	case rule.Kind == "bool":
+		_, err = strconv.ParseBool(value)
+	case strings.HasPrefix(rule.Kind, "float"):
+		_, err = strconv.ParseFloat(value, 64)
+	case strings.HasPrefix(rule.Kind, "uint") || rule.Kind == "byte":
+		_, err = strconv.ParseUint(value, 10, 64)
+	case isNumber(rule.Kind):
+		_, err = strconv.ParseInt(value, 10, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("field %q expects %s, but got %q", name, rule.Kind, value)
+	}
+
+	if len(rule.Options) > 0 {
+		var matched bool
+		for _, option := range rule.Options {
+			if option == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("value %q for field %q is not defined in options [%s]", value, name,
+				strings.Join(rule.Options, " "))
+		}
+	}
+
+	if rule.Range != nil && !rule.Range.Contains(value) {
+		return fmt.Errorf("value %s for field %q is out of range %s", value, name, rule.Range)
+	}
+
+	return nil
+}
+
+func isOptional(tag *spec.Tag) bool {
+	for _, option := range tag.Options {
+		if option == optionalOption || strings.HasPrefix(option, optionalOption+"=") {
+			return true
+		}
+	}
+
+	return false
+}
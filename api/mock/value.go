@@ -0,0 +1,281 @@
+package mock
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weitrue/goctl/api/spec"
+)
+
+const (
+	// maxDepth stops the recursive structures, such as a tree node referring to its children
+	maxDepth     = 5
+	maxArraySize = 3
+)
+
+// faker synthesizes the values of types, the values are derived from the member names,
+// types and the options, range and default options of tags
+type faker struct {
+	types typeSet
+	rand  *rand.Rand
+}
+
+func newFaker(types typeSet, seed int64) *faker {
+	return &faker{
+		types: types,
+		rand:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// value returns the synthetic value of tp, name is the member name used to guess a realistic value
+func (f *faker) value(tp spec.Type, name string, rule *spec.ValidationRule, depth int) interface{} {
+	if depth > maxDepth {
+		return nil
+	}
+
+	switch v := tp.(type) {
+	case spec.PrimitiveType:
+		return f.primitive(v.RawName, name, rule)
+	case spec.DefineStruct:
+		defined, ok := f.types.resolve(v.Name()).(spec.DefineStruct)
+		if !ok {
+			defined = v
+		}
+
+		return f.structure(defined, depth+1)
+	case spec.AliasType:
+		if value := f.types.alias(v); value != nil {
+			return f.value(value, name, rule, depth)
+		}
+		return nil
+	case spec.EnumType:
+		return f.enum(v, name, rule)
+	case spec.PointerType:
+		return f.value(v.Type, name, rule, depth)
+	case spec.ArrayType:
+		var items []interface{}
+		size := 1 + f.rand.Intn(maxArraySize)
+		for i := 0; i < size; i++ {
+			items = append(items, f.value(v.Value, singular(name), nil, depth+1))
+		}
+		return items
+	case spec.MapType:
+		return map[string]interface{}{
+			fmt.Sprint(f.primitive(v.Key, "key", nil)): f.value(v.Value, name, nil, depth+1),
+		}
+	case spec.InterfaceType:
+		return f.primitive("string", name, nil)
+	default:
+		if resolved := f.types.resolve(tp.Name()); resolved != nil {
+			return f.value(resolved, name, rule, depth)
+		}
+
+		return nil
+	}
+}
+
+func (f *faker) structure(tp spec.DefineStruct, depth int) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, member := range tp.Members {
+		if member.IsInline {
+			if inline, ok := f.value(member.Type, member.Name, nil, depth).(map[string]interface{}); ok {
+				for k, v := range inline {
+					result[k] = v
+				}
+			}
+			continue
+		}
+
+		if !member.IsBodyMember() {
+			continue
+		}
+
+		key, err := member.GetPropertyName()
+		if err != nil || key == "-" {
+			continue
+		}
+
+		rule, err := member.GetValidationRule()
+		if err != nil {
+			rule = nil
+		}
+		result[key] = f.value(member.Type, member.Name, rule, depth)
+	}
+
+	return result
+}
+
+func (f *faker) enum(tp spec.EnumType, name string, rule *spec.ValidationRule) interface{} {
+	if resolved, ok := f.types.resolve(tp.Name()).(spec.EnumType); ok {
+		tp = resolved
+	}
+	if len(tp.Members) == 0 {
+		return f.primitive(tp.Value.RawName, name, rule)
+	}
+
+	literal := tp.Members[f.rand.Intn(len(tp.Members))].Value
+	if value, err := strconv.Unquote(literal); err == nil {
+		return value
+	}
+
+	return parseLiteral(tp.Value.RawName, literal)
+}
+
+func (f *faker) primitive(kind, name string, rule *spec.ValidationRule) interface{} {
+	if rule != nil {
+		if len(rule.Options) > 0 {
+			return parseLiteral(kind, rule.Options[f.rand.Intn(len(rule.Options))])
+		}
+		if rule.Default != nil {
+			return parseLiteral(kind, *rule.Default)
+		}
+	}
+
+	switch {
+	case kind == "bool":
+		return f.rand.Intn(2) == 1
+	case kind == "string":
+		return f.text(strings.ToLower(name))
+	case strings.HasPrefix(kind, "float"):
+		low, high := f.numberRange(strings.ToLower(name), rule)
+		return math.Round((low+f.rand.Float64()*(high-low))*100) / 100
+	case isNumber(kind):
+		low, high := f.numberRange(strings.ToLower(name), rule)
+		low, high = math.Ceil(low), math.Floor(high)
+		if high < low {
+			return int64(low)
+		}
+		return int64(low) + f.rand.Int63n(int64(high-low)+1)
+	default:
+		return nil
+	}
+}
+
+// numberRange guesses the range of a number by the member name, the range option narrows it
+func (f *faker) numberRange(name string, rule *spec.ValidationRule) (float64, float64) {
+	low, high := 1.0, 1000.0
+	switch {
+	case strings.HasSuffix(name, "id"):
+		low, high = 1, 100000
+	case name == "age":
+		low, high = 18, 60
+	case strings.Contains(name, "time") || strings.HasSuffix(name, "at") || strings.Contains(name, "date"):
+		now := float64(time.Now().Unix())
+		low, high = now-30*24*3600, now
+	case strings.Contains(name, "price") || strings.Contains(name, "amount") ||
+		strings.Contains(name, "balance"):
+		low, high = 1, 10000
+	case strings.Contains(name, "count") || strings.Contains(name, "total") || strings.Contains(name, "num") ||
+		strings.Contains(name, "size"):
+		low, high = 0, 100
+	case strings.Contains(name, "page"):
+		low, high = 1, 10
+	case strings.Contains(name, "status") || strings.Contains(name, "type") || strings.Contains(name, "state"):
+		low, high = 0, 3
+	case strings.Contains(name, "code"):
+		low, high = 0, 0
+	}
+
+	if rule == nil || rule.Range == nil {
+		return low, high
+	}
+
+	r := rule.Range
+	if len(r.Left) > 0 {
+		low, _ = strconv.ParseFloat(r.Left, 64)
+		if !r.LeftInclude {
+			low++
+		}
+	}
+	if len(r.Right) > 0 {
+		high, _ = strconv.ParseFloat(r.Right, 64)
+		if !r.RightInclude {
+			high--
+		}
+	}
+	if len(r.Right) == 0 && high < low {
+		high = low + 1000
+	}
+	if len(r.Left) == 0 && low > high {
+		low = high - 1000
+	}
+
+	return low, high
+}
+
+func (f *faker) text(name string) string {
+	n := f.rand.Intn(1000)
+	switch {
+	case strings.Contains(name, "email") || strings.Contains(name, "mail"):
+		return fmt.Sprintf("user%d@example.com", n)
+	case strings.Contains(name, "phone") || strings.Contains(name, "mobile") || strings.Contains(name, "tel"):
+		return fmt.Sprintf("1380000%04d", n)
+	case strings.Contains(name, "url") || strings.Contains(name, "link") || strings.Contains(name, "website"):
+		return fmt.Sprintf("https://example.com/%d", n)
+	case strings.Contains(name, "avatar") || strings.Contains(name, "image") || strings.Contains(name, "img") ||
+		strings.Contains(name, "icon") || strings.Contains(name, "photo") || strings.Contains(name, "cover"):
+		return fmt.Sprintf("https://example.com/images/%d.png", n)
+	case strings.Contains(name, "uuid") || strings.Contains(name, "guid"):
+		return fmt.Sprintf("%08x-%04x-4%03x-a%03x-%012x", f.rand.Uint32(), f.rand.Intn(0x10000),
+			f.rand.Intn(0x1000), f.rand.Intn(0x1000), f.rand.Int63n(1<<48))
+	case strings.Contains(name, "token"):
+		return fmt.Sprintf("%016x%016x", f.rand.Uint64(), f.rand.Uint64())
+	case strings.HasSuffix(name, "ip"):
+		return fmt.Sprintf("192.168.%d.%d", f.rand.Intn(256), 1+f.rand.Intn(254))
+	case strings.Contains(name, "time") || strings.Contains(name, "date") || strings.HasSuffix(name, "at"):
+		return time.Now().Add(-time.Duration(f.rand.Intn(30*24)) * time.Hour).Format(time.RFC3339)
+	case strings.HasSuffix(name, "id"):
+		return strconv.Itoa(1 + f.rand.Intn(100000))
+	case strings.Contains(name, "name") || strings.Contains(name, "nick") || strings.Contains(name, "user"):
+		names := []string{"kevin", "anqi", "bob", "alice", "jack", "lily"}
+		return names[f.rand.Intn(len(names))]
+	case strings.Contains(name, "address") || strings.Contains(name, "city"):
+		return fmt.Sprintf("No.%d Main Street", n)
+	case strings.Contains(name, "title"):
+		return fmt.Sprintf("title %d", n)
+	case strings.Contains(name, "desc") || strings.Contains(name, "content") || strings.Contains(name, "remark") ||
+		strings.Contains(name, "comment") || strings.Contains(name, "msg") || strings.Contains(name, "message"):
+		return "lorem ipsum dolor sit amet"
+	default:
+		return fmt.Sprintf("%s%d", name, n)
+	}
+}
+
+func parseLiteral(kind, literal string) interface{} {
+	switch {
+	case kind == "bool":
+		v, _ := strconv.ParseBool(literal)
+		return v
+	case strings.HasPrefix(kind, "float"):
+		v, _ := strconv.ParseFloat(literal, 64)
+		return v
+	case isNumber(kind):
+		v, _ := strconv.ParseInt(literal, 10, 64)
+		return v
+	default:
+		return literal
+	}
+}
+
+func isNumber(kind string) bool {
+	switch kind {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "byte", "rune", "uintptr":
+		return true
+	}
+
+	return false
+}
+
+// singular returns the element name of a slice member, such as Users to User
+func singular(name string) string {
+	if strings.HasSuffix(name, "s") && len(name) > 1 {
+		return name[:len(name)-1]
+	}
+
+	return name
+}
@@ -0,0 +1,323 @@
+package openapigen
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/weitrue/goctl/api/spec"
+	"github.com/zeromicro/go-zero/core/stringx"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultVersion  = "1.0.0"
+	jsonContentType = "application/json"
+	pathTagKey      = "path"
+	formTagKey      = "form"
+	headerTagKey    = "header"
+	bodyTagKey      = "json"
+	optionalOption  = "optional"
+	optionSeparator = "|"
+)
+
+type builder struct {
+	types     map[string]spec.Type
+	doc       *Document
+	pathIndex map[string]int
+	tagM      map[string]bool
+}
+
+func buildDocument(api *spec.ApiSpec) (*Document, error) {
+	b := &builder{
+		types:     make(map[string]spec.Type),
+		pathIndex: make(map[string]int),
+		tagM:      make(map[string]bool),
+		doc: &Document{
+			OpenApi: openapiVersion,
+			Info:    buildInfo(api),
+			Paths:   yaml.MapSlice{},
+		},
+	}
+	for _, tp := range api.Types {
+		b.types[tp.Name()] = tp
+	}
+
+	for _, tp := range api.Types {
+		schema, err := b.typeSchema(tp)
+		if err != nil {
+			return nil, err
+		}
+
+		b.doc.Components.Schemas = append(b.doc.Components.Schemas, yaml.MapItem{Key: tp.Name(), Value: schema})
+	}
+
+	services := api.Services
+	if len(services) == 0 {
+		services = []spec.Service{api.Service}
+	}
+	for _, service := range services {
+		for _, group := range service.Groups {
+			for _, route := range group.Routes {
+				if err := b.addRoute(service, group, route); err != nil {
+					return nil, fmt.Errorf("route %s %s: %w", route.Method, route.Path, err)
+				}
+			}
+		}
+	}
+
+	return b.doc, nil
+}
+
+func buildInfo(api *spec.ApiSpec) Info {
+	property := func(key string) string {
+		if api.Info.Properties == nil {
+			return ""
+		}
+
+		return unquote(api.Info.Properties[key])
+	}
+
+	info := Info{
+		Title:       property("title"),
+		Description: property("desc"),
+		Version:     property("version"),
+	}
+	if len(info.Title) == 0 {
+		info.Title = api.Service.Name
+	}
+	if len(info.Version) == 0 {
+		info.Version = defaultVersion
+	}
+
+	author, email := property("author"), property("email")
+	if len(author) > 0 || len(email) > 0 {
+		info.Contact = &Contact{
+			Name:  author,
+			Email: email,
+		}
+	}
+
+	return info
+}
+
+func (b *builder) addRoute(service spec.Service, group spec.Group, route spec.Route) error {
+	tag := group.GetAnnotation("group")
+	if len(tag) == 0 {
+		tag = service.Name
+	}
+	if !b.tagM[tag] {
+		b.tagM[tag] = true
+		b.doc.Tags = append(b.doc.Tags, Tag{Name: tag})
+	}
+
+	summary := unquote(route.AtDoc.Text)
+	var description string
+	if route.AtDoc.Properties != nil {
+		if v := unquote(route.AtDoc.Properties["summary"]); len(v) > 0 {
+			summary = v
+		}
+		description = unquote(route.AtDoc.Properties["description"])
+	}
+
+	op := &Operation{
+		Tags:        []string{tag},
+		Summary:     summary,
+		Description: description,
+		OperationId: route.Handler,
+		Responses:   yaml.MapSlice{},
+	}
+
+	if route.RequestType != nil {
+		if err := b.fillRequest(op, route.RequestType); err != nil {
+			return err
+		}
+	}
+
+	path, params := convertPath(group.JoinPrefix(route.Path))
+	for _, name := range params {
+		if !hasParameter(op.Parameters, name, pathTagKey) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     name,
+				In:       pathTagKey,
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	response := Response{Description: http.StatusText(http.StatusOK)}
+	if route.ResponseType != nil {
+		schema, err := b.schemaOf(route.ResponseType)
+		if err != nil {
+			return err
+		}
+
+		response.Content = map[string]MediaType{jsonContentType: {Schema: schema}}
+	}
+	op.Responses = append(op.Responses, yaml.MapItem{Key: "200", Value: response})
+
+	if jwt := group.GetAnnotation("jwt"); len(jwt) > 0 {
+		if b.doc.Components.SecuritySchemes == nil {
+			b.doc.Components.SecuritySchemes = make(map[string]SecurityScheme)
+		}
+		b.doc.Components.SecuritySchemes[jwt] = SecurityScheme{
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+		}
+		op.Security = []map[string][]string{{jwt: {}}}
+	}
+
+	return b.addOperation(path, route.Method, op)
+}
+
+func (b *builder) fillRequest(op *Operation, tp spec.Type) error {
+	ds, ok := b.resolveStruct(tp)
+	if !ok {
+		schema, err := b.schemaOf(tp)
+		if err != nil {
+			return err
+		}
+
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: schema}},
+		}
+		return nil
+	}
+
+	var hasBody bool
+	err := b.walkMembers(ds, func(member spec.Member, tag *spec.Tag) error {
+		switch tag.Key {
+		case bodyTagKey:
+			hasBody = true
+			return nil
+		case pathTagKey, formTagKey, headerTagKey:
+		default:
+			return nil
+		}
+
+		schema, err := b.memberSchema(member)
+		if err != nil {
+			return err
+		}
+
+		in := tag.Key
+		if in == formTagKey {
+			in = "query"
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        tag.Name,
+			In:          in,
+			Description: trimComment(member.Comment),
+			Required:    in == pathTagKey || !isOptional(tag),
+			Schema:      schema,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if hasBody {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: refSchema(tp.Name())}},
+		}
+	}
+
+	return nil
+}
+
+func (b *builder) addOperation(path, method string, op *Operation) error {
+	index, ok := b.pathIndex[path]
+	if !ok {
+		index = len(b.doc.Paths)
+		b.pathIndex[path] = index
+		b.doc.Paths = append(b.doc.Paths, yaml.MapItem{Key: path, Value: &PathItem{}})
+	}
+
+	item := b.doc.Paths[index].Value.(*PathItem)
+	var target **Operation
+	switch strings.ToLower(method) {
+	case "get":
+		target = &item.Get
+	case "put":
+		target = &item.Put
+	case "post":
+		target = &item.Post
+	case "delete":
+		target = &item.Delete
+	case "options":
+		target = &item.Options
+	case "head":
+		target = &item.Head
+	case "patch":
+		target = &item.Patch
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+
+	if *target != nil {
+		return fmt.Errorf("duplicate operation %s %s", method, path)
+	}
+
+	*target = op
+	return nil
+}
+
+// convertPath converts the go-zero path /users/:id into /users/{id}
+func convertPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for index, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+			segments[index] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+func hasParameter(params []Parameter, name, in string) bool {
+	for _, each := range params {
+		if each.Name == name && each.In == in {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isOptional(tag *spec.Tag) bool {
+	for _, option := range tag.Options {
+		if option == optionalOption || strings.HasPrefix(option, optionalOption+"=") {
+			return true
+		}
+	}
+
+	return stringx.Contains(tag.Options, "omitempty")
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '`' && s[len(s)-1] == '`') {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+func trimComment(comment string) string {
+	comment = strings.TrimSpace(comment)
+	switch {
+	case strings.HasPrefix(comment, "//"):
+		comment = strings.TrimPrefix(comment, "//")
+	case strings.HasPrefix(comment, "/*"):
+		comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+	}
+
+	return strings.TrimSpace(comment)
+}
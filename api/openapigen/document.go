@@ -0,0 +1,118 @@
+package openapigen
+
+import "gopkg.in/yaml.v2"
+
+const openapiVersion = "3.0.3"
+
+type (
+	// Document describes the root object of an OpenAPI 3.0 document
+	Document struct {
+		OpenApi    string        `yaml:"openapi"`
+		Info       Info          `yaml:"info"`
+		Tags       []Tag         `yaml:"tags,omitempty"`
+		Paths      yaml.MapSlice `yaml:"paths"`
+		Components Components    `yaml:"components,omitempty"`
+	}
+
+	// Info describes the metadata of api
+	Info struct {
+		Title       string   `yaml:"title"`
+		Description string   `yaml:"description,omitempty"`
+		Version     string   `yaml:"version"`
+		Contact     *Contact `yaml:"contact,omitempty"`
+	}
+
+	// Contact describes the contact information of api
+	Contact struct {
+		Name  string `yaml:"name,omitempty"`
+		Email string `yaml:"email,omitempty"`
+	}
+
+	// Tag describes a group of operations
+	Tag struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description,omitempty"`
+	}
+
+	// PathItem describes the operations available on a single path
+	PathItem struct {
+		Get     *Operation `yaml:"get,omitempty"`
+		Put     *Operation `yaml:"put,omitempty"`
+		Post    *Operation `yaml:"post,omitempty"`
+		Delete  *Operation `yaml:"delete,omitempty"`
+		Options *Operation `yaml:"options,omitempty"`
+		Head    *Operation `yaml:"head,omitempty"`
+		Patch   *Operation `yaml:"patch,omitempty"`
+	}
+
+	// Operation describes a single api operation on a path
+	Operation struct {
+		Tags        []string              `yaml:"tags,omitempty"`
+		Summary     string                `yaml:"summary,omitempty"`
+		Description string                `yaml:"description,omitempty"`
+		OperationId string                `yaml:"operationId"`
+		Parameters  []Parameter           `yaml:"parameters,omitempty"`
+		RequestBody *RequestBody          `yaml:"requestBody,omitempty"`
+		Responses   yaml.MapSlice         `yaml:"responses"`
+		Security    []map[string][]string `yaml:"security,omitempty"`
+	}
+
+	// Parameter describes a path, query or header parameter
+	Parameter struct {
+		Name        string  `yaml:"name"`
+		In          string  `yaml:"in"`
+		Description string  `yaml:"description,omitempty"`
+		Required    bool    `yaml:"required,omitempty"`
+		Schema      *Schema `yaml:"schema"`
+	}
+
+	// RequestBody describes the body of request
+	RequestBody struct {
+		Required bool                 `yaml:"required,omitempty"`
+		Content  map[string]MediaType `yaml:"content"`
+	}
+
+	// Response describes a response of operation
+	Response struct {
+		Description string               `yaml:"description"`
+		Content     map[string]MediaType `yaml:"content,omitempty"`
+	}
+
+	// MediaType describes the schema of content
+	MediaType struct {
+		Schema *Schema `yaml:"schema"`
+	}
+
+	// Components holds the reusable schemas and security schemes
+	Components struct {
+		Schemas         yaml.MapSlice             `yaml:"schemas,omitempty"`
+		SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes,omitempty"`
+	}
+
+	// SecurityScheme describes a security scheme, such as the jwt bearer token
+	SecurityScheme struct {
+		Type         string `yaml:"type"`
+		Scheme       string `yaml:"scheme,omitempty"`
+		BearerFormat string `yaml:"bearerFormat,omitempty"`
+		Description  string `yaml:"description,omitempty"`
+	}
+
+	// Schema describes the data type of OpenAPI
+	Schema struct {
+		Ref                  string        `yaml:"$ref,omitempty"`
+		Type                 string        `yaml:"type,omitempty"`
+		Format               string        `yaml:"format,omitempty"`
+		Description          string        `yaml:"description,omitempty"`
+		Enum                 []interface{} `yaml:"enum,omitempty"`
+		EnumVarNames         []string      `yaml:"x-enum-varnames,omitempty"`
+		Default              interface{}   `yaml:"default,omitempty"`
+		Minimum              *float64      `yaml:"minimum,omitempty"`
+		ExclusiveMinimum     bool          `yaml:"exclusiveMinimum,omitempty"`
+		Maximum              *float64      `yaml:"maximum,omitempty"`
+		ExclusiveMaximum     bool          `yaml:"exclusiveMaximum,omitempty"`
+		Items                *Schema       `yaml:"items,omitempty"`
+		Properties           yaml.MapSlice `yaml:"properties,omitempty"`
+		Required             []string      `yaml:"required,omitempty"`
+		AdditionalProperties *Schema       `yaml:"additionalProperties,omitempty"`
+	}
+)
@@ -0,0 +1,1006 @@
+package openapigen
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/api/format"
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/spec"
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/console"
+	"github.com/zeromicro/go-zero/core/stringx"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultApiOutput     = "service.api"
+	defaultServiceName   = "service"
+	componentsRefPrefix  = "#/components/schemas/"
+	definitionsRefPrefix = "#/definitions/"
+	formDataIn           = "formData"
+	queryIn              = "query"
+	bodyIn               = "body"
+)
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+type (
+	importer struct {
+		root      yaml.MapSlice
+		doc       *sourceDocument
+		schemas   map[string]*sourceSchema
+		names     map[string]string
+		typeNames map[string]bool
+		handlers  map[string]bool
+		types     strings.Builder
+		groups    []*importGroup
+		groupM    map[string]*importGroup
+		warnings  []string
+	}
+
+	importGroup struct {
+		name   string
+		jwt    string
+		routes strings.Builder
+	}
+
+	importField struct {
+		name    string
+		tp      string
+		tag     string
+		comment string
+		inline  bool
+	}
+)
+
+// FromOpenApiCommand converts an OpenAPI 3.0 or Swagger 2.0 document into api file
+func FromOpenApiCommand(c *cli.Context) error {
+	src := c.String("src")
+	output := c.String("o")
+	if len(src) == 0 {
+		return errors.New("missing -src")
+	}
+
+	if len(output) == 0 {
+		output = defaultApiOutput
+	}
+
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	content, warnings, err := importOpenApi(data)
+	if err != nil {
+		fmt.Println(aurora.Red("Failed"))
+		return err
+	}
+
+	cs := console.NewColorConsole()
+	for _, warning := range warnings {
+		cs.Warning(warning)
+	}
+
+	if err := util.MkdirIfNotExist(filepath.Dir(output)); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(output, []byte(content), os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := format.ApiFormatByPath(output); err != nil {
+		return err
+	}
+
+	if _, err := parser.Parse(output); err != nil {
+		fmt.Println(aurora.Red("Failed"))
+		return err
+	}
+
+	fmt.Println(aurora.Green("Done."))
+	return nil
+}
+
+// importOpenApi converts the document into the content of api file, the parts which can not be
+// expressed in api file are skipped with warnings
+func importOpenApi(data []byte) (string, []string, error) {
+	root, doc, err := loadSource(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	im := &importer{
+		root:      root,
+		doc:       doc,
+		schemas:   make(map[string]*sourceSchema),
+		names:     make(map[string]string),
+		typeNames: make(map[string]bool),
+		handlers:  make(map[string]bool),
+		groupM:    make(map[string]*importGroup),
+	}
+
+	schemas := doc.Components.Schemas
+	if len(doc.Swagger) > 0 {
+		schemas = doc.Definitions
+	}
+
+	for _, item := range schemas {
+		key := fmt.Sprint(item.Key)
+		var schema sourceSchema
+		if err := convert(item.Value, &schema); err != nil {
+			return "", nil, fmt.Errorf("schema %s: %w", key, err)
+		}
+
+		im.schemas[key] = &schema
+		im.names[key] = im.uniqueTypeName(goName(key))
+	}
+
+	for _, item := range schemas {
+		key := fmt.Sprint(item.Key)
+		if err := im.declareSchema(im.names[key], im.schemas[key]); err != nil {
+			return "", nil, fmt.Errorf("schema %s: %w", key, err)
+		}
+	}
+
+	for _, item := range doc.Paths {
+		path := fmt.Sprint(item.Key)
+		if err := im.addPath(path, item.Value); err != nil {
+			return "", nil, fmt.Errorf("path %s: %w", path, err)
+		}
+	}
+
+	return im.String(), im.warnings, nil
+}
+
+// String returns the content of api file
+func (im *importer) String() string {
+	var builder strings.Builder
+	builder.WriteString("syntax = \"v1\"\n\n")
+
+	info := im.doc.Info
+	var properties []string
+	for _, item := range [][2]string{
+		{"title", info.Title},
+		{"desc", info.Description},
+		{"author", info.Contact.Name},
+		{"email", info.Contact.Email},
+		{"version", info.Version},
+	} {
+		if len(strings.TrimSpace(item[1])) > 0 {
+			properties = append(properties, fmt.Sprintf("\t%s: %s\n", item[0], quote(item[1])))
+		}
+	}
+	if len(properties) > 0 {
+		builder.WriteString("info(\n" + strings.Join(properties, "") + ")\n\n")
+	}
+
+	builder.WriteString(im.types.String())
+	service := serviceName(info.Title)
+	for _, group := range im.groups {
+		var annotations []string
+		if len(group.name) > 0 {
+			annotations = append(annotations, "\tgroup: "+group.name+"\n")
+		}
+		if len(group.jwt) > 0 {
+			annotations = append(annotations, "\tjwt: "+group.jwt+"\n")
+		}
+		if len(annotations) > 0 {
+			builder.WriteString("@server(\n" + strings.Join(annotations, "") + ")\n")
+		}
+
+		builder.WriteString("service " + service + " {\n")
+		builder.WriteString(group.routes.String())
+		builder.WriteString("}\n\n")
+	}
+
+	return builder.String()
+}
+
+func (im *importer) warn(format string, a ...interface{}) {
+	im.warnings = append(im.warnings, fmt.Sprintf(format, a...))
+}
+
+func (im *importer) declareSchema(name string, schema *sourceSchema) error {
+	if isEnumSchema(schema) {
+		im.declareEnum(name, schema)
+		return nil
+	}
+
+	if isStructSchema(schema) {
+		return im.declareStruct(name, schema, "json")
+	}
+
+	tp, err := im.typeExpr(name+"Value", schema)
+	if err != nil {
+		return err
+	}
+
+	im.writeDocs(&im.types, schema.Description)
+	fmt.Fprintf(&im.types, "type %s %s\n\n", name, tp)
+	return nil
+}
+
+func (im *importer) declareEnum(name string, schema *sourceSchema) {
+	im.writeDocs(&im.types, schema.Description)
+	fmt.Fprintf(&im.types, "type %s enum {\n", name)
+	memberM := make(map[string]bool)
+	for index, value := range schema.Enum {
+		var member string
+		if len(schema.EnumVarNames) == len(schema.Enum) {
+			member = goName(schema.EnumVarNames[index])
+		}
+		if len(member) == 0 {
+			member = goName(fmt.Sprint(value))
+		}
+		literal := fmt.Sprint(value)
+		if len(member) == 0 || !unicode.IsLetter(rune(member[0])) {
+			prefix := "Value"
+			if strings.HasPrefix(literal, "-") {
+				prefix = "ValueNeg"
+			}
+			member = prefix + goName(literal)
+		}
+
+		member = uniqueName(member, memberM)
+		if schema.Type == "string" {
+			literal = strconv.Quote(literal)
+		}
+		fmt.Fprintf(&im.types, "\t%s = %s\n", member, literal)
+	}
+	im.types.WriteString("}\n\n")
+}
+
+func (im *importer) declareStruct(name string, schema *sourceSchema, tagKey string) error {
+	fields, err := im.structFields(name, schema, tagKey)
+	if err != nil {
+		return err
+	}
+
+	im.writeStruct(name, schema.Description, fields)
+	return nil
+}
+
+func (im *importer) writeStruct(name, doc string, fields []importField) {
+	var builder strings.Builder
+	im.writeDocs(&builder, doc)
+	fmt.Fprintf(&builder, "type %s {\n", name)
+	fieldM := make(map[string]bool)
+	for _, field := range fields {
+		if field.inline {
+			fmt.Fprintf(&builder, "\t%s\n", field.tp)
+			continue
+		}
+
+		fmt.Fprintf(&builder, "\t%s %s %s", uniqueName(field.name, fieldM), field.tp, field.tag)
+		if len(field.comment) > 0 {
+			fmt.Fprintf(&builder, " // %s", field.comment)
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString("}\n\n")
+	im.types.WriteString(builder.String())
+}
+
+func (im *importer) structFields(owner string, schema *sourceSchema, tagKey string) ([]importField, error) {
+	var fields []importField
+	for _, part := range schema.AllOf {
+		if len(part.Ref) > 0 {
+			key, target, err := im.refSchema(part.Ref)
+			if err != nil {
+				return nil, err
+			}
+
+			if !isStructSchema(target) {
+				im.warn("%s: allOf item %s is not an object, skipped", owner, part.Ref)
+				continue
+			}
+
+			if tagKey == "json" {
+				fields = append(fields, importField{tp: im.names[key], inline: true})
+				continue
+			}
+
+			part = target
+		}
+
+		sub, err := im.structFields(owner, part, tagKey)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, sub...)
+	}
+
+	for _, item := range schema.Properties {
+		key := fmt.Sprint(item.Key)
+		var property sourceSchema
+		if err := convert(item.Value, &property); err != nil {
+			return nil, fmt.Errorf("property %s: %w", key, err)
+		}
+
+		field, err := im.field(owner, key, &property, stringx.Contains(schema.Required, key), tagKey)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (im *importer) field(owner, key string, schema *sourceSchema, required bool, tagKey string) (importField, error) {
+	name := goName(key)
+	if len(name) == 0 {
+		name = "Field"
+	}
+
+	tp, err := im.typeExpr(owner+name, schema)
+	if err != nil {
+		return importField{}, fmt.Errorf("property %s: %w", key, err)
+	}
+
+	return importField{
+		name:    name,
+		tp:      tp,
+		tag:     im.tag(tagKey, key, schema, required),
+		comment: firstLine(schema.Description),
+	}, nil
+}
+
+// tag builds the member tag, the constraints of basic types are converted into the tag options
+func (im *importer) tag(tagKey, name string, schema *sourceSchema, required bool) string {
+	options := []string{name}
+	if !required {
+		options = append(options, optionalOption)
+	}
+
+	kind := basicKind(schema)
+	if len(kind) > 0 {
+		var values []string
+		for _, item := range schema.Enum {
+			value, ok := optionLiteral(kind, item)
+			if !ok {
+				values = nil
+				break
+			}
+
+			values = append(values, value)
+		}
+		if len(values) > 0 {
+			options = append(options, "options="+strings.Join(values, optionSeparator))
+		}
+
+		if kind != "string" && (schema.Minimum != nil || schema.Maximum != nil) {
+			if r, ok := rangeOption(kind, schema); ok {
+				options = append(options, "range="+r)
+			}
+		}
+
+		if schema.Default != nil {
+			if value, ok := optionLiteral(kind, schema.Default); ok {
+				options = append(options, "default="+value)
+			}
+		}
+	}
+
+	return fmt.Sprintf("`%s:\"%s\"`", tagKey, strings.Join(options, ","))
+}
+
+// typeExpr returns the type expression in api file, the inline objects are declared with the name
+func (im *importer) typeExpr(name string, schema *sourceSchema) (string, error) {
+	if len(schema.Ref) > 0 {
+		key, _, err := im.refSchema(schema.Ref)
+		if err != nil {
+			return "", err
+		}
+
+		return im.names[key], nil
+	}
+
+	if len(schema.AllOf) == 1 {
+		return im.typeExpr(name, schema.AllOf[0])
+	}
+
+	switch schema.Type {
+	case "array":
+		if schema.Items == nil {
+			return "[]interface{}", nil
+		}
+
+		item, err := im.typeExpr(name+"Item", schema.Items)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + item, nil
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32", nil
+		}
+
+		return "int64", nil
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+
+		return "float64", nil
+	case "string", "file":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	}
+
+	if isStructSchema(schema) {
+		name = im.uniqueTypeName(name)
+		if err := im.declareStruct(name, schema, "json"); err != nil {
+			return "", err
+		}
+
+		return name, nil
+	}
+
+	switch v := schema.AdditionalProperties.(type) {
+	case nil:
+	case bool:
+		if v {
+			return "map[string]interface{}", nil
+		}
+	default:
+		var value sourceSchema
+		if err := convert(v, &value); err != nil {
+			return "", err
+		}
+
+		tp, err := im.typeExpr(name+"Value", &value)
+		if err != nil {
+			return "", err
+		}
+
+		return "map[string]" + tp, nil
+	}
+
+	return "interface{}", nil
+}
+
+func (im *importer) refSchema(ref string) (string, *sourceSchema, error) {
+	key := strings.TrimPrefix(strings.TrimPrefix(ref, componentsRefPrefix), definitionsRefPrefix)
+	schema, ok := im.schemas[key]
+	if !ok || key == ref {
+		return "", nil, fmt.Errorf("schema %q not found", ref)
+	}
+
+	return key, schema, nil
+}
+
+func (im *importer) addPath(path string, value interface{}) error {
+	node, ok := value.(yaml.MapSlice)
+	if !ok {
+		return nil
+	}
+
+	var common []interface{}
+	for _, item := range node {
+		if fmt.Sprint(item.Key) == "parameters" {
+			if err := convert(item.Value, &common); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, item := range node {
+		method := strings.ToLower(fmt.Sprint(item.Key))
+		if !stringx.Contains(httpMethods, method) {
+			continue
+		}
+
+		var op sourceOperation
+		if err := convert(item.Value, &op); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+
+		if err := im.addOperation(path, method, common, &op); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+	}
+
+	return nil
+}
+
+func (im *importer) addOperation(path, method string, common []interface{}, op *sourceOperation) error {
+	apiPath, pathParams, ok := convertApiPath(path)
+	if !ok {
+		im.warn("%s %s: path can not be expressed in api file, skipped", strings.ToUpper(method), path)
+		return nil
+	}
+
+	handler := im.handlerName(method, path, op.OperationId)
+	request, err := im.request(handler, pathParams, append(common, op.Parameters...), op.RequestBody)
+	if err != nil {
+		return err
+	}
+
+	response, err := im.response(handler, op.Responses)
+	if err != nil {
+		return err
+	}
+
+	var group string
+	if len(op.Tags) > 0 {
+		group = util.Untitle(goName(op.Tags[0]))
+	}
+	jwt := im.jwt(op.Security)
+	g, ok := im.groupM[group+"/"+jwt]
+	if !ok {
+		g = &importGroup{name: group, jwt: jwt}
+		im.groupM[group+"/"+jwt] = g
+		im.groups = append(im.groups, g)
+	}
+
+	summary := firstLine(op.Summary)
+	if len(summary) == 0 {
+		summary = firstLine(op.Description)
+	}
+	if g.routes.Len() > 0 {
+		g.routes.WriteString("\n")
+	}
+	if len(summary) > 0 {
+		fmt.Fprintf(&g.routes, "\t@doc(\n\t\tsummary: %s\n\t)\n", quote(summary))
+	}
+
+	fmt.Fprintf(&g.routes, "\t@handler %s\n\t%s %s", handler, method, apiPath)
+	if len(request) > 0 {
+		fmt.Fprintf(&g.routes, " (%s)", request)
+	}
+	if len(response) > 0 {
+		fmt.Fprintf(&g.routes, " returns (%s)", response)
+	}
+	g.routes.WriteString("\n")
+	return nil
+}
+
+func (im *importer) request(handler string, pathParams map[string]string, rawParams []interface{},
+	rawBody interface{}) (string, error) {
+	name := util.Title(handler) + "Req"
+	var params []sourceParameter
+	indexM := make(map[string]int)
+	for _, raw := range rawParams {
+		var param sourceParameter
+		if err := resolve(im.root, raw, &param); err != nil {
+			return "", err
+		}
+
+		if param.Schema == nil && param.In != bodyIn {
+			param.Schema = &sourceSchema{
+				Type:             param.Type,
+				Format:           param.Format,
+				Items:            param.Items,
+				Enum:             param.Enum,
+				Default:          param.Default,
+				Minimum:          param.Minimum,
+				Maximum:          param.Maximum,
+				ExclusiveMinimum: param.ExclusiveMinimum,
+				ExclusiveMaximum: param.ExclusiveMaximum,
+			}
+		}
+
+		// the parameters of operation override the ones of path
+		key := param.In + "/" + param.Name
+		if index, ok := indexM[key]; ok {
+			params[index] = param
+			continue
+		}
+
+		indexM[key] = len(params)
+		params = append(params, param)
+	}
+
+	var fields []importField
+	var body *sourceSchema
+	bodyTag := "json"
+	for _, param := range params {
+		var tagKey string
+		switch param.In {
+		case pathTagKey:
+			tagKey = pathTagKey
+			param.Required = true
+			if v, ok := pathParams[param.Name]; ok {
+				param.Name = v
+			}
+		case queryIn, formDataIn:
+			tagKey = formTagKey
+		case headerTagKey:
+			tagKey = headerTagKey
+		case bodyIn:
+			body = param.Schema
+			continue
+		default:
+			im.warn("%s: parameter %s in %s is not supported, skipped", handler, param.Name, param.In)
+			continue
+		}
+
+		field, err := im.field(name, param.Name, param.Schema, param.Required, tagKey)
+		if err != nil {
+			return "", fmt.Errorf("parameter %s: %w", param.Name, err)
+		}
+
+		if len(param.Description) > 0 {
+			field.comment = firstLine(param.Description)
+		}
+		fields = append(fields, field)
+	}
+
+	if rawBody != nil {
+		var requestBody sourceRequestBody
+		if err := resolve(im.root, rawBody, &requestBody); err != nil {
+			return "", err
+		}
+
+		var media sourceMediaType
+		media, bodyTag = pickMediaType(requestBody.Content)
+		body = media.Schema
+	}
+
+	if body != nil {
+		if len(body.Ref) > 0 {
+			key, target, err := im.refSchema(body.Ref)
+			if err != nil {
+				return "", err
+			}
+
+			switch {
+			case !isStructSchema(target):
+				im.warn("%s: request body %s is not an object, skipped", handler, body.Ref)
+			case len(fields) == 0 && bodyTag == "json":
+				return im.names[key], nil
+			case bodyTag == "json":
+				fields = append(fields, importField{tp: im.names[key], inline: true})
+			default:
+				sub, err := im.structFields(name, target, bodyTag)
+				if err != nil {
+					return "", err
+				}
+
+				fields = append(fields, sub...)
+			}
+		} else if isStructSchema(body) {
+			sub, err := im.structFields(name, body, bodyTag)
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, sub...)
+		} else {
+			im.warn("%s: request body is not an object, skipped", handler)
+		}
+	}
+
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	name = im.uniqueTypeName(name)
+	im.writeStruct(name, "", fields)
+	return name, nil
+}
+
+func (im *importer) response(handler string, responses yaml.MapSlice) (string, error) {
+	for _, item := range responses {
+		if !strings.HasPrefix(fmt.Sprint(item.Key), "2") {
+			continue
+		}
+
+		var resp sourceResponse
+		if err := resolve(im.root, item.Value, &resp); err != nil {
+			return "", err
+		}
+
+		schema := resp.Schema
+		if schema == nil {
+			media, _ := pickMediaType(resp.Content)
+			schema = media.Schema
+		}
+		if schema == nil {
+			return "", nil
+		}
+
+		tp, err := im.typeExpr(util.Title(handler)+"Resp", schema)
+		if err != nil {
+			return "", err
+		}
+
+		if !im.typeNames[strings.TrimLeft(tp, "[]*")] {
+			im.warn("%s: response type %s can not be expressed in api file, skipped", handler, tp)
+			return "", nil
+		}
+
+		return tp, nil
+	}
+
+	return "", nil
+}
+
+// jwt returns the name of the http bearer security scheme which the operation requires
+func (im *importer) jwt(security *[]map[string][]string) string {
+	requirements := im.doc.Security
+	if security != nil {
+		requirements = *security
+	}
+
+	for _, requirement := range requirements {
+		var names []string
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			scheme := im.doc.Components.SecuritySchemes[name]
+			if strings.EqualFold(scheme.Type, "http") && strings.EqualFold(scheme.Scheme, "bearer") {
+				return goName(name)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (im *importer) handlerName(method, path, operationId string) string {
+	name := util.Untitle(goName(operationId))
+	if len(name) == 0 || !unicode.IsLetter(rune(name[0])) {
+		name = method + goName(path)
+	}
+
+	return uniqueName(name, im.handlers)
+}
+
+func (im *importer) uniqueTypeName(name string) string {
+	if len(name) == 0 || !unicode.IsLetter(rune(name[0])) {
+		name = "Type" + name
+	}
+
+	return uniqueName(name, im.typeNames)
+}
+
+func (im *importer) writeDocs(builder *strings.Builder, doc string) {
+	for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			fmt.Fprintf(builder, "// %s\n", line)
+		}
+	}
+}
+
+// convertApiPath converts the OpenAPI path /users/{id} into /users/:id, the names of
+// path parameters are converted into the valid ones of api file
+func convertApiPath(path string) (string, map[string]string, bool) {
+	params := make(map[string]string)
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.Map(func(r rune) rune {
+				if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+					return r
+				}
+				return '_'
+			}, segment[1:len(segment)-1])
+			if len(name) == 0 || unicode.IsDigit(rune(name[0])) {
+				return "", nil, false
+			}
+
+			params[segment[1:len(segment)-1]] = name
+			segments = append(segments, ":"+name)
+			continue
+		}
+
+		parts := strings.FieldsFunc(segment, func(r rune) bool {
+			return !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+		})
+		for _, part := range parts {
+			if unicode.IsDigit(rune(part[0])) {
+				return "", nil, false
+			}
+		}
+		if len(parts) == 0 || strings.Join(parts, "-") != segment {
+			return "", nil, false
+		}
+
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return "", nil, false
+	}
+
+	return "/" + strings.Join(segments, "/"), params, true
+}
+
+// pickMediaType returns the json media type first, the form media types are parsed by form tag
+func pickMediaType(content map[string]sourceMediaType) (sourceMediaType, string) {
+	var keys []string
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if strings.Contains(key, "json") {
+			return content[key], "json"
+		}
+	}
+
+	for _, key := range keys {
+		if strings.Contains(key, "x-www-form-urlencoded") || strings.Contains(key, "multipart/form-data") {
+			return content[key], formTagKey
+		}
+	}
+
+	if len(keys) > 0 {
+		return content[keys[0]], "json"
+	}
+
+	return sourceMediaType{}, "json"
+}
+
+func isEnumSchema(schema *sourceSchema) bool {
+	if len(schema.Enum) == 0 || len(schema.Ref) > 0 {
+		return false
+	}
+
+	for _, item := range schema.Enum {
+		switch item.(type) {
+		case string:
+			if schema.Type != "string" {
+				return false
+			}
+		case int, int64, uint64:
+			if schema.Type != "integer" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isStructSchema(schema *sourceSchema) bool {
+	if len(schema.Ref) > 0 {
+		return false
+	}
+
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 1 ||
+		len(schema.AllOf) == 1 && len(schema.AllOf[0].Ref) == 0
+}
+
+// basicKind returns the kind of basic type in place, which the tag options can be applied on
+func basicKind(schema *sourceSchema) string {
+	if len(schema.Ref) > 0 {
+		return ""
+	}
+
+	switch schema.Type {
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	}
+
+	return ""
+}
+
+// optionLiteral formats the value as tag option, the values which break the tag syntax are rejected
+func optionLiteral(kind string, value interface{}) (string, bool) {
+	literal := fmt.Sprint(value)
+	if len(literal) == 0 || strings.ContainsAny(literal, ",|=\"` ") {
+		return "", false
+	}
+
+	var err error
+	switch kind {
+	case "int64":
+		_, err = strconv.ParseInt(literal, 10, 64)
+	case "float64":
+		_, err = strconv.ParseFloat(literal, 64)
+	case "bool":
+		_, err = strconv.ParseBool(literal)
+	}
+
+	return literal, err == nil
+}
+
+func rangeOption(kind string, schema *sourceSchema) (string, bool) {
+	bound := func(v *float64) (string, bool) {
+		if v == nil {
+			return "", true
+		}
+
+		if kind == "int64" && *v != float64(int64(*v)) {
+			return "", false
+		}
+
+		return strconv.FormatFloat(*v, 'f', -1, 64), true
+	}
+
+	left, ok := bound(schema.Minimum)
+	if !ok {
+		return "", false
+	}
+
+	right, ok := bound(schema.Maximum)
+	if !ok {
+		return "", false
+	}
+
+	return spec.NumberRange{
+		Left:         left,
+		LeftInclude:  !schema.ExclusiveMinimum,
+		Right:        right,
+		RightInclude: !schema.ExclusiveMaximum,
+	}.String(), true
+}
+
+// goName converts the name into the exported name of golang, such as user_id to UserId
+func goName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteString(util.Title(part))
+	}
+
+	return builder.String()
+}
+
+func uniqueName(name string, names map[string]bool) string {
+	target := name
+	for index := 1; names[target]; index++ {
+		target = name + strconv.Itoa(index)
+	}
+
+	names[target] = true
+	return target
+}
+
+func serviceName(title string) string {
+	parts := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	if len(parts) == 0 || unicode.IsDigit(rune(parts[0][0])) {
+		return defaultServiceName
+	}
+
+	return strings.Join(parts, "-")
+}
+
+func firstLine(s string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(s), "\n", 2)[0])
+}
+
+// quote formats the value of key-value pair in api file
+func quote(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "\n", " ")
+	return strconv.Quote(strings.ReplaceAll(s, `"`, "'"))
+}
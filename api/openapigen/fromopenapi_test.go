@@ -0,0 +1,177 @@
+package openapigen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/spec"
+	"gopkg.in/yaml.v2"
+)
+
+const testOpenApi = `
+openapi: 3.0.0
+info:
+  title: Pet Store
+  version: 1.0.0
+security:
+  - bearerAuth: []
+paths:
+  /pets:
+    get:
+      tags: [pet]
+      summary: List all pets
+      operationId: listPets
+      security: []
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+        - $ref: '#/components/parameters/TraceId'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    put:
+      tags: [pet]
+      operationId: updatePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: session
+          in: cookie
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: no content
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+  parameters:
+    TraceId:
+      name: X-Trace-Id
+      in: header
+      required: true
+      schema:
+        type: string
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: the pet name
+        status:
+          $ref: '#/components/schemas/Status'
+        kind:
+          type: string
+          enum: [cat, dog]
+        attrs:
+          type: object
+          additionalProperties:
+            type: integer
+`
+
+func TestImportOpenApi(t *testing.T) {
+	content, warnings, err := importOpenApi([]byte(testOpenApi))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"updatePet: parameter session in cookie is not supported, skipped"}, warnings)
+
+	api, err := parser.ParseContent(content)
+	assert.Nil(t, err)
+	assert.Equal(t, "pet-store", api.Service.Name)
+
+	types := make(map[string]spec.Type)
+	for _, tp := range api.Types {
+		types[tp.Name()] = tp
+	}
+
+	status, ok := types["Status"].(spec.EnumType)
+	assert.True(t, ok)
+	assert.Equal(t, []spec.EnumMember{
+		{Name: "Available", Value: `"available"`},
+		{Name: "Sold", Value: `"sold"`},
+	}, status.Members)
+
+	pet, ok := types["Pet"].(spec.DefineStruct)
+	assert.True(t, ok)
+	assert.Equal(t, "`json:\"name\"`", pet.Members[0].Tag)
+	assert.Equal(t, "`json:\"kind,optional,options=cat|dog\"`", pet.Members[2].Tag)
+	assert.Equal(t, "map[string]int64", pet.Members[3].Type.Name())
+
+	listReq, ok := types["ListPetsReq"].(spec.DefineStruct)
+	assert.True(t, ok)
+	assert.Equal(t, "`form:\"limit,optional,range=[1:100]\"`", listReq.Members[0].Tag)
+	assert.Equal(t, "`header:\"X-Trace-Id\"`", listReq.Members[1].Tag)
+
+	assert.Equal(t, 2, len(api.Service.Groups))
+	list := api.Service.Groups[0]
+	assert.Equal(t, "pet", list.GetAnnotation("group"))
+	assert.Equal(t, "", list.GetAnnotation("jwt"))
+	assert.Equal(t, "/pets", list.Routes[0].Path)
+	assert.Equal(t, "[]Pet", list.Routes[0].ResponseType.Name())
+
+	update := api.Service.Groups[1]
+	assert.Equal(t, "BearerAuth", update.GetAnnotation("jwt"))
+	assert.Equal(t, "/pets/:petId", update.Routes[0].Path)
+	assert.Equal(t, "UpdatePetReq", update.Routes[0].RequestType.Name())
+}
+
+func TestImportExportedDocument(t *testing.T) {
+	api, err := parser.ParseContent(testApi)
+	assert.Nil(t, err)
+
+	doc, err := buildDocument(api)
+	assert.Nil(t, err)
+
+	data, err := yaml.Marshal(doc)
+	assert.Nil(t, err)
+
+	content, warnings, err := importOpenApi(data)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+
+	imported, err := parser.ParseContent(content)
+	assert.Nil(t, err)
+	assert.Equal(t, len(api.Service.Routes()), len(imported.Service.Routes()))
+	for _, tp := range imported.Types {
+		if tp.Name() == "User" {
+			user := tp.(spec.DefineStruct)
+			assert.Equal(t, "`json:\"age,optional,range=(0:150]\"`", user.Members[1].Tag)
+		}
+	}
+}
+
+func TestConvertApiPath(t *testing.T) {
+	path, params, ok := convertApiPath("/users/{user-id}/books/{book.id}")
+	assert.True(t, ok)
+	assert.Equal(t, "/users/:user_id/books/:book_id", path)
+	assert.Equal(t, map[string]string{"user-id": "user_id", "book.id": "book_id"}, params)
+
+	_, _, ok = convertApiPath("/v1.0/health")
+	assert.False(t, ok)
+}
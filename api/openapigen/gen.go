@@ -0,0 +1,57 @@
+package openapigen
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/util"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultOutput = "openapi.yaml"
+
+// OpenApiCommand generates an OpenAPI 3.0 document from the api file
+func OpenApiCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	output := c.String("o")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+
+	if len(output) == 0 {
+		output = defaultOutput
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		fmt.Println(aurora.Red("Failed"))
+		return err
+	}
+
+	doc, err := buildDocument(api)
+	if err != nil {
+		return err
+	}
+
+	content, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	if err := util.MkdirIfNotExist(filepath.Dir(output)); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(output, content, os.ModePerm); err != nil {
+		return err
+	}
+
+	fmt.Println(aurora.Green("Done."))
+	return nil
+}
@@ -0,0 +1,129 @@
+package openapigen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/api/parser"
+	"gopkg.in/yaml.v2"
+)
+
+const testApi = `
+info(
+	title: "user api"
+	version: "1.1.0"
+)
+
+type Status enum {
+	Active = 1
+	Banned = 2
+}
+
+type Page {
+	Page int64 ` + "`form:\"page,range=[1:]\"`" + `
+	Size int64 ` + "`form:\"size,optional,default=10\"`" + `
+}
+
+type User {
+	Name   string ` + "`json:\"name,options=a|b\"`" + `
+	Age    int32  ` + "`json:\"age,optional,range=(0:150]\"`" + `
+	Status Status ` + "`json:\"status\"`" + `
+}
+
+type UpdateReq {
+	Id    int64  ` + "`path:\"id\"`" + `
+	Token string ` + "`header:\"X-Token\"`" + `
+	User
+}
+
+type ListReq {
+	Page
+}
+
+@server(
+	jwt: Auth
+	group: user
+)
+service user-api {
+	@doc(
+		summary: "update user"
+	)
+	@handler updateUser
+	put /users/:id (UpdateReq) returns (User)
+}
+
+service user-api {
+	@handler listUsers
+	get /users (ListReq) returns ([]User)
+}
+`
+
+func TestBuildDocument(t *testing.T) {
+	api, err := parser.ParseContent(testApi)
+	assert.Nil(t, err)
+
+	doc, err := buildDocument(api)
+	assert.Nil(t, err)
+	assert.Equal(t, openapiVersion, doc.OpenApi)
+	assert.Equal(t, "user api", doc.Info.Title)
+	assert.Equal(t, "1.1.0", doc.Info.Version)
+	assert.Equal(t, []Tag{{Name: "user"}, {Name: "user-api"}}, doc.Tags)
+	assert.Equal(t, SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		doc.Components.SecuritySchemes["Auth"])
+
+	assert.Equal(t, 2, len(doc.Paths))
+	assert.Equal(t, "/users/{id}", doc.Paths[0].Key)
+	update := doc.Paths[0].Value.(*PathItem).Put
+	assert.NotNil(t, update)
+	assert.Equal(t, "updateUser", update.OperationId)
+	assert.Equal(t, "update user", update.Summary)
+	assert.Equal(t, []map[string][]string{{"Auth": {}}}, update.Security)
+	assert.Equal(t, []Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int64"}},
+		{Name: "X-Token", In: "header", Required: true, Schema: &Schema{Type: "string"}},
+	}, update.Parameters)
+	assert.Equal(t, "#/components/schemas/UpdateReq", update.RequestBody.Content[jsonContentType].Schema.Ref)
+
+	list := doc.Paths[1].Value.(*PathItem).Get
+	assert.NotNil(t, list)
+	assert.Nil(t, list.RequestBody)
+	assert.Nil(t, list.Security)
+	assert.Equal(t, "query", list.Parameters[0].In)
+	assert.True(t, list.Parameters[0].Required)
+	assert.Equal(t, float64(1), *list.Parameters[0].Schema.Minimum)
+	assert.False(t, list.Parameters[1].Required)
+	assert.Equal(t, int64(10), list.Parameters[1].Schema.Default)
+	response := list.Responses[0].Value.(Response)
+	assert.Equal(t, &Schema{Type: "array", Items: refSchema("User")}, response.Content[jsonContentType].Schema)
+
+	schemas := make(map[interface{}]*Schema)
+	for _, item := range doc.Components.Schemas {
+		schemas[item.Key] = item.Value.(*Schema)
+	}
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, schemas["Status"].Enum)
+	assert.Equal(t, []string{"Active", "Banned"}, schemas["Status"].EnumVarNames)
+
+	user := schemas["User"]
+	assert.Equal(t, []string{"name", "status"}, user.Required)
+	age := user.Properties[1].Value.(*Schema)
+	assert.Equal(t, "int32", age.Format)
+	assert.True(t, age.ExclusiveMinimum)
+	assert.False(t, age.ExclusiveMaximum)
+	assert.Equal(t, float64(150), *age.Maximum)
+	assert.Equal(t, []interface{}{"a", "b"}, user.Properties[0].Value.(*Schema).Enum)
+	assert.Equal(t, refSchema("Status"), user.Properties[2].Value)
+
+	// the inline members are flattened and the non-json members are excluded
+	updateReq := schemas["UpdateReq"]
+	assert.Equal(t, 3, len(updateReq.Properties))
+	assert.Equal(t, "name", updateReq.Properties[0].Key)
+
+	_, err = yaml.Marshal(doc)
+	assert.Nil(t, err)
+}
+
+func TestConvertPath(t *testing.T) {
+	path, params := convertPath("/users/:id/books/:bookId")
+	assert.Equal(t, "/users/{id}/books/{bookId}", path)
+	assert.Equal(t, []string{"id", "bookId"}, params)
+}
@@ -0,0 +1,249 @@
+package openapigen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/weitrue/goctl/api/spec"
+	"gopkg.in/yaml.v2"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// typeSchema returns the schema of a type declared in api file
+func (b *builder) typeSchema(tp spec.Type) (*Schema, error) {
+	var schema *Schema
+	switch v := tp.(type) {
+	case spec.DefineStruct:
+		s, err := b.structSchema(v)
+		if err != nil {
+			return nil, err
+		}
+
+		schema = s
+	case spec.AliasType:
+		s, err := b.schemaOf(v.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(s.Ref) > 0 {
+			// the siblings of $ref are ignored, so the alias keeps the reference only
+			return s, nil
+		}
+
+		schema = s
+	case spec.EnumType:
+		schema = primitiveSchema(v.Value.RawName)
+		for _, member := range v.Members {
+			schema.Enum = append(schema.Enum, enumValue(v.Value.RawName, member.Value))
+			schema.EnumVarNames = append(schema.EnumVarNames, member.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", tp.Name())
+	}
+
+	schema.Description = joinDocs(tp.Documents())
+	return schema, nil
+}
+
+func (b *builder) structSchema(tp spec.DefineStruct) (*Schema, error) {
+	schema := &Schema{
+		Type:       "object",
+		Properties: yaml.MapSlice{},
+	}
+	err := b.walkMembers(tp, func(member spec.Member, tag *spec.Tag) error {
+		if tag.Key != bodyTagKey || tag.Name == "-" {
+			return nil
+		}
+
+		property, err := b.memberSchema(member)
+		if err != nil {
+			return err
+		}
+
+		schema.Properties = append(schema.Properties, yaml.MapItem{Key: tag.Name, Value: property})
+		if !isOptional(tag) {
+			schema.Required = append(schema.Required, tag.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("type %s: %w", tp.Name(), err)
+	}
+
+	return schema, nil
+}
+
+// walkMembers visits the tags of members, the members of inline structures are flattened
+func (b *builder) walkMembers(tp spec.DefineStruct, fn func(member spec.Member, tag *spec.Tag) error) error {
+	for _, member := range tp.Members {
+		if member.IsInline {
+			inline, ok := b.resolveStruct(member.Type)
+			if !ok {
+				return fmt.Errorf("inline type %s not found", member.Type.Name())
+			}
+
+			if err := b.walkMembers(inline, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tags, err := spec.Parse(member.Tag)
+		if err != nil {
+			return fmt.Errorf("member %s: %w", member.Name, err)
+		}
+
+		for _, tag := range tags.Tags() {
+			if err := fn(member, tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *builder) memberSchema(member spec.Member) (*Schema, error) {
+	schema, err := b.schemaOf(member.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schema.Ref) > 0 {
+		return schema, nil
+	}
+
+	rule, err := member.GetValidationRule()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range rule.Options {
+		schema.Enum = append(schema.Enum, enumValue(rule.Kind, item))
+	}
+	if rule.Default != nil {
+		schema.Default = enumValue(rule.Kind, *rule.Default)
+	}
+	if rule.Range != nil {
+		if len(rule.Range.Left) > 0 {
+			left, _ := strconv.ParseFloat(rule.Range.Left, 64)
+			schema.Minimum = &left
+			schema.ExclusiveMinimum = !rule.Range.LeftInclude
+		}
+		if len(rule.Range.Right) > 0 {
+			right, _ := strconv.ParseFloat(rule.Range.Right, 64)
+			schema.Maximum = &right
+			schema.ExclusiveMaximum = !rule.Range.RightInclude
+		}
+	}
+
+	schema.Description = trimComment(member.Comment)
+	return schema, nil
+}
+
+// schemaOf returns the schema of a type referred by members, requests or responses
+func (b *builder) schemaOf(tp spec.Type) (*Schema, error) {
+	switch v := tp.(type) {
+	case spec.PrimitiveType:
+		return primitiveSchema(v.RawName), nil
+	case spec.DefineStruct, spec.AliasType, spec.EnumType:
+		if _, ok := b.types[tp.Name()]; !ok {
+			return nil, fmt.Errorf("type %s not found", tp.Name())
+		}
+
+		return refSchema(tp.Name()), nil
+	case spec.PointerType:
+		return b.schemaOf(v.Type)
+	case spec.ArrayType:
+		if p, ok := v.Value.(spec.PrimitiveType); ok && (p.RawName == "byte" || p.RawName == "uint8") {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+
+		items, err := b.schemaOf(v.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: "array", Items: items}, nil
+	case spec.MapType:
+		value, err := b.schemaOf(v.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: "object", AdditionalProperties: value}, nil
+	case spec.InterfaceType:
+		return &Schema{}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", tp.Name())
+}
+
+func (b *builder) resolveStruct(tp spec.Type) (spec.DefineStruct, bool) {
+	switch v := tp.(type) {
+	case spec.DefineStruct:
+		if defined, ok := b.types[v.Name()].(spec.DefineStruct); ok {
+			return defined, true
+		}
+	case spec.AliasType:
+		return b.resolveStruct(v.Value)
+	}
+
+	return spec.DefineStruct{}, false
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func primitiveSchema(name string) *Schema {
+	switch name {
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int64", "uint64", "int", "uint", "uintptr":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "byte", "rune":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	}
+
+	return &Schema{Type: "string"}
+}
+
+// enumValue converts the literal into the value of kind, the literal is checked already
+func enumValue(kind, literal string) interface{} {
+	switch {
+	case strings.HasPrefix(kind, "float"):
+		v, _ := strconv.ParseFloat(literal, 64)
+		return v
+	case strings.HasPrefix(kind, "int") || strings.HasPrefix(kind, "uint") || kind == "byte" || kind == "rune":
+		v, _ := strconv.ParseInt(literal, 10, 64)
+		return v
+	case kind == "bool":
+		v, _ := strconv.ParseBool(literal)
+		return v
+	}
+
+	if s, err := strconv.Unquote(literal); err == nil {
+		return s
+	}
+
+	return literal
+}
+
+func joinDocs(docs []string) string {
+	var lines []string
+	for _, doc := range docs {
+		if line := trimComment(doc); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,202 @@
+package openapigen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const maxRefDepth = 10
+
+type (
+	// sourceDocument describes the OpenAPI 3.0 or Swagger 2.0 document to import,
+	// only the fields which can be expressed in api file are declared
+	sourceDocument struct {
+		Swagger     string                `yaml:"swagger"`
+		OpenApi     string                `yaml:"openapi"`
+		Info        sourceInfo            `yaml:"info"`
+		Paths       yaml.MapSlice         `yaml:"paths"`
+		Components  sourceComponents      `yaml:"components"`
+		Definitions yaml.MapSlice         `yaml:"definitions"`
+		Security    []map[string][]string `yaml:"security"`
+	}
+
+	sourceInfo struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+		Contact     struct {
+			Name  string `yaml:"name"`
+			Email string `yaml:"email"`
+		} `yaml:"contact"`
+	}
+
+	sourceComponents struct {
+		Schemas         yaml.MapSlice             `yaml:"schemas"`
+		SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes"`
+	}
+
+	sourceOperation struct {
+		Tags        []string               `yaml:"tags"`
+		Summary     string                 `yaml:"summary"`
+		Description string                 `yaml:"description"`
+		OperationId string                 `yaml:"operationId"`
+		Parameters  []interface{}          `yaml:"parameters"`
+		RequestBody interface{}            `yaml:"requestBody"`
+		Responses   yaml.MapSlice          `yaml:"responses"`
+		Security    *[]map[string][]string `yaml:"security"`
+	}
+
+	sourceParameter struct {
+		Name        string        `yaml:"name"`
+		In          string        `yaml:"in"`
+		Description string        `yaml:"description"`
+		Required    bool          `yaml:"required"`
+		Schema      *sourceSchema `yaml:"schema"`
+		// the type of Swagger 2.0 parameters which are not in body is declared in place
+		Type             string        `yaml:"type"`
+		Format           string        `yaml:"format"`
+		Items            *sourceSchema `yaml:"items"`
+		Enum             []interface{} `yaml:"enum"`
+		Default          interface{}   `yaml:"default"`
+		Minimum          *float64      `yaml:"minimum"`
+		Maximum          *float64      `yaml:"maximum"`
+		ExclusiveMinimum bool          `yaml:"exclusiveMinimum"`
+		ExclusiveMaximum bool          `yaml:"exclusiveMaximum"`
+	}
+
+	sourceRequestBody struct {
+		Required bool                       `yaml:"required"`
+		Content  map[string]sourceMediaType `yaml:"content"`
+	}
+
+	sourceResponse struct {
+		Description string                     `yaml:"description"`
+		Content     map[string]sourceMediaType `yaml:"content"`
+		// Schema is the response schema of Swagger 2.0
+		Schema *sourceSchema `yaml:"schema"`
+	}
+
+	sourceMediaType struct {
+		Schema *sourceSchema `yaml:"schema"`
+	}
+
+	sourceSchema struct {
+		Ref                  string          `yaml:"$ref"`
+		Type                 string          `yaml:"type"`
+		Format               string          `yaml:"format"`
+		Description          string          `yaml:"description"`
+		Enum                 []interface{}   `yaml:"enum"`
+		EnumVarNames         []string        `yaml:"x-enum-varnames"`
+		Default              interface{}     `yaml:"default"`
+		Minimum              *float64        `yaml:"minimum"`
+		Maximum              *float64        `yaml:"maximum"`
+		ExclusiveMinimum     bool            `yaml:"exclusiveMinimum"`
+		ExclusiveMaximum     bool            `yaml:"exclusiveMaximum"`
+		Items                *sourceSchema   `yaml:"items"`
+		Properties           yaml.MapSlice   `yaml:"properties"`
+		Required             []string        `yaml:"required"`
+		AdditionalProperties interface{}     `yaml:"additionalProperties"`
+		AllOf                []*sourceSchema `yaml:"allOf"`
+	}
+)
+
+// loadSource decodes the document into ordered maps, so that the declaration order is kept
+func loadSource(data []byte) (yaml.MapSlice, *sourceDocument, error) {
+	var root yaml.MapSlice
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+
+	var doc sourceDocument
+	if err := convert(root, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	if len(doc.OpenApi) == 0 && len(doc.Swagger) == 0 {
+		return nil, nil, errors.New("missing openapi or swagger version, expecting an OpenAPI 3.0 or Swagger 2.0 document")
+	}
+
+	return root, &doc, nil
+}
+
+// convert decodes the generic value into out through yaml
+func convert(in, out interface{}) error {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, out)
+}
+
+// lookup finds the value which the local reference points to, such as #/components/parameters/id
+func lookup(root yaml.MapSlice, ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported reference %q, only the local reference is supported", ref)
+	}
+
+	var current interface{} = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		node, ok := current.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+
+		var found bool
+		for _, item := range node {
+			if fmt.Sprint(item.Key) == token {
+				current, found = item.Value, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+	}
+
+	return current, nil
+}
+
+// resolve decodes the value into out, the value is looked up first if it is a reference
+func resolve(root yaml.MapSlice, value, out interface{}) error {
+	for depth := 0; ; depth++ {
+		ref := refOf(value)
+		if len(ref) == 0 {
+			break
+		}
+
+		if depth > maxRefDepth {
+			return fmt.Errorf("too deep reference %q", ref)
+		}
+
+		target, err := lookup(root, ref)
+		if err != nil {
+			return err
+		}
+
+		value = target
+	}
+
+	return convert(value, out)
+}
+
+func refOf(value interface{}) string {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range v {
+			if fmt.Sprint(item.Key) == "$ref" {
+				return fmt.Sprint(item.Value)
+			}
+		}
+	case map[interface{}]interface{}:
+		if ref, ok := v["$ref"]; ok {
+			return fmt.Sprint(ref)
+		}
+	}
+
+	return ""
+}
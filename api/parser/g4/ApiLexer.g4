@@ -14,6 +14,8 @@ STRING:             '"' (~["\\] | EscapeSequence)* '"';
 RAW_STRING:         '`' (~[`\\\r\n] | EscapeSequence)+ '`';
 LINE_VALUE:         ':' [ \t]* (STRING|(~[\r\n"`]*));
 ID:         Letter LetterOrDigit*;
+INT:        [0-9]+;
+SEMICOLON:  ';' -> channel(HIDDEN);
 
 
 fragment ExponentPart
@@ -35,8 +35,8 @@ typeSpec:       typeLit
 typeLit:        {match(p,"type")}typeToken=ID  typeLitBody;
 // eg: type (...)
 typeBlock:      {match(p,"type")}typeToken=ID lp='(' typeBlockBody* rp=')';
-typeLitBody:    typeStruct|typeAlias;
-typeBlockBody:  typeBlockStruct|typeBlockAlias;
+typeLitBody:    {isEnum(p)}? typeEnum|typeStruct|typeAlias;
+typeBlockBody:  {isEnum(p)}? typeEnum|typeBlockStruct|typeBlockAlias;
 typeStruct:     {checkKeyword(p)}structName=ID structToken=ID? lbrace='{'  field* rbrace='}';
 typeAlias:      {checkKeyword(p)}alias=ID assign='='? dataType;
 typeBlockStruct: {checkKeyword(p)}structName=ID structToken=ID? lbrace='{'  field* rbrace='}';
@@ -70,4 +70,8 @@ replybody:      lp='(' dataType? rp=')';
 kvLit:          key=ID {checkKeyValue(p)}value=LINE_VALUE;
 
 serviceName:    (ID '-'?)+;
-path:           (('/' (ID ('-' ID)*))|('/:' (ID ('-' ID)?)))+;
\ No newline at end of file
+path:           (('/' (ID ('-' ID)*))|('/:' (ID ('-' ID)?)))+;
+
+// enum, eg: type Status enum { Pending = 1; Paid = 2 }
+typeEnum:       {checkKeyword(p)}enumName=ID enumToken=ID lbrace='{' enumMember* rbrace='}';
+enumMember:     {checkKeyword(p)}memberName=ID assign='=' (minus='-'? value=INT|value=STRING);
\ No newline at end of file
@@ -3,6 +3,7 @@ package ast
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/weitrue/goctl/api/parser/g4/gen/api"
 )
@@ -44,15 +45,15 @@ func (v *ApiVisitor) VisitApi(ctx *api.ApiContext) interface{} {
 
 func (v *ApiVisitor) acceptService(root, final *Api) {
 	for _, service := range root.Service {
-		if _, ok := final.serviceM[service.ServiceApi.Name.Text()]; !ok && len(final.serviceM) > 0 {
-			v.panic(service.ServiceApi.Name, "multiple service declaration")
-		}
+		final.serviceM[service.ServiceApi.Name.Text()] = Holder
 		v.duplicateServerItemCheck(service)
+		v.prefixCheck(service)
 
 		for _, route := range service.ServiceApi.ServiceRoute {
-			uniqueRoute := fmt.Sprintf("%s %s", route.Route.Method.Text(), route.Route.Path.Text())
+			uniqueRoute := service.RouteKey(route)
 			if _, ok := final.routeM[uniqueRoute]; ok {
-				v.panic(route.Route.Method, fmt.Sprintf("duplicate route '%s'", uniqueRoute))
+				v.panic(route.Route.Method, fmt.Sprintf("duplicate route '%s %s'",
+					route.Route.Method.Text(), service.JoinPrefix(route.Route.Path.Text())))
 			}
 
 			final.routeM[uniqueRoute] = Holder
@@ -104,6 +105,21 @@ func (v *ApiVisitor) duplicateServerItemCheck(service *Service) {
 	}
 }
 
+func (v *ApiVisitor) prefixCheck(service *Service) {
+	if service.AtServer == nil {
+		return
+	}
+
+	if service.AtServer.Kv.Get(prefixKey) != nil && service.AtServer.Kv.Get(pathPrefixKey) != nil {
+		v.panic(service.AtServer.Kv.Get(pathPrefixKey), fmt.Sprintf("duplicate key '%s', use '%s' instead", pathPrefixKey, prefixKey))
+	}
+
+	prefix := service.Prefix()
+	if prefix != nil && !prefixRegex.MatchString(strings.Trim(prefix.Text(), `"`)) {
+		v.panic(prefix, fmt.Sprintf("invalid prefix '%s', expecting path like /api/v1", prefix.Text()))
+	}
+}
+
 func (v *ApiVisitor) acceptType(root, final *Api) {
 	for _, tp := range root.Type {
 		if _, ok := final.typeM[tp.NameExpr().Text()]; ok {
@@ -187,17 +187,16 @@ func (p *Parser) valid(mainApi, nestedApi *Api) error {
 	mainRouteMap := make(map[string]PlaceHolder)
 	mainTypeMap := make(map[string]PlaceHolder)
 
-	routeMap := func(list []*ServiceRoute) (map[string]PlaceHolder, map[string]PlaceHolder) {
+	routeMap := func(service *Service) (map[string]PlaceHolder, map[string]PlaceHolder) {
 		handlerMap := make(map[string]PlaceHolder)
 		routeMap := make(map[string]PlaceHolder)
 
-		for _, g := range list {
+		for _, g := range service.ServiceApi.ServiceRoute {
 			handler := g.GetHandler()
 			if handler.IsNotNil() {
 				handlerName := handler.Text()
 				handlerMap[handlerName] = Holder
-				path := fmt.Sprintf("%s://%s", g.Route.Method.Text(), g.Route.Path.Text())
-				routeMap[path] = Holder
+				routeMap[service.RouteKey(g)] = Holder
 			}
 		}
 
@@ -205,7 +204,7 @@ func (p *Parser) valid(mainApi, nestedApi *Api) error {
 	}
 
 	for _, each := range mainApi.Service {
-		h, r := routeMap(each.ServiceApi.ServiceRoute)
+		h, r := routeMap(each)
 
 		for k, v := range h {
 			mainHandlerMap[k] = v
@@ -250,8 +249,7 @@ func (p *Parser) duplicateRouteCheck(nestedApi *Api, mainHandlerMap, mainRouteMa
 					nestedApi.LinePrefix, handler.Line(), handler.Column(), handler.Text())
 			}
 
-			path := fmt.Sprintf("%s://%s", r.Route.Method.Text(), r.Route.Path.Text())
-			if _, ok := mainRouteMap[path]; ok {
+			if _, ok := mainRouteMap[each.RouteKey(r)]; ok {
 				return fmt.Errorf("%s line %d:%d duplicate route '%s'",
 					nestedApi.LinePrefix, r.Route.Method.Line(), r.Route.Method.Column(), r.Route.Method.Text()+" "+r.Route.Path.Text())
 			}
@@ -275,15 +273,6 @@ func (p *Parser) nestedApiCheck(mainApi, nestedApi *Api) error {
 		}
 	}
 
-	if len(mainApi.Service) > 0 {
-		mainService := mainApi.Service[0]
-		for _, service := range nestedApi.Service {
-			if mainService.ServiceApi.Name.Text() != service.ServiceApi.Name.Text() {
-				return fmt.Errorf("%s multiple service name declaration, expecting service name '%s', but found '%s'",
-					nestedApi.LinePrefix, mainService.ServiceApi.Name.Text(), service.ServiceApi.Name.Text())
-			}
-		}
-	}
 	return nil
 }
 
@@ -380,13 +369,16 @@ func (p *Parser) checkRequestBody(route *Route, types map[string]TypeExpr, lineP
 
 func (p *Parser) checkTypes(apiItem *Api, linePrefix string, types map[string]TypeExpr) error {
 	for _, each := range apiItem.Type {
-		tp, ok := each.(*TypeStruct)
-		if !ok {
-			continue
-		}
-
-		for _, member := range tp.Fields {
-			err := p.checkType(linePrefix, types, member.DataType)
+		switch tp := each.(type) {
+		case *TypeStruct:
+			for _, member := range tp.Fields {
+				err := p.checkType(linePrefix, types, member.DataType)
+				if err != nil {
+					return err
+				}
+			}
+		case *TypeAlias:
+			err := p.checkType(linePrefix, types, tp.DataType)
 			if err != nil {
 				return err
 			}
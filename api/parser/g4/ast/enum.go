@@ -0,0 +1,206 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weitrue/goctl/api/parser/g4/gen/api"
+	"github.com/zeromicro/antlr"
+)
+
+type (
+	// TypeEnum describes enum ast for api syntax, such as
+	//	type OrderStatus enum {
+	//		Pending = 1
+	//		Paid = 2
+	//	}
+	TypeEnum struct {
+		Name        Expr
+		Enum        Expr
+		LBrace      Expr
+		RBrace      Expr
+		DocExpr     []Expr
+		CommentExpr Expr
+		Members     []*EnumMember
+	}
+
+	// EnumMember describes a member of TypeEnum, the value is an integer or a string literal
+	EnumMember struct {
+		Name        Expr
+		Assign      Expr
+		Value       Expr
+		DocExpr     []Expr
+		CommentExpr Expr
+	}
+)
+
+// NameExpr returns the expression string of TypeEnum
+func (e *TypeEnum) NameExpr() Expr {
+	return e.Name
+}
+
+// Doc returns the document of TypeEnum, like // some text
+func (e *TypeEnum) Doc() []Expr {
+	return e.DocExpr
+}
+
+// Comment returns the comment of TypeEnum, like // some text
+func (e *TypeEnum) Comment() Expr {
+	return e.CommentExpr
+}
+
+// Format provides a formatter for api command, now nothing to do
+func (e *TypeEnum) Format() error {
+	return nil
+}
+
+// IsString returns true if the values of TypeEnum are string literals
+func (e *TypeEnum) IsString() bool {
+	return len(e.Members) > 0 && strings.HasPrefix(e.Members[0].Value.Text(), `"`)
+}
+
+// Equal compares whether the element literals in two TypeEnum are equal
+func (e *TypeEnum) Equal(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+
+	enum, ok := v.(*TypeEnum)
+	if !ok {
+		return false
+	}
+
+	if !e.Name.Equal(enum.Name) {
+		return false
+	}
+
+	if len(e.Members) != len(enum.Members) {
+		return false
+	}
+
+	for index, each := range e.Members {
+		if !each.Equal(enum.Members[index]) {
+			return false
+		}
+	}
+
+	return EqualDoc(e, enum)
+}
+
+// Doc returns the document of EnumMember, like // some text
+func (m *EnumMember) Doc() []Expr {
+	return m.DocExpr
+}
+
+// Comment returns the comment of EnumMember, like // some text
+func (m *EnumMember) Comment() Expr {
+	return m.CommentExpr
+}
+
+// Format provides a formatter for api command, now nothing to do
+func (m *EnumMember) Format() error {
+	return nil
+}
+
+// Equal compares whether the element literals in two EnumMember are equal
+func (m *EnumMember) Equal(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+
+	member, ok := v.(*EnumMember)
+	if !ok {
+		return false
+	}
+
+	if !m.Name.Equal(member.Name) {
+		return false
+	}
+
+	if !m.Value.Equal(member.Value) {
+		return false
+	}
+
+	return EqualDoc(m, member)
+}
+
+// VisitTypeEnum implements from api.BaseApiParserVisitor
+func (v *ApiVisitor) VisitTypeEnum(ctx *api.TypeEnumContext) interface{} {
+	var enum TypeEnum
+	enum.Name = v.newExprWithToken(ctx.GetEnumName())
+	enum.Enum = v.newExprWithToken(ctx.GetEnumToken())
+	enum.LBrace = v.newExprWithToken(ctx.GetLbrace())
+	enum.RBrace = v.newExprWithToken(ctx.GetRbrace())
+	enum.DocExpr = v.getDoc(ctx)
+	enum.CommentExpr = v.getComment(ctx)
+
+	members := ctx.AllEnumMember()
+	if len(members) == 0 {
+		v.panic(enum.Name, fmt.Sprintf("empty enum '%s'", enum.Name.Text()))
+	}
+
+	memberM := make(map[string]PlaceHolder)
+	for index, each := range members {
+		member := each.Accept(v).(*EnumMember)
+		if index > 0 && !isSeparated(members[index-1], each) {
+			v.panic(member.Name, fmt.Sprintf("expecting ';' or new line before '%s'", member.Name.Text()))
+		}
+
+		if _, ok := memberM[member.Name.Text()]; ok {
+			v.panic(member.Name, fmt.Sprintf("duplicate enum member '%s'", member.Name.Text()))
+		}
+
+		memberM[member.Name.Text()] = Holder
+		v.checkEnumValue(&enum, member)
+		enum.Members = append(enum.Members, member)
+	}
+
+	return &enum
+}
+
+// VisitEnumMember implements from api.BaseApiParserVisitor
+func (v *ApiVisitor) VisitEnumMember(ctx *api.EnumMemberContext) interface{} {
+	var member EnumMember
+	member.Name = v.newExprWithToken(ctx.GetMemberName())
+	member.Assign = v.newExprWithToken(ctx.GetAssign())
+	value := ctx.GetValue()
+	if minus := ctx.GetMinus(); minus != nil {
+		member.Value = v.newExprWithText(minus.GetText()+value.GetText(), minus.GetLine(), minus.GetColumn(),
+			minus.GetStart(), value.GetStop())
+	} else {
+		member.Value = v.newExprWithToken(value)
+	}
+
+	member.DocExpr = v.getDoc(ctx)
+	member.CommentExpr = v.getComment(ctx)
+	return &member
+}
+
+func (v *ApiVisitor) checkEnumValue(enum *TypeEnum, member *EnumMember) {
+	if len(enum.Members) == 0 {
+		return
+	}
+
+	isString := strings.HasPrefix(member.Value.Text(), `"`)
+	if isString != enum.IsString() {
+		v.panic(member.Value, fmt.Sprintf("mismatched value type of enum '%s', found input '%s'",
+			enum.Name.Text(), member.Value.Text()))
+	}
+}
+
+// isSeparated returns true if the members are declared in different lines or separated by ';'
+func isSeparated(prev, next TokenStream) bool {
+	if prev.GetStop().GetLine() != next.GetStart().GetLine() {
+		return true
+	}
+
+	ct := next.GetParser().GetTokenStream().(*antlr.CommonTokenStream)
+	tokens := ct.GetHiddenTokensToRight(prev.GetStop().GetTokenIndex(), antlr.TokenHiddenChannel)
+	for _, each := range tokens {
+		if each.GetTokenType() == api.ApiParserParserSEMICOLON {
+			return true
+		}
+	}
+
+	return false
+}
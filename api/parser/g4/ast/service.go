@@ -2,11 +2,20 @@ package ast
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/weitrue/goctl/api/parser/g4/gen/api"
 )
 
+const (
+	prefixKey     = "prefix"
+	pathPrefixKey = "pathPrefix"
+)
+
+var prefixRegex = regexp.MustCompile(`^(/[\w.\-~]+)+/?$`)
+
 // Service describes service for api syntax
 type Service struct {
 	AtServer   *AtServer
@@ -639,3 +648,39 @@ func (kv KV) Get(key string) Expr {
 	}
 	return nil
 }
+
+// Prefix returns the route prefix declared by @server(prefix: /api/v1), the deprecated key
+// pathPrefix is supported too
+func (s *Service) Prefix() Expr {
+	if s.AtServer == nil {
+		return nil
+	}
+
+	if prefix := s.AtServer.Kv.Get(prefixKey); prefix != nil {
+		return prefix
+	}
+
+	return s.AtServer.Kv.Get(pathPrefixKey)
+}
+
+// JoinPrefix returns the path joined with the route prefix of service
+func (s *Service) JoinPrefix(path string) string {
+	prefix := s.Prefix()
+	if prefix == nil {
+		return path
+	}
+
+	text := strings.TrimRight(strings.Trim(prefix.Text(), `"`), "/")
+	if len(text) > 0 && path == "/" {
+		return text
+	}
+
+	return text + path
+}
+
+// RouteKey returns the key which identifies the route in its service, the same route can be
+// declared by different services since they are served separately
+func (s *Service) RouteKey(route *ServiceRoute) string {
+	return fmt.Sprintf("%s %s %s", s.ServiceApi.Name.Text(), route.Route.Method.Text(),
+		s.JoinPrefix(route.Route.Path.Text()))
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type (
-	// TypeExpr describes an expression for TypeAlias and TypeStruct
+	// TypeExpr describes an expression for TypeAlias, TypeStruct and TypeEnum
 	TypeExpr interface {
 		Doc() []Expr
 		Format() error
@@ -121,6 +121,12 @@ func (v *ApiVisitor) VisitTypeLit(ctx *api.TypeLitContext) interface{} {
 		return st
 	}
 
+	enum, ok := typeLit.(*TypeEnum)
+	if ok {
+		enum.DocExpr = doc
+		return enum
+	}
+
 	return typeLit
 }
 
@@ -136,6 +142,9 @@ func (v *ApiVisitor) VisitTypeBlock(ctx *api.TypeBlockContext) interface{} {
 
 // VisitTypeLitBody implements from api.BaseApiParserVisitor
 func (v *ApiVisitor) VisitTypeLitBody(ctx *api.TypeLitBodyContext) interface{} {
+	if ctx.TypeEnum() != nil {
+		return ctx.TypeEnum().Accept(v)
+	}
 	if ctx.TypeAlias() != nil {
 		return ctx.TypeAlias().Accept(v)
 	}
@@ -144,6 +153,9 @@ func (v *ApiVisitor) VisitTypeLitBody(ctx *api.TypeLitBodyContext) interface{} {
 
 // VisitTypeBlockBody implements from api.BaseApiParserVisitor
 func (v *ApiVisitor) VisitTypeBlockBody(ctx *api.TypeBlockBodyContext) interface{} {
+	if ctx.TypeEnum() != nil {
+		return ctx.TypeEnum().Accept(v).(*TypeEnum)
+	}
 	if ctx.TypeBlockAlias() != nil {
 		return ctx.TypeBlockAlias().Accept(v).(*TypeAlias)
 	}
@@ -220,12 +232,12 @@ func (v *ApiVisitor) VisitTypeBlockStruct(ctx *api.TypeBlockStructContext) inter
 func (v *ApiVisitor) VisitTypeBlockAlias(ctx *api.TypeBlockAliasContext) interface{} {
 	var alias TypeAlias
 	alias.Name = v.newExprWithToken(ctx.GetAlias())
-	alias.Assign = v.newExprWithToken(ctx.GetAssign())
+	if ctx.GetAssign() != nil {
+		alias.Assign = v.newExprWithToken(ctx.GetAssign())
+	}
 	alias.DataType = ctx.DataType().Accept(v).(DataType)
 	alias.DocExpr = v.getDoc(ctx)
 	alias.CommentExpr = v.getComment(ctx)
-	// todo: reopen if necessary
-	v.panic(alias.Name, "unsupported alias")
 	return &alias
 }
 
@@ -233,12 +245,12 @@ func (v *ApiVisitor) VisitTypeBlockAlias(ctx *api.TypeBlockAliasContext) interfa
 func (v *ApiVisitor) VisitTypeAlias(ctx *api.TypeAliasContext) interface{} {
 	var alias TypeAlias
 	alias.Name = v.newExprWithToken(ctx.GetAlias())
-	alias.Assign = v.newExprWithToken(ctx.GetAssign())
+	if ctx.GetAssign() != nil {
+		alias.Assign = v.newExprWithToken(ctx.GetAssign())
+	}
 	alias.DataType = ctx.DataType().Accept(v).(DataType)
 	alias.DocExpr = v.getDoc(ctx)
 	alias.CommentExpr = v.getComment(ctx)
-	// todo: reopen if necessary
-	v.panic(alias.Name, "unsupported alias")
 	return &alias
 }
 
@@ -392,7 +404,11 @@ func (a *TypeAlias) Equal(v interface{}) bool {
 		return false
 	}
 
-	if !a.Assign.Equal(alias.Assign) {
+	if a.Assign != nil {
+		if !a.Assign.Equal(alias.Assign) {
+			return false
+		}
+	} else if alias.Assign != nil {
 		return false
 	}
 
@@ -154,3 +154,11 @@ func (v *BaseApiParserVisitor) VisitServiceName(ctx *ServiceNameContext) interfa
 func (v *BaseApiParserVisitor) VisitPath(ctx *PathContext) interface{} {
 	return v.VisitChildren(ctx)
 }
+
+func (v *BaseApiParserVisitor) VisitTypeEnum(ctx *TypeEnumContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseApiParserVisitor) VisitEnumMember(ctx *EnumMemberContext) interface{} {
+	return v.VisitChildren(ctx)
+}
@@ -117,4 +117,10 @@ type ApiParserVisitor interface {
 
 	// Visit a parse tree produced by ApiParserParser#path.
 	VisitPath(ctx *PathContext) interface{}
+
+	// Visit a parse tree produced by ApiParserParser#typeEnum.
+	VisitTypeEnum(ctx *TypeEnumContext) interface{}
+
+	// Visit a parse tree produced by ApiParserParser#enumMember.
+	VisitEnumMember(ctx *EnumMemberContext) interface{}
 }
@@ -175,6 +175,13 @@ func isNormal(p *ApiParserParser) bool {
 	return len(list) > 1
 }
 
+// isEnum returns true if the type declares an enum like Foo enum {, the members of enum
+// are separated by new lines or SEMICOLON which is in the hidden channel
+func isEnum(p *ApiParserParser) bool {
+	ts := p.GetTokenStream()
+	return ts.LT(2).GetText() == "enum" && ts.LT(3).GetTokenType() == ApiParserParserT__3
+}
+
 // MatchTag returns a Boolean value, which returns true if it does matched, otherwise returns fase
 func MatchTag(v string) bool {
 	return matchRegex(v, tagRegex)
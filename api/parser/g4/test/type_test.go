@@ -256,19 +256,45 @@ func TestAlias(t *testing.T) {
 		return p.TypeAlias().Accept(visitor)
 	}
 	t.Run("normal", func(t *testing.T) {
-		_, err := parser.Accept(fn, `Foo int`)
-		assert.Error(t, err)
+		v, err := parser.Accept(fn, `Foo int`)
+		assert.Nil(t, err)
+		alias := v.(*ast.TypeAlias)
+		assert.True(t, alias.Equal(&ast.TypeAlias{
+			Name:     ast.NewTextExpr("Foo"),
+			DataType: &ast.Literal{Literal: ast.NewTextExpr("int")},
+		}))
 
-		_, err = parser.Accept(fn, `Foo=int`)
-		assert.Error(t, err)
+		v, err = parser.Accept(fn, `Foo=int`)
+		assert.Nil(t, err)
+		alias = v.(*ast.TypeAlias)
+		assert.True(t, alias.Equal(&ast.TypeAlias{
+			Name:     ast.NewTextExpr("Foo"),
+			Assign:   ast.NewTextExpr("="),
+			DataType: &ast.Literal{Literal: ast.NewTextExpr("int")},
+		}))
 
-		_, err = parser.Accept(fn, `
-		Foo int // comment`)
-		assert.Error(t, err)
+		v, err = parser.Accept(fn, `Foo []Bar`)
+		assert.Nil(t, err)
+		alias = v.(*ast.TypeAlias)
+		assert.True(t, alias.Equal(&ast.TypeAlias{
+			Name: ast.NewTextExpr("Foo"),
+			DataType: &ast.Array{
+				ArrayExpr: ast.NewTextExpr("[]Bar"),
+				LBrack:    ast.NewTextExpr("["),
+				RBrack:    ast.NewTextExpr("]"),
+				Literal:   &ast.Literal{Literal: ast.NewTextExpr("Bar")},
+			},
+		}))
 
-		_, err = parser.Accept(fn, `
-		Foo int /**comment*/`)
-		assert.Error(t, err)
+		v, err = parser.Accept(fn, `
+		Foo int // comment`)
+		assert.Nil(t, err)
+		alias = v.(*ast.TypeAlias)
+		assert.True(t, alias.Equal(&ast.TypeAlias{
+			Name:        ast.NewTextExpr("Foo"),
+			DataType:    &ast.Literal{Literal: ast.NewTextExpr("int")},
+			CommentExpr: ast.NewTextExpr("// comment"),
+		}))
 	})
 
 	t.Run("wrong", func(t *testing.T) {
@@ -329,18 +355,89 @@ func TestTypeStruct(t *testing.T) {
 	})
 }
 
+func TestTypeEnum(t *testing.T) {
+	fn := func(p *api.ApiParserParser, visitor *ast.ApiVisitor) interface{} {
+		return p.TypeEnum().Accept(visitor)
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		v, err := parser.Accept(fn, "Status enum {\n\t\t\t// pending doc\n\t\t\tPending = -1 // pending comment\n\t\t\tPaid = 2; Closed = 3;\n\t\t}")
+		assert.Nil(t, err)
+		e := v.(*ast.TypeEnum)
+		assert.True(t, e.Equal(&ast.TypeEnum{
+			Name:   ast.NewTextExpr("Status"),
+			Enum:   ast.NewTextExpr("enum"),
+			LBrace: ast.NewTextExpr("{"),
+			RBrace: ast.NewTextExpr("}"),
+			Members: []*ast.EnumMember{
+				{
+					Name:        ast.NewTextExpr("Pending"),
+					Value:       ast.NewTextExpr("-1"),
+					DocExpr:     []ast.Expr{ast.NewTextExpr("// pending doc")},
+					CommentExpr: ast.NewTextExpr("// pending comment"),
+				},
+				{
+					Name:  ast.NewTextExpr("Paid"),
+					Value: ast.NewTextExpr("2"),
+				},
+				{
+					Name:  ast.NewTextExpr("Closed"),
+					Value: ast.NewTextExpr("3"),
+				},
+			},
+		}))
+
+		v, err = parser.Accept(fn, `Color enum { Red = "red"; Green = "green" }`)
+		assert.Nil(t, err)
+		e = v.(*ast.TypeEnum)
+		assert.True(t, e.IsString())
+		assert.Equal(t, `"green"`, e.Members[1].Value.Text())
+	})
+
+	t.Run("wrong", func(t *testing.T) {
+		_, err := parser.Accept(fn, `Foo enum {}`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { A = 1 B = 2 }`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { A = 1; A = 2 }`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { A = 1; B = "b" }`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { A = 1.5 }`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { A = B }`)
+		assert.Error(t, err)
+
+		_, err = parser.Accept(fn, `Foo enum { type = 1 }`)
+		assert.Error(t, err)
+	})
+}
+
 func TestTypeBlock(t *testing.T) {
 	fn := func(p *api.ApiParserParser, visitor *ast.ApiVisitor) interface{} {
 		return p.TypeBlock().Accept(visitor)
 	}
 	t.Run("normal", func(t *testing.T) {
-		_, err := parser.Accept(fn, `type(
+		v, err := parser.Accept(fn, `type(
 			// doc
 			Foo int
 		)`)
-		assert.Error(t, err)
+		assert.Nil(t, err)
+		alias := v.([]ast.TypeExpr)
+		assert.True(t, alias[0].Equal(&ast.TypeAlias{
+			Name:     ast.NewTextExpr("Foo"),
+			DataType: &ast.Literal{Literal: ast.NewTextExpr("int")},
+			DocExpr: []ast.Expr{
+				ast.NewTextExpr("// doc"),
+			},
+		}))
 
-		v, err := parser.Accept(fn, `type (
+		v, err = parser.Accept(fn, `type (
 			// doc
 			Foo {
 				Bar int
@@ -371,17 +468,27 @@ func TestTypeLit(t *testing.T) {
 	}
 	t.Run("normal", func(t *testing.T) {
 		_, err := parser.Accept(fn, `type Foo int`)
-		assert.Error(t, err)
+		assert.Nil(t, err)
 
 		_, err = parser.Accept(fn, `type Foo = int`)
-		assert.Error(t, err)
+		assert.Nil(t, err)
 
-		_, err = parser.Accept(fn, `
+		v, err := parser.Accept(fn, `
 		// doc
 		type Foo = int // comment`)
-		assert.Error(t, err)
+		assert.Nil(t, err)
+		alias := v.(*ast.TypeAlias)
+		assert.True(t, alias.Equal(&ast.TypeAlias{
+			Name:     ast.NewTextExpr("Foo"),
+			Assign:   ast.NewTextExpr("="),
+			DataType: &ast.Literal{Literal: ast.NewTextExpr("int")},
+			DocExpr: []ast.Expr{
+				ast.NewTextExpr("// doc"),
+			},
+			CommentExpr: ast.NewTextExpr("// comment"),
+		}))
 
-		v, err := parser.Accept(fn, `
+		v, err = parser.Accept(fn, `
 		// doc
 		type Foo {// comment
 			Bar int
@@ -426,6 +533,30 @@ func TestTypeLit(t *testing.T) {
 				ast.NewTextExpr("// doc"),
 			},
 		}))
+
+		v, err = parser.Accept(fn, `
+		// doc
+		type Foo enum { Bar = 1 } // comment`)
+		assert.Nil(t, err)
+		e := v.(*ast.TypeEnum)
+		assert.True(t, e.Equal(&ast.TypeEnum{
+			Name: ast.NewTextExpr("Foo"),
+			Members: []*ast.EnumMember{
+				{
+					Name:  ast.NewTextExpr("Bar"),
+					Value: ast.NewTextExpr("1"),
+				},
+			},
+			DocExpr: []ast.Expr{
+				ast.NewTextExpr("// doc"),
+			},
+			CommentExpr: ast.NewTextExpr("// comment"),
+		}))
+
+		v, err = parser.Accept(fn, `type Foo struct {}`)
+		assert.Nil(t, err)
+		_, ok := v.(*ast.TypeStruct)
+		assert.True(t, ok)
 	})
 
 	t.Run("wrong", func(t *testing.T) {
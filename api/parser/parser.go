@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/weitrue/goctl/api/parser/g4/ast"
@@ -108,6 +109,15 @@ func (p parser) fillTypes() error {
 				Members: members,
 				Docs:    p.stringExprs(v.Doc()),
 			})
+		case *ast.TypeAlias:
+			p.spec.Types = append(p.spec.Types, spec.AliasType{
+				RawName: v.Name.Text(),
+				Value:   p.astTypeToSpec(v.DataType),
+				Docs:    p.stringExprs(v.Doc()),
+				Assign:  v.Assign != nil,
+			})
+		case *ast.TypeEnum:
+			p.spec.Types = append(p.spec.Types, p.enumToSpec(v))
 		default:
 			return fmt.Errorf("unknown type %+v", v)
 		}
@@ -119,19 +129,25 @@ func (p parser) fillTypes() error {
 		case spec.DefineStruct:
 			var members []spec.Member
 			for _, member := range v.Members {
-				switch v := member.Type.(type) {
-				case spec.DefineStruct:
-					tp, err := p.findDefinedType(v.RawName)
-					if err != nil {
-						return err
-					}
-
-					member.Type = *tp
+				tp, err := p.resolveType(member.Type, nil)
+				if err != nil {
+					return err
 				}
+
+				member.Type = tp
 				members = append(members, member)
 			}
 			v.Members = members
 			types = append(types, v)
+		case spec.AliasType:
+			tp, err := p.resolveAlias(v, nil)
+			if err != nil {
+				return err
+			}
+
+			types = append(types, tp)
+		case spec.EnumType:
+			types = append(types, v)
 		default:
 			return fmt.Errorf("unknown type %+v", v)
 		}
@@ -141,9 +157,70 @@ func (p parser) fillTypes() error {
 	return nil
 }
 
+// resolveType replaces the named references in tp with the declared types,
+// aliases are resolved to their final value, structures are not expanded further.
+func (p parser) resolveType(tp spec.Type, visited []string) (spec.Type, error) {
+	switch v := tp.(type) {
+	case spec.DefineStruct:
+		defined, err := p.findDefinedType(v.RawName)
+		if err != nil {
+			return nil, err
+		}
+
+		if alias, ok := (*defined).(spec.AliasType); ok {
+			return p.resolveAlias(alias, visited)
+		}
+
+		return *defined, nil
+	case spec.ArrayType:
+		value, err := p.resolveType(v.Value, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Value = value
+		return v, nil
+	case spec.MapType:
+		value, err := p.resolveType(v.Value, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Value = value
+		return v, nil
+	case spec.PointerType:
+		value, err := p.resolveType(v.Type, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Type = value
+		return v, nil
+	}
+
+	return tp, nil
+}
+
+func (p parser) resolveAlias(alias spec.AliasType, visited []string) (spec.Type, error) {
+	for _, name := range visited {
+		if name == alias.RawName {
+			return nil, fmt.Errorf("invalid recursive type alias %s", strings.Join(append(visited, name), " -> "))
+		}
+	}
+
+	value, err := p.resolveType(alias.Value, append(visited, alias.RawName))
+	if err != nil {
+		return nil, err
+	}
+
+	alias.Value = value
+	return alias, nil
+}
+
 func (p parser) findDefinedType(name string) (*spec.Type, error) {
 	for _, item := range p.spec.Types {
-		if _, ok := item.(spec.DefineStruct); ok {
+		switch item.(type) {
+		case spec.DefineStruct, spec.AliasType, spec.EnumType:
 			if item.Name() == name {
 				return &item, nil
 			}
@@ -153,6 +230,30 @@ func (p parser) findDefinedType(name string) (*spec.Type, error) {
 	return nil, fmt.Errorf("type %s not defined", name)
 }
 
+func (p parser) enumToSpec(enum *ast.TypeEnum) spec.EnumType {
+	value := spec.PrimitiveType{RawName: "int64"}
+	if enum.IsString() {
+		value = spec.PrimitiveType{RawName: "string"}
+	}
+
+	var members []spec.EnumMember
+	for _, item := range enum.Members {
+		members = append(members, spec.EnumMember{
+			Name:    item.Name.Text(),
+			Value:   item.Value.Text(),
+			Comment: p.commentExprs(item.Comment()),
+			Docs:    p.stringExprs(item.Doc()),
+		})
+	}
+
+	return spec.EnumType{
+		RawName: enum.Name.Text(),
+		Value:   value,
+		Members: members,
+		Docs:    p.stringExprs(enum.Doc()),
+	}
+}
+
 func (p parser) fieldToMember(field *ast.TypeField) spec.Member {
 	name := ""
 	tag := ""
@@ -227,6 +328,7 @@ func (p parser) commentExprs(comment ast.Expr) string {
 
 func (p parser) fillService() error {
 	var groups []spec.Group
+	serviceIndex := make(map[string]int)
 	for _, item := range p.ast.Service {
 		var group spec.Group
 		p.fillAtServer(item, &group)
@@ -273,14 +375,20 @@ func (p parser) fillService() error {
 			}
 
 			group.Routes = append(group.Routes, route)
-
-			name := item.ServiceApi.Name.Text()
-			if len(p.spec.Service.Name) > 0 && p.spec.Service.Name != name {
-				return fmt.Errorf("multiple service names defined %s and %s", name, p.spec.Service.Name)
-			}
-			p.spec.Service.Name = name
 		}
 		groups = append(groups, group)
+
+		name := item.ServiceApi.Name.Text()
+		index, ok := serviceIndex[name]
+		if !ok {
+			index = len(p.spec.Services)
+			serviceIndex[name] = index
+			p.spec.Services = append(p.spec.Services, spec.Service{Name: name})
+		}
+		p.spec.Services[index].Groups = append(p.spec.Services[index].Groups, group)
+	}
+	if len(p.spec.Services) > 0 {
+		p.spec.Service.Name = p.spec.Services[0].Name
 	}
 	p.spec.Service.Groups = groups
 
@@ -325,24 +433,24 @@ func (p parser) fillRouteType(route *spec.Route) error {
 	if route.RequestType != nil {
 		switch route.RequestType.(type) {
 		case spec.DefineStruct:
-			tp, err := p.findDefinedType(route.RequestType.Name())
+			tp, err := p.resolveType(route.RequestType, nil)
 			if err != nil {
 				return err
 			}
 
-			route.RequestType = *tp
+			route.RequestType = tp
 		}
 	}
 
 	if route.ResponseType != nil {
 		switch route.ResponseType.(type) {
 		case spec.DefineStruct:
-			tp, err := p.findDefinedType(route.ResponseType.Name())
+			tp, err := p.resolveType(route.ResponseType, nil)
 			if err != nil {
 				return err
 			}
 
-			route.ResponseType = *tp
+			route.ResponseType = tp
 		}
 	}
 
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,134 @@ func TestParseContent(t *testing.T) {
 		}
 	}
 }
+
+var testAliasApi = "type UserID int64\n\ntype (\n\tTags = []string\n\tUserIDs []UserID\n)\n\ntype Request {\n\tId UserID `path:\"id\"`\n\tTags Tags `json:\"tags\"`\n\tFriends UserIDs `json:\"friends\"`\n}\n\nservice greet-api {\n\t@handler GreetHandler\n\tget /from/:id(Request)\n}"
+
+func TestParseAlias(t *testing.T) {
+	sp, err := ParseContent(testAliasApi)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(sp.Types))
+	assert.Equal(t, spec.AliasType{
+		RawName: "UserID",
+		Value:   spec.PrimitiveType{RawName: "int64"},
+	}, sp.Types[0])
+
+	tags, ok := sp.Types[1].(spec.AliasType)
+	assert.True(t, ok)
+	assert.True(t, tags.Assign)
+
+	userIDs, ok := sp.Types[2].(spec.AliasType)
+	assert.True(t, ok)
+	assert.False(t, userIDs.Assign)
+	assert.Equal(t, spec.ArrayType{
+		RawName: "[]UserID",
+		Value: spec.AliasType{
+			RawName: "UserID",
+			Value:   spec.PrimitiveType{RawName: "int64"},
+		},
+	}, userIDs.Value)
+
+	request, ok := sp.Types[3].(spec.DefineStruct)
+	assert.True(t, ok)
+	assert.Equal(t, "UserID", request.Members[0].Type.Name())
+	_, ok = request.Members[0].Type.(spec.AliasType)
+	assert.True(t, ok)
+	_, ok = request.Members[1].Type.(spec.AliasType)
+	assert.True(t, ok)
+}
+
+func TestParseRecursiveAlias(t *testing.T) {
+	_, err := ParseContent("type (\n\tFoo Bar\n\tBar []Foo\n)")
+	assert.Error(t, err)
+}
+
+var testEnumApi = "// order status\ntype OrderStatus enum { Pending = 1; Paid = 2 }\n\ntype (\n\t// color\n\tColor enum {\n\t\t// red doc\n\t\tRed = \"red\" // red comment\n\t\tGreen = \"green\"\n\t}\n\n\tRequest {\n\t\tStatus OrderStatus `json:\"status\"`\n\t\tColor *Color `json:\"color\"`\n\t}\n)\n\nservice greet-api {\n\t@handler GreetHandler\n\tpost /greet(Request)\n}"
+
+func TestParseEnum(t *testing.T) {
+	sp, err := ParseContent(testEnumApi)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(sp.Types))
+	assert.Equal(t, spec.EnumType{
+		RawName: "OrderStatus",
+		Value:   spec.PrimitiveType{RawName: "int64"},
+		Members: []spec.EnumMember{
+			{Name: "Pending", Value: "1"},
+			{Name: "Paid", Value: "2"},
+		},
+		Docs: spec.Doc{"// order status"},
+	}, sp.Types[0])
+
+	color := spec.EnumType{
+		RawName: "Color",
+		Value:   spec.PrimitiveType{RawName: "string"},
+		Members: []spec.EnumMember{
+			{Name: "Red", Value: `"red"`, Comment: "// red comment", Docs: spec.Doc{"// red doc"}},
+			{Name: "Green", Value: `"green"`},
+		},
+		Docs: spec.Doc{"// color"},
+	}
+	assert.Equal(t, color, sp.Types[1])
+
+	request, ok := sp.Types[2].(spec.DefineStruct)
+	assert.True(t, ok)
+	assert.Equal(t, sp.Types[0], request.Members[0].Type)
+	assert.Equal(t, spec.PointerType{RawName: "*Color", Type: color}, request.Members[1].Type)
+}
+
+func TestParseInvalidEnum(t *testing.T) {
+	_, err := ParseContent("type Foo enum {}")
+	assert.Error(t, err)
+
+	_, err = ParseContent("type Foo enum { A = 1; A = 2 }")
+	assert.Error(t, err)
+
+	_, err = ParseContent(`type Foo enum { A = 1; B = "b" }`)
+	assert.Error(t, err)
+
+	_, err = ParseContent("type Foo enum { A = 1 B = 2 }")
+	assert.Error(t, err)
+
+	_, err = ParseContent("type Foo enum { A = 1.5 }")
+	assert.Error(t, err)
+
+	_, err = ParseContent("type Foo enum { A = 1 }\ntype Foo {\n\tA int `json:\"a\"`\n}")
+	assert.Error(t, err)
+}
+
+var testPrefixApi = "type Request {\n\tName string `path:\"name\"`\n}\n\n@server(\n\tprefix: /api/v1\n)\nservice greet-api {\n\t@handler GreetV1Handler\n\tget /from/:name(Request)\n}\n\n@server(\n\tprefix: \"/api/v2/\"\n)\nservice greet-api {\n\t@handler GreetV2Handler\n\tget /from/:name(Request)\n}"
+
+func TestParsePrefix(t *testing.T) {
+	sp, err := ParseContent(testPrefixApi)
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1", sp.Service.Groups[0].GetPrefix())
+	assert.Equal(t, "/api/v2", sp.Service.Groups[1].GetPrefix())
+	assert.Equal(t, "/api/v2/from/:name", sp.Service.Groups[1].JoinPrefix(sp.Service.Groups[1].Routes[0].Path))
+	assert.Equal(t, "/api/v2", sp.Service.Groups[1].JoinPrefix("/"))
+
+	_, err = ParseContent(strings.Replace(testPrefixApi, "/api/v2/", "/api/v1", 1))
+	assert.Contains(t, err.Error(), "duplicate route")
+
+	_, err = ParseContent(strings.Replace(testPrefixApi, "/api/v2/", "api v2", 1))
+	assert.Contains(t, err.Error(), "invalid prefix")
+}
+
+var testMultipleServiceApi = "type Request {\n\tName string `path:\"name\"`\n}\n\nservice user-api {\n\t@handler GreetHandler\n\tget /from/:name(Request)\n}\n\n@server(\n\tjwt: Auth\n)\nservice user-admin {\n\t@handler AdminGreetHandler\n\tget /from/:name(Request)\n}\n\nservice user-api {\n\t@handler PingHandler\n\tget /ping\n}"
+
+func TestParseMultipleService(t *testing.T) {
+	sp, err := ParseContent(testMultipleServiceApi)
+	assert.Nil(t, err)
+	assert.Equal(t, "user-api", sp.Service.Name)
+	assert.Equal(t, 3, len(sp.Service.Groups))
+	assert.Equal(t, 2, len(sp.Services))
+	assert.Equal(t, "user-api", sp.Services[0].Name)
+	assert.Equal(t, 2, len(sp.Services[0].Groups))
+	assert.Equal(t, "PingHandler", sp.Services[0].Groups[1].Routes[0].Handler)
+	assert.Equal(t, "user-admin", sp.Services[1].Name)
+	assert.Equal(t, "Auth", sp.Services[1].Groups[0].GetAnnotation("jwt"))
+
+	_, err = ParseContent(strings.Replace(testMultipleServiceApi, "AdminGreetHandler", "GreetHandler", 1))
+	assert.Contains(t, err.Error(), "duplicate handler")
+
+	_, err = ParseContent(strings.Replace(testMultipleServiceApi, "get /ping", "get /from/:name(Request)", 1))
+	assert.Contains(t, err.Error(), "duplicate route")
+}
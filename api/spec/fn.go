@@ -13,6 +13,9 @@ const (
 	formTagKey        = "form"
 	pathTagKey        = "path"
 	defaultSummaryKey = "summary"
+	prefixKey         = "prefix"
+	// Deprecated: use prefix instead, it is kept for the api files written for tsgen
+	pathPrefixKey = "pathPrefix"
 )
 
 var definedKeys = []string{bodyTagKey, formTagKey, pathTagKey}
@@ -181,6 +184,37 @@ func (g Group) GetAnnotation(key string) string {
 	return g.Annotation.Properties[key]
 }
 
+// GetPrefix returns the route prefix from @server(prefix: /api/v1), it is empty or
+// starts with '/' without the trailing '/'
+func (g Group) GetPrefix() string {
+	prefix := g.GetAnnotation(prefixKey)
+	if len(prefix) == 0 {
+		prefix = g.GetAnnotation(pathPrefixKey)
+	}
+
+	prefix = strings.TrimRight(strings.Trim(strings.TrimSpace(prefix), `"`), "/")
+	if len(prefix) > 0 && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return prefix
+}
+
+// JoinPrefix returns the path joined with the route prefix of group, which is the path
+// served by the generated server
+func (g Group) JoinPrefix(path string) string {
+	prefix := g.GetPrefix()
+	if len(prefix) == 0 {
+		return path
+	}
+
+	if path == "/" {
+		return prefix
+	}
+
+	return prefix + path
+}
+
 // ResponseTypeName returns response type name of route
 func (r Route) ResponseTypeName() string {
 	if r.ResponseType == nil {
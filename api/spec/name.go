@@ -89,3 +89,33 @@ func (t InterfaceType) Comments() []string {
 func (t InterfaceType) Documents() []string {
 	return nil
 }
+
+// Name returns an alias string, such as UserID
+func (t AliasType) Name() string {
+	return t.RawName
+}
+
+// Comments returns the comments of alias
+func (t AliasType) Comments() []string {
+	return nil
+}
+
+// Documents returns the documents of alias
+func (t AliasType) Documents() []string {
+	return t.Docs
+}
+
+// Name returns an enum string, such as OrderStatus
+func (t EnumType) Name() string {
+	return t.RawName
+}
+
+// Comments returns the comments of enum
+func (t EnumType) Comments() []string {
+	return nil
+}
+
+// Documents returns the documents of enum
+func (t EnumType) Documents() []string {
+	return t.Docs
+}
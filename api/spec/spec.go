@@ -22,7 +22,11 @@ type (
 		Syntax  ApiSyntax
 		Imports []Import
 		Types   []Type
+		// Service merges the groups of all services and takes the name of the first one,
+		// generators which don't distinguish services cover all routes through it
 		Service Service
+		// Services describes the services in the declaration order
+		Services []Service
 	}
 
 	// Import describes api import
@@ -101,6 +105,36 @@ type (
 		Docs    Doc
 	}
 
+	// AliasType describes a named type declared on another type, such as type UserID int64
+	AliasType struct {
+		RawName string
+		// it can be asserted as PrimitiveType, DefineStruct, MapType,
+		// ArrayType, PointerType, InterfaceType or another AliasType
+		Value Type
+		Docs  Doc
+		// Assign is true if the alias is declared with =, such as type UserID = int64,
+		// which is the same type as its value
+		Assign bool
+	}
+
+	// EnumType describes a set of named constants, such as type OrderStatus enum { Pending = 1; Paid = 2 }
+	EnumType struct {
+		RawName string
+		// the underlying type of members, int64 or string
+		Value   PrimitiveType
+		Members []EnumMember
+		Docs    Doc
+	}
+
+	// EnumMember describes the member of an enum
+	EnumMember struct {
+		Name string
+		// the literal value, such as 1 or "paid"
+		Value   string
+		Comment string
+		Docs    Doc
+	}
+
 	// PrimitiveType describes the basic golang type, such as bool,int32,int64, ...
 	PrimitiveType struct {
 		RawName string
@@ -0,0 +1,311 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/stringx"
+)
+
+const (
+	optionalOption  = "optional"
+	optionsOption   = "options"
+	defaultOption   = "default"
+	rangeOption     = "range"
+	optionSeparator = "|"
+	equalToken      = "="
+)
+
+type (
+	// ValidationRule describes the validation options of a member tag, such as
+	// `json:"age,optional,range=[1:150],default=18"`
+	ValidationRule struct {
+		Optional bool
+		// Options is the allowed values from options=a|b
+		Options []string
+		// Default is nil if there is no default option
+		Default *string
+		// Range is nil if there is no range option
+		Range *NumberRange
+		// Kind is the primitive type which the rule applies to, such as int64, string,
+		// it is empty if the member is not a primitive type
+		Kind string
+	}
+
+	// NumberRange describes the range option, such as [1:10], (1:10], [1:]
+	NumberRange struct {
+		Left         string
+		LeftInclude  bool
+		Right        string
+		RightInclude bool
+	}
+)
+
+// GetValidationRule parses the options of the json, form or path tag and checks whether they
+// are compatible with the type of member
+func (m Member) GetValidationRule() (*ValidationRule, error) {
+	tags, err := Parse(m.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &ValidationRule{Kind: primitiveKind(m.Type)}
+	for _, tag := range tags.Tags() {
+		if !stringx.Contains(definedKeys, tag.Key) {
+			continue
+		}
+
+		for _, option := range tag.Options {
+			if err := rule.parseOption(option); err != nil {
+				return nil, fmt.Errorf("member %s: %w", m.Name, err)
+			}
+		}
+	}
+
+	if err := rule.check(); err != nil {
+		return nil, fmt.Errorf("member %s: %w", m.Name, err)
+	}
+
+	return rule, nil
+}
+
+// IsEmpty returns true if there is nothing to validate
+func (r *ValidationRule) IsEmpty() bool {
+	return len(r.Options) == 0 && r.Range == nil
+}
+
+// String returns the range notation, such as [1:10]
+func (r NumberRange) String() string {
+	left, right := "(", ")"
+	if r.LeftInclude {
+		left = "["
+	}
+	if r.RightInclude {
+		right = "]"
+	}
+
+	return left + r.Left + ":" + r.Right + right
+}
+
+func (r *ValidationRule) parseOption(option string) error {
+	switch {
+	case option == optionalOption || strings.HasPrefix(option, optionalOption+equalToken):
+		r.Optional = true
+	case strings.HasPrefix(option, optionsOption):
+		value, err := optionValue(option)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range strings.Split(value, optionSeparator) {
+			if len(item) == 0 {
+				return fmt.Errorf("empty value in option %q", option)
+			}
+			r.Options = append(r.Options, item)
+		}
+	case strings.HasPrefix(option, defaultOption):
+		value, err := optionValue(option)
+		if err != nil {
+			return err
+		}
+
+		value = strings.TrimSpace(value)
+		r.Default = &value
+	case strings.HasPrefix(option, rangeOption):
+		value, err := optionValue(option)
+		if err != nil {
+			return err
+		}
+
+		nr, err := parseNumberRange(value)
+		if err != nil {
+			return fmt.Errorf("invalid option %q: %w", option, err)
+		}
+
+		r.Range = nr
+	}
+
+	return nil
+}
+
+func (r *ValidationRule) check() error {
+	if r.IsEmpty() && r.Default == nil {
+		return nil
+	}
+
+	switch {
+	case isNumberKind(r.Kind):
+		for _, item := range r.Options {
+			if err := checkNumber(r.Kind, item); err != nil {
+				return fmt.Errorf("invalid options value %q: %w", item, err)
+			}
+		}
+
+		if r.Range != nil {
+			for _, item := range []string{r.Range.Left, r.Range.Right} {
+				if len(item) == 0 {
+					continue
+				}
+
+				if err := checkNumber(r.Kind, item); err != nil {
+					return fmt.Errorf("invalid range %s: %w", r.Range, err)
+				}
+			}
+		}
+
+		if r.Default != nil {
+			if err := checkNumber(r.Kind, *r.Default); err != nil {
+				return fmt.Errorf("invalid default value %q: %w", *r.Default, err)
+			}
+
+			if r.Range != nil && !r.Range.Contains(*r.Default) {
+				return fmt.Errorf("default value %s is out of range %s", *r.Default, r.Range)
+			}
+		}
+	case r.Kind == "string":
+		if r.Range != nil {
+			return fmt.Errorf("range is not supported on %s", r.Kind)
+		}
+	case r.Kind == "bool":
+		if len(r.Options) > 0 || r.Range != nil {
+			return fmt.Errorf("options and range are not supported on %s", r.Kind)
+		}
+
+		if r.Default != nil {
+			if _, err := strconv.ParseBool(*r.Default); err != nil {
+				return fmt.Errorf("invalid default value %q: %w", *r.Default, err)
+			}
+		}
+	default:
+		if !r.IsEmpty() {
+			return fmt.Errorf("options and range are only supported on basic types")
+		}
+	}
+
+	if r.Default != nil && len(r.Options) > 0 && !stringx.Contains(r.Options, *r.Default) {
+		return fmt.Errorf("default value %s is not in options %s", *r.Default, strings.Join(r.Options, optionSeparator))
+	}
+
+	return nil
+}
+
+// Contains returns true if the number value is in the range
+func (r NumberRange) Contains(value string) bool {
+	v, _ := strconv.ParseFloat(value, 64)
+	if len(r.Left) > 0 {
+		left, _ := strconv.ParseFloat(r.Left, 64)
+		if v < left || !r.LeftInclude && v == left {
+			return false
+		}
+	}
+
+	if len(r.Right) > 0 {
+		right, _ := strconv.ParseFloat(r.Right, 64)
+		if v > right || !r.RightInclude && v == right {
+			return false
+		}
+	}
+
+	return true
+}
+
+func optionValue(option string) (string, error) {
+	segs := strings.Split(option, equalToken)
+	if len(segs) != 2 || len(segs[1]) == 0 {
+		return "", fmt.Errorf("invalid option %q, expecting key=value", option)
+	}
+
+	return segs[1], nil
+}
+
+// parseNumberRange parses the range option in the same notations as go-zero:
+// [:5] (:5] [:5) (:5) [1:] [1:) (1:] (1:) [1:5] [1:5) (1:5] (1:5)
+func parseNumberRange(str string) (*NumberRange, error) {
+	if len(str) < 3 {
+		return nil, fmt.Errorf("bad format %q", str)
+	}
+
+	var nr NumberRange
+	switch str[0] {
+	case '[':
+		nr.LeftInclude = true
+	case '(':
+	default:
+		return nil, fmt.Errorf("expecting '[' or '(', found %q", str[0])
+	}
+
+	switch str[len(str)-1] {
+	case ']':
+		nr.RightInclude = true
+	case ')':
+	default:
+		return nil, fmt.Errorf("expecting ']' or ')', found %q", str[len(str)-1])
+	}
+
+	fields := strings.Split(str[1:len(str)-1], ":")
+	if len(fields) != 2 || len(fields[0]) == 0 && len(fields[1]) == 0 {
+		return nil, fmt.Errorf("bad format %q", str)
+	}
+
+	nr.Left, nr.Right = strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+	for _, item := range fields {
+		if len(item) == 0 {
+			continue
+		}
+
+		if _, err := strconv.ParseFloat(item, 64); err != nil {
+			return nil, fmt.Errorf("bad number %q", item)
+		}
+	}
+
+	if len(nr.Left) > 0 && len(nr.Right) > 0 {
+		left, _ := strconv.ParseFloat(nr.Left, 64)
+		right, _ := strconv.ParseFloat(nr.Right, 64)
+		if left > right {
+			return nil, fmt.Errorf("left %s is greater than right %s", nr.Left, nr.Right)
+		}
+	}
+
+	return &nr, nil
+}
+
+// primitiveKind returns the primitive type under pointers, aliases and enums
+func primitiveKind(tp Type) string {
+	switch v := tp.(type) {
+	case PrimitiveType:
+		return v.RawName
+	case PointerType:
+		return primitiveKind(v.Type)
+	case AliasType:
+		return primitiveKind(v.Value)
+	case EnumType:
+		return v.Value.RawName
+	}
+
+	return ""
+}
+
+func isNumberKind(kind string) bool {
+	switch kind {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "byte", "rune", "uintptr":
+		return true
+	}
+
+	return false
+}
+
+func checkNumber(kind, value string) error {
+	var err error
+	switch {
+	case strings.HasPrefix(kind, "float"):
+		_, err = strconv.ParseFloat(value, 64)
+	case strings.HasPrefix(kind, "uint") || kind == "byte":
+		_, err = strconv.ParseUint(value, 10, 64)
+	default:
+		_, err = strconv.ParseInt(value, 10, 64)
+	}
+
+	return err
+}
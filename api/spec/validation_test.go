@@ -0,0 +1,65 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidationRule(t *testing.T) {
+	member := Member{
+		Name: "Age",
+		Type: PointerType{RawName: "*int", Type: PrimitiveType{RawName: "int"}},
+		Tag:  "`json:\"age,optional,range=(1:150],default=18\"`",
+	}
+	rule, err := member.GetValidationRule()
+	assert.Nil(t, err)
+	assert.True(t, rule.Optional)
+	assert.Equal(t, "int", rule.Kind)
+	assert.Equal(t, "18", *rule.Default)
+	assert.Equal(t, &NumberRange{Left: "1", Right: "150", RightInclude: true}, rule.Range)
+	assert.Equal(t, "(1:150]", rule.Range.String())
+
+	member = Member{
+		Name: "Color",
+		Type: EnumType{RawName: "Color", Value: PrimitiveType{RawName: "string"}},
+		Tag:  "`form:\"color,options=red|green\"`",
+	}
+	rule, err = member.GetValidationRule()
+	assert.Nil(t, err)
+	assert.False(t, rule.Optional)
+	assert.Equal(t, []string{"red", "green"}, rule.Options)
+
+	member = Member{
+		Name: "Name",
+		Type: PrimitiveType{RawName: "string"},
+		Tag:  "`json:\"name,omitempty\"`",
+	}
+	rule, err = member.GetValidationRule()
+	assert.Nil(t, err)
+	assert.True(t, rule.IsEmpty())
+}
+
+func TestGetValidationRuleError(t *testing.T) {
+	cases := []struct {
+		tp  Type
+		tag string
+	}{
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,range=[1:"`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,range=[:]"`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,range=[10:1]"`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,range=[1.5:2]"`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,options="`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,options=1|x"`},
+		{tp: PrimitiveType{RawName: "uint"}, tag: `json:"a,default=-1"`},
+		{tp: PrimitiveType{RawName: "int"}, tag: `json:"a,range=[1:10],default=11"`},
+		{tp: PrimitiveType{RawName: "string"}, tag: `json:"a,options=x|y,default=z"`},
+		{tp: PrimitiveType{RawName: "string"}, tag: `json:"a,range=[1:10]"`},
+		{tp: PrimitiveType{RawName: "bool"}, tag: `json:"a,options=true"`},
+		{tp: ArrayType{RawName: "[]int", Value: PrimitiveType{RawName: "int"}}, tag: `json:"a,range=[1:10]"`},
+	}
+	for _, c := range cases {
+		_, err := Member{Name: "A", Type: c.tp, Tag: "`" + c.tag + "`"}.GetValidationRule()
+		assert.Error(t, err, c.tag)
+	}
+}
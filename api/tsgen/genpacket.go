@@ -157,14 +157,7 @@ func callParamsForRoute(route spec.Route, group spec.Group) string {
 }
 
 func pathForRoute(route spec.Route, group spec.Group) string {
-	prefix := group.GetAnnotation(pathPrefix)
-	if len(prefix) == 0 {
-		return "\"" + route.Path + "\""
-	}
-
-	prefix = strings.TrimPrefix(prefix, `"`)
-	prefix = strings.TrimSuffix(prefix, `"`)
-	return fmt.Sprintf(`"%s/%s"`, prefix, strings.TrimPrefix(route.Path, "/"))
+	return "\"" + group.JoinPrefix(route.Path) + "\""
 }
 
 func pathHasParams(route spec.Route) bool {
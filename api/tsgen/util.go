@@ -48,7 +48,7 @@ func writeIndent(writer io.Writer, indent int) {
 
 func goTypeToTs(tp spec.Type, fromPacket bool) (string, error) {
 	switch v := tp.(type) {
-	case spec.DefineStruct:
+	case spec.DefineStruct, spec.AliasType, spec.EnumType:
 		return addPrefix(tp, fromPacket), nil
 	case spec.PrimitiveType:
 		r, ok := primitiveType(tp.Name())
@@ -110,6 +110,20 @@ func primitiveType(tp string) (string, bool) {
 }
 
 func writeType(writer io.Writer, tp spec.Type) error {
+	if aliasType, ok := tp.(spec.AliasType); ok {
+		ty, err := goTypeToTs(aliasType.Value, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(writer, "export type %s = %s\n", util.Title(tp.Name()), ty)
+		return err
+	}
+
+	if enumType, ok := tp.(spec.EnumType); ok {
+		return writeEnum(writer, enumType)
+	}
+
 	fmt.Fprintf(writer, "export interface %s {\n", util.Title(tp.Name()))
 	if err := writeMembers(writer, tp, false); err != nil {
 		return err
@@ -119,6 +133,28 @@ func writeType(writer io.Writer, tp spec.Type) error {
 	return genParamsTypesIfNeed(writer, tp)
 }
 
+func writeEnum(writer io.Writer, enumType spec.EnumType) error {
+	fmt.Fprintf(writer, "export enum %s {\n", util.Title(enumType.Name()))
+	for _, member := range enumType.Members {
+		for _, doc := range member.Docs {
+			writeIndent(writer, 1)
+			fmt.Fprintf(writer, "%s\n", doc)
+		}
+
+		comment := member.Comment
+		if len(comment) > 0 {
+			comment = " // " + strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+		}
+		writeIndent(writer, 1)
+		if _, err := fmt.Fprintf(writer, "%s = %s,%s\n", member.Name, member.Value, comment); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(writer, "}\n")
+	return err
+}
+
 func genParamsTypesIfNeed(writer io.Writer, tp spec.Type) error {
 	definedType, ok := tp.(spec.DefineStruct)
 	if !ok {
@@ -1,6 +1,3 @@
 package tsgen
 
-const (
-	packagePrefix = "components."
-	pathPrefix    = "pathPrefix"
-)
+const packagePrefix = "components."
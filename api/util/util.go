@@ -10,6 +10,7 @@ import (
 
 	"github.com/weitrue/goctl/api/spec"
 	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/preview"
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
@@ -18,7 +19,11 @@ func MaybeCreateFile(dir, subdir, file string) (fp *os.File, created bool, err e
 	logx.Must(util.MkdirIfNotExist(path.Join(dir, subdir)))
 	fpath := path.Join(dir, subdir, file)
 	if util.FileExists(fpath) {
-		fmt.Printf("%s exists, ignored generation\n", fpath)
+		if preview.Enabled() {
+			preview.Skip(fpath)
+		} else {
+			fmt.Printf("%s exists, ignored generation\n", fpath)
+		}
 		return nil, false, nil
 	}
 
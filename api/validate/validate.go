@@ -7,6 +7,8 @@ import (
 	"github.com/logrusorgru/aurora"
 	"github.com/urfave/cli"
 	"github.com/weitrue/goctl/api/parser"
+	"github.com/weitrue/goctl/api/spec"
+	"github.com/weitrue/goctl/api/util"
 )
 
 // GoValidateApi verifies whether the api has a syntax error
@@ -17,9 +19,36 @@ func GoValidateApi(c *cli.Context) error {
 		return errors.New("missing -api")
 	}
 
-	_, err := parser.Parse(apiFile)
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	err = validateTagOptions(api)
 	if err == nil {
 		fmt.Println(aurora.Green("api format ok"))
 	}
 	return err
 }
+
+// validateTagOptions checks the optional, options, range and default options of the member tags
+func validateTagOptions(api *spec.ApiSpec) error {
+	for _, tp := range api.Types {
+		defineStruct, ok := tp.(spec.DefineStruct)
+		if !ok {
+			continue
+		}
+
+		for _, member := range defineStruct.Members {
+			if member.IsInline {
+				continue
+			}
+
+			if _, err := member.GetValidationRule(); err != nil {
+				return util.WrapErr(err, "type "+defineStruct.Name())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli"
+	"github.com/weitrue/goctl/util/console"
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectFile is the name of the project configuration file, it's looked up from the working
+// directory to the root, like go.mod
+const ProjectFile = "goctl.yaml"
+
+var (
+	// projectFlags are the flags whose defaults are set by goctl.yaml
+	projectFlags = map[string]bool{
+		"style": true, "home": true, "dir": true, "cache": true, "port": true, "namespace": true,
+		"image": true, "secret": true, "replicas": true, "revisions": true, "requestCpu": true,
+		"requestMem": true, "limitCpu": true, "limitMem": true, "minReplicas": true, "maxReplicas": true,
+	}
+	// warnedFiles avoids warning the unknown fields of a goctl.yaml more than once, since it's loaded
+	// by both the flags and the actions of commands
+	warnedFiles sync.Map
+)
+
+type (
+	// ProjectConfig holds the defaults of flags shared by the commands in a project, the flags
+	// which are set explicitly take precedence. The relative paths are relative to the directory
+	// of goctl.yaml.
+	ProjectConfig struct {
+		// Style is the default of --style
+		Style string `yaml:"style"`
+		// Home is the default of --home, the goctl home path of the templates
+		Home   string       `yaml:"home"`
+		Api    ApiConfig    `yaml:"api"`
+		Rpc    RpcConfig    `yaml:"rpc"`
+		Model  ModelConfig  `yaml:"model"`
+		Docker DockerConfig `yaml:"docker"`
+		Kube   KubeConfig   `yaml:"kube"`
+
+		dir string
+	}
+
+	// ApiConfig holds the defaults of api commands
+	ApiConfig struct {
+		// Dir is the output directory of api go and api plugin
+		Dir string `yaml:"dir"`
+		// Plugins are run in order by api plugin if -plugin is not set
+		Plugins []string `yaml:"plugins"`
+	}
+
+	// RpcConfig holds the defaults of rpc commands
+	RpcConfig struct {
+		// Dir is the output directory of rpc proto
+		Dir string `yaml:"dir"`
+	}
+
+	// ModelConfig holds the defaults of model commands
+	ModelConfig struct {
+		// Dir is the output directory of model mysql, model pg and model mongo
+		Dir string `yaml:"dir"`
+		// Cache generates the models with cache if true
+		Cache bool `yaml:"cache"`
+		// TypeMapping is the default of the type mapping of model mysql and model pg, the one of
+		// --types overrides it by entries
+		TypeMapping `yaml:",inline"`
+		// SoftDeleteColumns are the candidates of the soft delete column, delete_time and deleted_at
+		// by default, the first one found in a table is used
+		SoftDeleteColumns []string `yaml:"softDeleteColumns"`
+		// VersionColumns are the candidates of the optimistic locking column, version by default
+		VersionColumns []string `yaml:"versionColumns"`
+	}
+
+	// TypeMapping holds the custom conversions from sql types into go types, the go types of packages
+	// are written with the import paths, such as github.com/shopspring/decimal.Decimal and
+	// encoding/json.RawMessage
+	TypeMapping struct {
+		// Types are keyed by sql types, such as decimal, decimal(10,2), tinyint(1), bigint unsigned
+		// and unsigned for all the unsigned integers
+		Types map[string]string `yaml:"types"`
+		// Columns are keyed by table.column, they take precedence over Types
+		Columns map[string]string `yaml:"columns"`
+	}
+
+	// DockerConfig holds the defaults of docker command
+	DockerConfig struct {
+		Port int `yaml:"port"`
+	}
+
+	// KubeConfig holds the defaults of kube deploy command
+	KubeConfig struct {
+		Namespace   string `yaml:"namespace"`
+		Image       string `yaml:"image"`
+		Secret      string `yaml:"secret"`
+		Replicas    int    `yaml:"replicas"`
+		Revisions   int    `yaml:"revisions"`
+		RequestCpu  int    `yaml:"requestCpu"`
+		RequestMem  int    `yaml:"requestMem"`
+		LimitCpu    int    `yaml:"limitCpu"`
+		LimitMem    int    `yaml:"limitMem"`
+		MinReplicas int    `yaml:"minReplicas"`
+		MaxReplicas int    `yaml:"maxReplicas"`
+	}
+)
+
+// FindProjectFile returns the path of goctl.yaml in dir or its parents
+func FindProjectFile(dir string) (string, bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		file := filepath.Join(abs, ProjectFile)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, true
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", false
+		}
+
+		abs = parent
+	}
+}
+
+// LoadProject loads goctl.yaml found from dir, it returns nil if there is no goctl.yaml. The unknown
+// fields are warned rather than rejected, so that the goctl.yaml of newer versions can be used.
+func LoadProject(dir string) (*ProjectConfig, error) {
+	file, ok := FindProjectFile(dir)
+	if !ok {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ProjectConfig
+	if err = yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	if err = yaml.UnmarshalStrict(content, new(ProjectConfig)); err != nil {
+		if _, warned := warnedFiles.LoadOrStore(file, true); !warned {
+			console.NewColorConsole().Warning("%s: %v", file, err)
+		}
+	}
+
+	cfg.dir = filepath.Dir(file)
+	return &cfg, nil
+}
+
+// ApplyProject sets the defaults of the flags of the command named by args, such as api go -api
+// user.api, by goctl.yaml found from dir. goctl.yaml is loaded only if the command has the flags of it.
+func ApplyProject(dir string, commands []cli.Command, args []string) error {
+	var (
+		path []string
+		cmd  *cli.Command
+	)
+	for _, arg := range args {
+		var next *cli.Command
+		for i := range commands {
+			if commands[i].HasName(arg) {
+				next = &commands[i]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		cmd = next
+		path = append(path, cmd.Name)
+		commands = cmd.Subcommands
+	}
+	if cmd == nil || !hasProjectFlags(cmd) {
+		return nil
+	}
+
+	project, err := LoadProject(dir)
+	if err != nil || project == nil {
+		return err
+	}
+
+	project.applyCommand(strings.Join(path, " "), cmd)
+	return nil
+}
+
+func hasProjectFlags(cmd *cli.Command) bool {
+	for _, flag := range cmd.Flags {
+		if projectFlags[flagName(flag)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func flagName(flag cli.Flag) string {
+	return strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+}
+
+// LoadTypeMapping loads the type mapping from a yaml file which has types and columns
+func LoadTypeMapping(file string) (*TypeMapping, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping TypeMapping
+	if err = yaml.UnmarshalStrict(content, &mapping); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return &mapping, nil
+}
+
+// Merge returns a mapping with the entries of m and other, the ones of other take precedence
+func (m TypeMapping) Merge(other TypeMapping) TypeMapping {
+	merge := func(a, b map[string]string) map[string]string {
+		if len(a) == 0 && len(b) == 0 {
+			return nil
+		}
+
+		merged := make(map[string]string, len(a)+len(b))
+		for k, v := range a {
+			merged[k] = v
+		}
+		for k, v := range b {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	return TypeMapping{
+		Types:   merge(m.Types, other.Types),
+		Columns: merge(m.Columns, other.Columns),
+	}
+}
+
+// Path returns the path relative to the directory of goctl.yaml, it returns empty if path is empty
+func (p *ProjectConfig) Path(path string) string {
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(p.dir, path)
+}
+
+// Apply sets the defaults of the flags in commands, the flags which are set explicitly
+// override them. It does nothing if p is nil.
+func (p *ProjectConfig) Apply(commands []cli.Command) {
+	if p == nil {
+		return
+	}
+
+	p.apply(nil, commands)
+}
+
+func (p *ProjectConfig) apply(parents []string, commands []cli.Command) {
+	for i := range commands {
+		cmd := &commands[i]
+		path := append(append([]string(nil), parents...), cmd.Name)
+		p.applyCommand(strings.Join(path, " "), cmd)
+		p.apply(path, cmd.Subcommands)
+	}
+}
+
+// applyCommand sets the defaults of the flags of cmd, path is the full name of cmd such as api go
+func (p *ProjectConfig) applyCommand(path string, cmd *cli.Command) {
+	defaults := p.defaults(path)
+	for i, flag := range cmd.Flags {
+		if value, ok := defaults[flagName(flag)]; ok {
+			cmd.Flags[i] = withDefault(flag, value)
+		}
+	}
+}
+
+// defaults returns the flag defaults of the command, such as api go, the zero values are ignored
+func (p *ProjectConfig) defaults(command string) map[string]interface{} {
+	values := make(map[string]interface{})
+	set := func(name string, value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if len(v) == 0 {
+				return
+			}
+		case int:
+			if v == 0 {
+				return
+			}
+		case bool:
+			if !v {
+				return
+			}
+		}
+
+		values[name] = value
+	}
+
+	set("style", p.Style)
+	set("home", p.Path(p.Home))
+	switch command {
+	case "api go", "api plugin":
+		set("dir", p.Path(p.Api.Dir))
+	case "rpc proto":
+		set("dir", p.Path(p.Rpc.Dir))
+	case "model mysql ddl", "model mysql datasource", "model pg ddl", "model pg datasource", "model sqlite ddl",
+		"model sqlite datasource", "model mongo":
+		set("dir", p.Path(p.Model.Dir))
+		set("cache", p.Model.Cache)
+	case "docker":
+		set("port", p.Docker.Port)
+	case "kube deploy":
+		kube := p.Kube
+		set("namespace", kube.Namespace)
+		set("image", kube.Image)
+		set("secret", kube.Secret)
+		set("replicas", kube.Replicas)
+		set("revisions", kube.Revisions)
+		set("requestCpu", kube.RequestCpu)
+		set("requestMem", kube.RequestMem)
+		set("limitCpu", kube.LimitCpu)
+		set("limitMem", kube.LimitMem)
+		set("minReplicas", kube.MinReplicas)
+		set("maxReplicas", kube.MaxReplicas)
+	}
+
+	return values
+}
+
+// withDefault returns the flag with the default value, the required flags become optional
+func withDefault(flag cli.Flag, value interface{}) cli.Flag {
+	switch f := flag.(type) {
+	case cli.StringFlag:
+		if v, ok := value.(string); ok {
+			f.Value = v
+			f.Required = false
+			return f
+		}
+	case cli.IntFlag:
+		if v, ok := value.(int); ok {
+			f.Value = v
+			f.Required = false
+			return f
+		}
+	case cli.BoolFlag:
+		// the flag can be turned off by -cache=false
+		if v, ok := value.(bool); ok && v {
+			return cli.BoolTFlag{
+				Name:     f.Name,
+				Usage:    f.Usage,
+				EnvVar:   f.EnvVar,
+				FilePath: f.FilePath,
+				Hidden:   f.Hidden,
+			}
+		}
+	}
+
+	return flag
+}
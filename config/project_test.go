@@ -0,0 +1,189 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const testProject = `style: go_zero
+home: templates
+api:
+  dir: service/api
+  plugins:
+    - goctl-swagger="swagger -filename user.json"
+model:
+  dir: /abs/model
+  cache: true
+  types:
+    decimal: github.com/shopspring/decimal.Decimal
+  columns:
+    user.balance: int64
+  softDeleteColumns:
+    - removed_at
+kube:
+  namespace: prod
+  replicas: 5
+`
+
+func TestLoadProject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sub := filepath.Join(dir, "a", "b")
+	assert.Nil(t, os.MkdirAll(sub, os.ModePerm))
+
+	project, err := LoadProject(sub)
+	assert.Nil(t, err)
+	assert.Nil(t, project)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, ProjectFile), []byte(testProject), os.ModePerm))
+	project, err = LoadProject(sub)
+	assert.Nil(t, err)
+	assert.Equal(t, "go_zero", project.Style)
+	assert.Equal(t, []string{`goctl-swagger="swagger -filename user.json"`}, project.Api.Plugins)
+	assert.Equal(t, filepath.Join(dir, "service", "api"), project.Path(project.Api.Dir))
+	assert.Equal(t, "/abs/model", project.Path(project.Model.Dir))
+	assert.Equal(t, map[string]string{"decimal": "github.com/shopspring/decimal.Decimal"}, project.Model.Types)
+	assert.Equal(t, map[string]string{"user.balance": "int64"}, project.Model.Columns)
+	assert.Equal(t, []string{"removed_at"}, project.Model.SoftDeleteColumns)
+	assert.Nil(t, project.Model.VersionColumns)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sub, ProjectFile), []byte("style: goZero\n"), os.ModePerm))
+	project, err = LoadProject(sub)
+	assert.Nil(t, err)
+	assert.Equal(t, "goZero", project.Style, "the nearest goctl.yaml is used")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sub, ProjectFile), []byte("styles: go_zero\nhome: tpl\n"), os.ModePerm))
+	project, err = LoadProject(sub)
+	assert.Nil(t, err, "unknown fields are warned")
+	assert.Equal(t, filepath.Join(sub, "tpl"), project.Path(project.Home))
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sub, ProjectFile), []byte("style: [\n"), os.ModePerm))
+	_, err = LoadProject(sub)
+	assert.NotNil(t, err)
+}
+
+func TestApplyProject(t *testing.T) {
+	dir := t.TempDir()
+	commands := []cli.Command{
+		{
+			Name: "env",
+		},
+		{
+			Name: "api",
+			Subcommands: []cli.Command{
+				{
+					Name:  "go",
+					Flags: []cli.Flag{cli.StringFlag{Name: "dir"}, cli.StringFlag{Name: "style"}},
+				},
+				{
+					Name:    "format",
+					Aliases: []string{"fmt"},
+					Flags:   []cli.Flag{cli.StringFlag{Name: "dir"}},
+				},
+			},
+		},
+	}
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, ProjectFile), []byte("style: [\n"), os.ModePerm))
+	assert.Nil(t, ApplyProject(dir, commands, nil))
+	assert.Nil(t, ApplyProject(dir, commands, []string{"env"}), "goctl.yaml is not loaded")
+	assert.Nil(t, ApplyProject(dir, commands, []string{"unknown", "go"}))
+	assert.NotNil(t, ApplyProject(dir, commands, []string{"api", "go", "-api", "user.api"}))
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, ProjectFile), []byte("style: go_zero\napi:\n  dir: api\n"),
+		os.ModePerm))
+	assert.Nil(t, ApplyProject(dir, commands, []string{"api", "go", "-api", "user.api"}))
+	assert.Equal(t, cli.StringFlag{Name: "dir", Value: filepath.Join(dir, "api")}, commands[1].Subcommands[0].Flags[0])
+	assert.Equal(t, cli.StringFlag{Name: "style", Value: "go_zero"}, commands[1].Subcommands[0].Flags[1])
+	assert.Nil(t, ApplyProject(dir, commands, []string{"api", "fmt"}))
+	assert.Equal(t, cli.StringFlag{Name: "dir"}, commands[1].Subcommands[1].Flags[0], "dir of api go only")
+}
+
+func TestLoadTypeMapping(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "types.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("types:\n  unsigned: uint64\n  decimal: float64\n"), os.ModePerm))
+	mapping, err := LoadTypeMapping(file)
+	assert.Nil(t, err)
+
+	merged := TypeMapping{
+		Types:   map[string]string{"decimal": "github.com/shopspring/decimal.Decimal", "json": "string"},
+		Columns: map[string]string{"user.balance": "int64"},
+	}.Merge(*mapping)
+	assert.Equal(t, map[string]string{"unsigned": "uint64", "decimal": "float64", "json": "string"}, merged.Types)
+	assert.Equal(t, map[string]string{"user.balance": "int64"}, merged.Columns)
+
+	assert.Nil(t, ioutil.WriteFile(file, []byte("type:\n  unsigned: uint64\n"), os.ModePerm))
+	_, err = LoadTypeMapping(file)
+	assert.NotNil(t, err, "unknown fields are rejected")
+}
+
+func TestProjectApply(t *testing.T) {
+	project := &ProjectConfig{
+		Style: "goZero",
+		Home:  "templates",
+		Model: ModelConfig{Dir: "model", Cache: true},
+		Kube:  KubeConfig{Namespace: "prod", Replicas: 5},
+		dir:   "/project",
+	}
+	commands := []cli.Command{
+		{
+			Name: "model",
+			Subcommands: []cli.Command{
+				{
+					Name: "mongo",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "dir, d"},
+						cli.StringFlag{Name: "style"},
+						cli.BoolFlag{Name: "cache, c"},
+					},
+				},
+			},
+		},
+		{
+			Name: "kube",
+			Subcommands: []cli.Command{
+				{
+					Name: "deploy",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "namespace", Required: true},
+						cli.IntFlag{Name: "replicas", Value: 3},
+						cli.StringFlag{Name: "home"},
+						cli.StringFlag{Name: "dir"},
+					},
+				},
+			},
+		},
+	}
+	project.Apply(commands)
+
+	mongo := commands[0].Subcommands[0]
+	set := flag.NewFlagSet("mongo", flag.ContinueOnError)
+	for _, each := range mongo.Flags {
+		each.Apply(set)
+	}
+	assert.Nil(t, set.Parse([]string{"-style", "go_zero"}))
+	c := cli.NewContext(nil, set, nil)
+	assert.Equal(t, "/project/model", c.String("dir"))
+	assert.Equal(t, "go_zero", c.String("style"), "the flag overrides goctl.yaml")
+	assert.True(t, c.Bool("cache"))
+
+	assert.Nil(t, set.Parse([]string{"-cache=false"}))
+	assert.False(t, c.Bool("cache"))
+
+	deploy := commands[1].Subcommands[0]
+	assert.Equal(t, cli.StringFlag{Name: "namespace", Value: "prod"}, deploy.Flags[0])
+	assert.Equal(t, cli.IntFlag{Name: "replicas", Value: 5}, deploy.Flags[1])
+	assert.Equal(t, cli.StringFlag{Name: "home", Value: "/project/templates"}, deploy.Flags[2])
+	assert.Equal(t, cli.StringFlag{Name: "dir"}, deploy.Flags[3], "dir of model is not applied to kube")
+
+	var empty *ProjectConfig
+	empty.Apply(commands)
+}
@@ -1,7 +1,10 @@
 package command
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -9,13 +12,16 @@ import (
 	"github.com/urfave/cli"
 	"github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/model/sql/gen"
+	"github.com/weitrue/goctl/model/sql/migrate"
 	"github.com/weitrue/goctl/model/sql/model"
+	"github.com/weitrue/goctl/model/sql/parser"
 	"github.com/weitrue/goctl/model/sql/util"
 	file "github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/console"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/postgres"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	_ "modernc.org/sqlite"
 )
 
 const (
@@ -29,6 +35,14 @@ const (
 	flagDatabase = "database"
 	flagSchema   = "schema"
 	flagHome     = "home"
+	flagCount    = "count"
+	flagTypes    = "types"
+	flagFrom     = "from"
+	flagTo       = "to"
+	flagOutput   = "output"
+	flagDown     = "down"
+	flagApi      = "api"
+	flagProto    = "proto"
 )
 
 var errNotMatched = errors.New("sql not matched")
@@ -51,7 +65,19 @@ func MysqlDDL(ctx *cli.Context) error {
 		return err
 	}
 
-	return fromDDL(src, dir, cfg, cache, idea, database)
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if api := strings.TrimSpace(ctx.String(flagApi)); len(api) > 0 {
+		opts = append(opts, gen.WithApiOutput(api))
+	}
+	if proto := strings.TrimSpace(ctx.String(flagProto)); len(proto) > 0 {
+		opts = append(opts, gen.WithProtoOutput(proto))
+	}
+
+	return fromDDL(src, dir, cfg, cache, idea, database, opts...)
 }
 
 // MySqlDataSource generates model code from datasource
@@ -73,7 +99,54 @@ func MySqlDataSource(ctx *cli.Context) error {
 		return err
 	}
 
-	return fromMysqlDataSource(url, pattern, dir, cfg, cache, idea)
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fromMysqlDataSource(url, pattern, dir, cfg, cache, idea, opts...)
+}
+
+// MysqlMigrate generates the up and down migration scripts between two versions of ddl
+func MysqlMigrate(ctx *cli.Context) error {
+	from := ctx.String(flagFrom)
+	to := ctx.String(flagTo)
+	output := strings.TrimSpace(ctx.String(flagOutput))
+	down := strings.TrimSpace(ctx.String(flagDown))
+	idea := ctx.Bool(flagIdea)
+
+	return migrateDDL(from, to, output, down, idea)
+}
+
+// PostgreSqlDDL generates model code from the ddl of postgresql
+func PostgreSqlDDL(ctx *cli.Context) error {
+	src := ctx.String(flagSrc)
+	dir := ctx.String(flagDir)
+	cache := ctx.Bool(flagCache)
+	idea := ctx.Bool(flagIdea)
+	style := ctx.String(flagStyle)
+	schema := ctx.String(flagSchema)
+	home := ctx.String(flagHome)
+
+	if len(home) > 0 {
+		file.RegisterGoctlHome(home)
+	}
+
+	if len(schema) == 0 {
+		schema = "public"
+	}
+
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fromDDL(src, dir, cfg, cache, idea, schema, append(opts, gen.WithPostgreSql())...)
 }
 
 // PostgreSqlDataSource generates model code from datasource
@@ -100,10 +173,104 @@ func PostgreSqlDataSource(ctx *cli.Context) error {
 		return err
 	}
 
-	return fromPostgreSqlDataSource(url, pattern, dir, schema, cfg, cache, idea)
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fromPostgreSqlDataSource(url, pattern, dir, schema, cfg, cache, idea, opts...)
 }
 
-func fromDDL(src, dir string, cfg *config.Config, cache, idea bool, database string) error {
+// SqliteDDL generates model code from the ddl of sqlite
+func SqliteDDL(ctx *cli.Context) error {
+	src := ctx.String(flagSrc)
+	dir := ctx.String(flagDir)
+	cache := ctx.Bool(flagCache)
+	idea := ctx.Bool(flagIdea)
+	style := ctx.String(flagStyle)
+	database := ctx.String(flagDatabase)
+	home := ctx.String(flagHome)
+
+	if len(home) > 0 {
+		file.RegisterGoctlHome(home)
+	}
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fromSqliteDDL(src, dir, cfg, cache, idea, database, opts...)
+}
+
+// SqliteDataSource generates model code from the database file of sqlite
+func SqliteDataSource(ctx *cli.Context) error {
+	url := strings.TrimSpace(ctx.String(flagURL))
+	dir := strings.TrimSpace(ctx.String(flagDir))
+	cache := ctx.Bool(flagCache)
+	idea := ctx.Bool(flagIdea)
+	style := ctx.String(flagStyle)
+	home := ctx.String(flagHome)
+
+	if len(home) > 0 {
+		file.RegisterGoctlHome(home)
+	}
+
+	pattern := strings.TrimSpace(ctx.String(flagTable))
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	opts, err := genOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fromSqliteDataSource(url, pattern, dir, cfg, cache, idea, opts...)
+}
+
+// genOptions returns the generator options of the optional methods and the type mapping, the one
+// of --types overrides the one of goctl.yaml by entries
+func genOptions(ctx *cli.Context) ([]gen.Option, error) {
+	var opts []gen.Option
+	if ctx.Bool(flagCount) {
+		opts = append(opts, gen.WithCount())
+	}
+
+	var mapping config.TypeMapping
+	project, err := config.LoadProject(".")
+	if err != nil {
+		return nil, err
+	}
+	if project != nil {
+		mapping = project.Model.TypeMapping
+		if len(project.Model.SoftDeleteColumns) > 0 {
+			opts = append(opts, gen.WithSoftDeleteColumns(project.Model.SoftDeleteColumns...))
+		}
+		if len(project.Model.VersionColumns) > 0 {
+			opts = append(opts, gen.WithVersionColumns(project.Model.VersionColumns...))
+		}
+	}
+
+	if types := ctx.String(flagTypes); len(types) > 0 {
+		m, err := config.LoadTypeMapping(types)
+		if err != nil {
+			return nil, err
+		}
+
+		mapping = mapping.Merge(*m)
+	}
+	opts = append(opts, gen.WithTypeMapping(&mapping))
+
+	return opts, nil
+}
+
+func fromDDL(src, dir string, cfg *config.Config, cache, idea bool, database string, opts ...gen.Option) error {
 	log := console.NewConsole(idea)
 	src = strings.TrimSpace(src)
 	if len(src) == 0 {
@@ -119,7 +286,7 @@ func fromDDL(src, dir string, cfg *config.Config, cache, idea bool, database str
 		return errNotMatched
 	}
 
-	generator, err := gen.NewDefaultGenerator(dir, cfg, gen.WithConsoleOption(log))
+	generator, err := gen.NewDefaultGenerator(dir, cfg, append([]gen.Option{gen.WithConsoleOption(log)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -131,10 +298,10 @@ func fromDDL(src, dir string, cfg *config.Config, cache, idea bool, database str
 		}
 	}
 
-	return nil
+	return generator.GenDefinitions()
 }
 
-func fromMysqlDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bool) error {
+func fromMysqlDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bool, opts ...gen.Option) error {
 	log := console.NewConsole(idea)
 	if len(url) == 0 {
 		log.Error("%v", "expected data source of mysql, but nothing found")
@@ -189,7 +356,7 @@ func fromMysqlDataSource(url, pattern, dir string, cfg *config.Config, cache, id
 		return errors.New("no tables matched")
 	}
 
-	generator, err := gen.NewDefaultGenerator(dir, cfg, gen.WithConsoleOption(log))
+	generator, err := gen.NewDefaultGenerator(dir, cfg, append([]gen.Option{gen.WithConsoleOption(log)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -197,7 +364,7 @@ func fromMysqlDataSource(url, pattern, dir string, cfg *config.Config, cache, id
 	return generator.StartFromInformationSchema(matchTables, cache)
 }
 
-func fromPostgreSqlDataSource(url, pattern, dir, schema string, cfg *config.Config, cache, idea bool) error {
+func fromPostgreSqlDataSource(url, pattern, dir, schema string, cfg *config.Config, cache, idea bool, opts ...gen.Option) error {
 	log := console.NewConsole(idea)
 	if len(url) == 0 {
 		log.Error("%v", "expected data source of postgresql, but nothing found")
@@ -244,10 +411,247 @@ func fromPostgreSqlDataSource(url, pattern, dir, schema string, cfg *config.Conf
 		return errors.New("no tables matched")
 	}
 
-	generator, err := gen.NewDefaultGenerator(dir, cfg, gen.WithConsoleOption(log), gen.WithPostgreSql())
+	generator, err := gen.NewDefaultGenerator(dir, cfg, append([]gen.Option{gen.WithConsoleOption(log), gen.WithPostgreSql()}, opts...)...)
 	if err != nil {
 		return err
 	}
 
 	return generator.StartFromInformationSchema(matchTables, cache)
 }
+
+func fromSqliteDDL(src, dir string, cfg *config.Config, cache, idea bool, database string, opts ...gen.Option) error {
+	log := console.NewConsole(idea)
+	src = strings.TrimSpace(src)
+	if len(src) == 0 {
+		return errors.New("expected path or path globbing patterns, but nothing found")
+	}
+
+	files, err := util.MatchFiles(src)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return errNotMatched
+	}
+
+	generator, err := gen.NewDefaultGenerator(dir, cfg, append([]gen.Option{gen.WithConsoleOption(log),
+		gen.WithDialect(gen.Sqlite)}, opts...)...)
+	if err != nil {
+		return err
+	}
+
+	logx.Disable()
+	for _, file := range files {
+		tables, err := loadSqliteDDL(file, database)
+		if err != nil {
+			return err
+		}
+
+		err = generator.StartFromInformationSchema(tables, cache)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadSqliteDDL executes the ddl in an in-memory database of sqlite, and reads the tables back
+func loadSqliteDDL(filename, database string) (map[string]*model.Table, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// every connection opens its own in-memory database
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec(string(content)); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	tables, err := findSqliteTables(sqlx.NewSqlConnFromDB(db), database, "*")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%s: no tables found", filename)
+	}
+
+	return tables, nil
+}
+
+func fromSqliteDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bool, opts ...gen.Option) error {
+	log := console.NewConsole(idea)
+	if len(url) == 0 {
+		log.Error("%v", "expected data source of sqlite, but nothing found")
+		return nil
+	}
+
+	if len(pattern) == 0 {
+		log.Error("%v", "expected table or table globbing patterns, but nothing found")
+		return nil
+	}
+
+	// sqlite creates the missing database files unless they are opened in read only mode
+	if !file.FileExists(url) {
+		return fmt.Errorf("%s: no such database file", url)
+	}
+
+	logx.Disable()
+	db := sqlx.NewSqlConn("sqlite", fmt.Sprintf("file:%s?mode=ro", url))
+	database := strings.TrimSuffix(filepath.Base(url), filepath.Ext(url))
+	matchTables, err := findSqliteTables(db, database, pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(matchTables) == 0 {
+		return errors.New("no tables matched")
+	}
+
+	generator, err := gen.NewDefaultGenerator(dir, cfg, append([]gen.Option{gen.WithConsoleOption(log),
+		gen.WithDialect(gen.Sqlite)}, opts...)...)
+	if err != nil {
+		return err
+	}
+
+	return generator.StartFromInformationSchema(matchTables, cache)
+}
+
+func findSqliteTables(db sqlx.SqlConn, database, pattern string) (map[string]*model.Table, error) {
+	im := model.NewSqliteModel(db)
+	tables, err := im.GetAllTables()
+	if err != nil {
+		return nil, err
+	}
+
+	matchTables := make(map[string]*model.Table)
+	for _, item := range tables {
+		match, err := filepath.Match(pattern, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if !match {
+			continue
+		}
+
+		columnData, err := im.FindColumns(database, item)
+		if err != nil {
+			return nil, err
+		}
+
+		table, err := columnData.Convert()
+		if err != nil {
+			return nil, err
+		}
+
+		matchTables[item] = table
+	}
+
+	return matchTables, nil
+}
+
+func migrateDDL(from, to, output, down string, idea bool) error {
+	log := console.NewConsole(idea)
+	if len(output) == 0 {
+		return errors.New("expected the output file of up script, but nothing found")
+	}
+
+	fromTables, err := loadDDLTables(from)
+	if err != nil {
+		return err
+	}
+
+	toTables, err := loadDDLTables(to)
+	if err != nil {
+		return err
+	}
+
+	up := migrate.Diff(fromTables, toTables)
+	if len(up) == 0 {
+		log.Info("no changes between %s and %s", from, to)
+		return nil
+	}
+
+	if len(down) == 0 {
+		down = downFilename(output)
+	}
+
+	scripts := []struct {
+		filename   string
+		statements []migrate.Statement
+	}{
+		{filename: output, statements: up},
+		{filename: down, statements: migrate.Diff(toTables, fromTables)},
+	}
+	for _, script := range scripts {
+		for _, each := range script.statements {
+			if each.Destructive {
+				log.Warning("%s: %s", script.filename, each.Reason)
+			}
+		}
+
+		err = file.MkdirIfNotExist(filepath.Dir(script.filename))
+		if err != nil {
+			return err
+		}
+
+		err = file.WriteFile(script.filename, []byte(migrate.Script(script.statements)), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Success("Done.")
+	return nil
+}
+
+// loadDDLTables parses the tables of the ddl files matched by src
+func loadDDLTables(src string) ([]*parser.Table, error) {
+	src = strings.TrimSpace(src)
+	if len(src) == 0 {
+		return nil, errors.New("expected path or path globbing patterns, but nothing found")
+	}
+
+	files, err := util.MatchFiles(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, errNotMatched
+	}
+
+	var list []*parser.Table
+	for _, each := range files {
+		tables, err := parser.Parse(each, "")
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, tables...)
+	}
+
+	return list, nil
+}
+
+// downFilename returns the filename of down script for the up script, such as 002_down.sql for 002_up.sql
+// and 002.down.sql for 002.sql
+func downFilename(up string) string {
+	ext := filepath.Ext(up)
+	name := strings.TrimSuffix(up, ext)
+	if strings.HasSuffix(strings.ToLower(name), "up") {
+		return name[:len(name)-2] + "down" + ext
+	}
+
+	return name + ".down" + ext
+}
@@ -4,17 +4,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/model/sql/gen"
 	"github.com/weitrue/goctl/util"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 var (
-	sql = "-- 用户表 --\nCREATE TABLE `user` (\n  `id` bigint(10) NOT NULL AUTO_INCREMENT,\n  `name` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户名称',\n  `password` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户密码',\n  `mobile` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '手机号',\n  `gender` char(5) COLLATE utf8mb4_general_ci NOT NULL COMMENT '男｜女｜未公开',\n  `nickname` varchar(255) COLLATE utf8mb4_general_ci DEFAULT '' COMMENT '用户昵称',\n  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n  `update_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n  PRIMARY KEY (`id`),\n  UNIQUE KEY `name_index` (`name`),\n  UNIQUE KEY `mobile_index` (`mobile`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;\n\n"
-	cfg = &config.Config{
+	userSql = "-- 用户表 --\nCREATE TABLE `user` (\n  `id` bigint(10) NOT NULL AUTO_INCREMENT,\n  `name` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户名称',\n  `password` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户密码',\n  `mobile` varchar(255) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '手机号',\n  `gender` char(5) COLLATE utf8mb4_general_ci NOT NULL COMMENT '男｜女｜未公开',\n  `nickname` varchar(255) COLLATE utf8mb4_general_ci DEFAULT '' COMMENT '用户昵称',\n  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n  `update_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n  PRIMARY KEY (`id`),\n  UNIQUE KEY `name_index` (`name`),\n  UNIQUE KEY `mobile_index` (`mobile`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;\n\n"
+	cfg     = &config.Config{
 		NamingFormat: "gozero",
 	}
 )
@@ -53,12 +55,12 @@ func TestFromDDl(t *testing.T) {
 	user1Sql := filepath.Join(tempDir, "user1.sql")
 	user2Sql := filepath.Join(tempDir, "user2.sql")
 
-	err = ioutil.WriteFile(user1Sql, []byte(sql), os.ModePerm)
+	err = ioutil.WriteFile(user1Sql, []byte(userSql), os.ModePerm)
 	if err != nil {
 		return
 	}
 
-	err = ioutil.WriteFile(user2Sql, []byte(sql), os.ModePerm)
+	err = ioutil.WriteFile(user2Sql, []byte(userSql), os.ModePerm)
 	if err != nil {
 		return
 	}
@@ -84,3 +86,120 @@ func TestFromDDl(t *testing.T) {
 	_ = os.Remove(filename)
 	fromDDL("1gozero")
 }
+
+func TestFromSqlite(t *testing.T) {
+	err := gen.Clean()
+	assert.Nil(t, err)
+
+	ddl := "CREATE TABLE user (\n  id INTEGER PRIMARY KEY AUTOINCREMENT,\n  email VARCHAR(255) NOT NULL UNIQUE,\n" +
+		"  name TEXT NOT NULL DEFAULT '',\n  score REAL,\n  avatar BLOB,\n  create_time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP\n);\n" +
+		"CREATE INDEX user_name_index ON user (name);\n" +
+		"CREATE TABLE tag (\n  tenant_id INTEGER NOT NULL,\n  id INTEGER NOT NULL,\n  name TEXT NOT NULL,\n" +
+		"  PRIMARY KEY (tenant_id, id)\n);\n"
+	dir := t.TempDir()
+	ddlFile := filepath.Join(dir, "blog.sql")
+	err = ioutil.WriteFile(ddlFile, []byte(ddl), os.ModePerm)
+	assert.Nil(t, err)
+
+	tables, err := loadSqliteDDL(ddlFile, "blog")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tables))
+	user := tables["user"]
+	assert.Equal(t, "id", user.PrimaryKey.Name)
+	assert.Equal(t, "auto_increment", user.PrimaryKey.Extra)
+	assert.Equal(t, 1, len(user.UniqueIndex))
+	assert.Equal(t, "name", user.NormalIndex["user_name_index"][0].Name)
+	var types []string
+	for _, column := range user.Columns {
+		types = append(types, column.DataType)
+	}
+	assert.Equal(t, []string{"integer", "varchar", "text", "double", "blob", "datetime"}, types)
+	assert.Equal(t, 2, len(tables["tag"].PrimaryKeys))
+	assert.Equal(t, "", tables["tag"].PrimaryKey.Extra)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "bad.sql"), []byte("CREATE TABLE user (id bigint) ENGINE=InnoDB;"), os.ModePerm)
+	assert.Nil(t, err)
+	_, err = loadSqliteDDL(filepath.Join(dir, "bad.sql"), "blog")
+	assert.NotNil(t, err)
+
+	modelDir := filepath.Join(dir, "ddl")
+	err = fromSqliteDDL(ddlFile, modelDir, cfg, false, false, "blog")
+	assert.Nil(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(modelDir, "usermodel.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "on conflict (`email`) do update set `name` = excluded.`name`")
+	assert.Contains(t, string(data), "where `name` = ? order by `id` limit ? offset ?")
+
+	dbFile := filepath.Join(dir, "blog.db")
+	err = fromSqliteDataSource(dbFile, "*", filepath.Join(dir, "missing"), cfg, false, false)
+	assert.NotNil(t, err)
+	_, err = os.Stat(dbFile)
+	assert.True(t, os.IsNotExist(err))
+
+	conn := sqlx.NewSqlConn("sqlite", dbFile)
+	_, err = conn.Exec(ddl)
+	assert.Nil(t, err)
+
+	modelDir = filepath.Join(dir, "datasource")
+	err = fromSqliteDataSource(dbFile, "t*", modelDir, cfg, true, false)
+	assert.Nil(t, err)
+	data, err = ioutil.ReadFile(filepath.Join(modelDir, "tagmodel.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `cacheBlogTagTenantIdIdPrefix = "cache:blog:tag:tenantId:id:"`)
+	_, err = os.Stat(filepath.Join(modelDir, "usermodel.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFromPostgreSqlDDL(t *testing.T) {
+	err := gen.Clean()
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	ddlFile := filepath.Join(dir, "schema.sql")
+	err = ioutil.WriteFile(ddlFile, []byte("CREATE TABLE users (\n  id bigserial PRIMARY KEY,\n  uid uuid NOT NULL UNIQUE,\n"+
+		"  tags text[],\n  profile jsonb,\n  create_time timestamptz NOT NULL DEFAULT now()\n);\n"+
+		"CREATE INDEX users_create_time_idx ON users (create_time);\n"), os.ModePerm)
+	assert.Nil(t, err)
+
+	modelDir := filepath.Join(dir, "model")
+	err = fromDDL(ddlFile, modelDir, cfg, false, false, "public", gen.WithPostgreSql())
+	assert.Nil(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(modelDir, "usersmodel.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "table: `\"public\".\"users\"`")
+	assert.Contains(t, string(data), "where uid = $1 limit 1")
+	assert.Contains(t, string(data), "FindListByCreateTime(createTime time.Time, limit, offset int64)")
+}
+
+func TestMigrateDDL(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "old.sql")
+	to := filepath.Join(dir, "new.sql")
+	err := ioutil.WriteFile(from, []byte(userSql), os.ModePerm)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(to, []byte(strings.Replace(userSql, "  `nickname`", "  `avatar` varchar(255) NOT NULL DEFAULT '',\n  `nickname`", 1)), os.ModePerm)
+	assert.Nil(t, err)
+
+	err = migrateDDL(from, to, "", "", false)
+	assert.NotNil(t, err)
+
+	up := filepath.Join(dir, "migrations", "002_up.sql")
+	err = migrateDDL(from, from, up, "", false)
+	assert.Nil(t, err)
+	_, err = os.Stat(up)
+	assert.True(t, os.IsNotExist(err))
+
+	err = migrateDDL(from, to, up, "", false)
+	assert.Nil(t, err)
+	data, err := ioutil.ReadFile(up)
+	assert.Nil(t, err)
+	assert.Equal(t, "ALTER TABLE `user` ADD COLUMN `avatar` varchar(255) NOT NULL DEFAULT '' AFTER `gender`;\n\n", string(data))
+	data, err = ioutil.ReadFile(filepath.Join(dir, "migrations", "002_down.sql"))
+	assert.Nil(t, err)
+	assert.Equal(t, "-- DESTRUCTIVE: drop column `user`.`avatar`, its values will be lost\n"+
+		"ALTER TABLE `user` DROP COLUMN `avatar`;\n\n", string(data))
+
+	assert.Equal(t, "002_down.sql", downFilename("002_up.sql"))
+	assert.Equal(t, "migrations/002.down.sql", downFilename("migrations/002.UP.sql"))
+	assert.Equal(t, "002.down.sql", downFilename("002.sql"))
+}
@@ -0,0 +1,262 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/weitrue/goctl/config"
+)
+
+var (
+	spaceRegex      = regexp.MustCompile(`\s+`)
+	parenSpaceRegex = regexp.MustCompile(`\s*([(),])\s*`)
+
+	integerTypes = map[string]bool{
+		"tinyint":   true,
+		"smallint":  true,
+		"mediumint": true,
+		"middleint": true,
+		"int":       true,
+		"int1":      true,
+		"int2":      true,
+		"int3":      true,
+		"int4":      true,
+		"int8":      true,
+		"integer":   true,
+		"bigint":    true,
+	}
+
+	// shortPackages resolves the standard packages which are written without the parent path,
+	// such as sql.NullString
+	shortPackages = map[string]string{
+		"sql":    "database/sql",
+		"driver": "database/sql/driver",
+		"json":   "encoding/json",
+		"big":    "math/big",
+		"url":    "net/url",
+		"net":    "net",
+		"time":   "time",
+	}
+
+	// nullTypes are the null types of the custom types which can't hold NULL, the null types
+	// of builtin types are converted by mayConvertNullType
+	nullTypes = map[string]string{
+		"decimal.Decimal": "decimal.NullDecimal",
+	}
+)
+
+type (
+	// TypeConverter converts the column types into golang types, the custom types of the mapping
+	// take precedence over the builtin ones
+	TypeConverter struct {
+		types   map[string]string
+		columns map[string]string
+		imports map[string]string
+		// errs are the errors of the invalid custom types, they are reported if the types are used
+		errs map[string]error
+	}
+
+	// Column describes the column whose type is converted
+	Column struct {
+		// Table is the name of table
+		Table string
+		// Name is the name of column
+		Name string
+		// Type is the full column type such as tinyint(1) unsigned, the custom types are matched by it
+		Type string
+	}
+)
+
+// NewTypeConverter creates an instance for TypeConverter, mapping can be nil
+func NewTypeConverter(mapping *config.TypeMapping) *TypeConverter {
+	c := &TypeConverter{
+		types:   make(map[string]string),
+		columns: make(map[string]string),
+		imports: make(map[string]string),
+		errs:    make(map[string]error),
+	}
+	if mapping == nil {
+		return c
+	}
+
+	for k, v := range mapping.Types {
+		c.types[normalizeType(k)] = c.addGoType(v)
+	}
+	for k, v := range mapping.Columns {
+		c.columns[strings.ToLower(strings.TrimSpace(k))] = c.addGoType(v)
+	}
+
+	return c
+}
+
+// ConvertDataType converts mysql column type into golang type
+func (c *TypeConverter) ConvertDataType(column Column, dataBaseType int, isDefaultNull bool) (string, error) {
+	if tp, ok, err := c.custom(column, isDefaultNull); ok || err != nil {
+		return tp, err
+	}
+
+	return ConvertDataType(dataBaseType, isDefaultNull)
+}
+
+// ConvertStringDataType converts mysql column type into golang type
+func (c *TypeConverter) ConvertStringDataType(column Column, dataBaseType string, isDefaultNull bool) (string, error) {
+	if tp, ok, err := c.custom(column, isDefaultNull); ok || err != nil {
+		return tp, err
+	}
+
+	return ConvertStringDataType(dataBaseType, isDefaultNull)
+}
+
+// Import returns the import path of the custom golang type, it returns empty for the builtin ones
+func (c *TypeConverter) Import(goType string) string {
+	if c == nil {
+		return ""
+	}
+
+	return c.imports[goType]
+}
+
+// custom looks up the column at first, then the types from the most specific one, the custom types
+// of columns are used as is, while the others are converted into the null types if needed. It returns
+// an error if the nullable column is mapped to a type which can't hold NULL.
+func (c *TypeConverter) custom(column Column, isDefaultNull bool) (string, bool, error) {
+	if c == nil {
+		return "", false, nil
+	}
+
+	key := strings.ToLower(column.Table + "." + column.Name)
+	tp, ok := c.columns[key]
+	if !ok {
+		for _, each := range typeKeys(column.Type) {
+			if tp, ok = c.types[each]; ok {
+				tp = c.nullType(tp, isDefaultNull)
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	if err, ok := c.errs[tp]; ok {
+		return "", false, err
+	}
+	if isDefaultNull && !isNullable(tp) {
+		return "", false, fmt.Errorf("column %s.%s is nullable, but the custom type %s can't hold NULL, "+
+			"map it to a null type such as sql.NullInt64 or a pointer", column.Table, column.Name, tp)
+	}
+
+	return tp, true, nil
+}
+
+// nullType converts the custom type into the null type if the column is nullable
+func (c *TypeConverter) nullType(tp string, isDefaultNull bool) string {
+	if !isDefaultNull {
+		return tp
+	}
+
+	if null, ok := nullTypes[tp]; ok {
+		if path, ok := c.imports[tp]; ok {
+			c.imports[null] = path
+		}
+		return null
+	}
+
+	return mayConvertNullType(tp, isDefaultNull)
+}
+
+func (c *TypeConverter) addGoType(v string) string {
+	tp, path, err := splitGoType(strings.TrimSpace(v))
+	if err != nil {
+		c.errs[tp] = err
+	} else if len(path) > 0 {
+		c.imports[tp] = path
+	}
+
+	return tp
+}
+
+// isNullable returns true if the golang type can hold NULL, such as the pointers, slices and null types
+func isNullable(tp string) bool {
+	if strings.HasPrefix(tp, "*") || strings.HasPrefix(tp, "[]") || strings.HasPrefix(tp, "map[") ||
+		tp == "interface{}" || tp == "json.RawMessage" {
+		return true
+	}
+
+	index := strings.LastIndex(tp, ".")
+	return index >= 0 && (strings.HasPrefix(tp[index+1:], "Null") || tp[:index] == "null")
+}
+
+// splitGoType splits the golang type like github.com/shopspring/decimal.Decimal into the type
+// decimal.Decimal and the import path github.com/shopspring/decimal, the single element paths
+// like sql.NullString are resolved as the standard packages
+func splitGoType(v string) (string, string, error) {
+	prefix := strings.TrimLeft(v, "*[]")
+	index := strings.LastIndex(prefix, ".")
+	if index < 0 {
+		return v, "", nil
+	}
+
+	path, name := prefix[:index], prefix[index+1:]
+	pkg := path[strings.LastIndex(path, "/")+1:]
+	tp := v[:len(v)-len(prefix)] + pkg + "." + name
+	if !strings.Contains(path, "/") {
+		full, ok := shortPackages[path]
+		if !ok {
+			return tp, "", fmt.Errorf("unknown package %s of type %s, write it with the import path "+
+				"such as github.com/shopspring/decimal.Decimal", path, v)
+		}
+		path = full
+	}
+
+	return tp, path, nil
+}
+
+// typeKeys returns the keys of types to look up in order, such as tinyint(1) unsigned,
+// tinyint unsigned, unsigned, tinyint(1) and tinyint for tinyint(1) unsigned zerofill
+func typeKeys(columnType string) []string {
+	tp := normalizeType(columnType)
+	fields := strings.Fields(tp)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	withLength := fields[0]
+	base := withLength
+	if index := strings.Index(base, "("); index >= 0 {
+		base = base[:index]
+	}
+
+	var keys []string
+	add := func(key string) {
+		for _, each := range keys {
+			if each == key {
+				return
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	add(tp)
+	if strings.Contains(tp, " unsigned") {
+		add(withLength + " unsigned")
+		add(base + " unsigned")
+		if integerTypes[base] {
+			add("unsigned")
+		}
+	}
+	add(withLength)
+	add(base)
+
+	return keys
+}
+
+func normalizeType(tp string) string {
+	tp = strings.ToLower(strings.TrimSpace(tp))
+	tp = strings.ReplaceAll(tp, "zerofill", "")
+	tp = spaceRegex.ReplaceAllString(tp, " ")
+	tp = parenSpaceRegex.ReplaceAllString(tp, "$1")
+	tp = strings.ReplaceAll(tp, ")", ") ")
+	return strings.TrimSpace(spaceRegex.ReplaceAllString(tp, " "))
+}
@@ -84,6 +84,7 @@ var commonMysqlDataTypeMap2 = map[string]string{
 	"longtext":   "string",
 	"enum":       "string",
 	"set":        "string",
+	"uuid":       "string",
 	"json":       "string",
 	"jsonb":      "string",
 	"blob":       "string",
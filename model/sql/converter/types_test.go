@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/config"
 	"github.com/zeromicro/ddl-parser/parser"
 )
 
@@ -24,3 +25,131 @@ func TestConvertDataType(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "sql.NullTime", v)
 }
+
+func TestTypeConverter(t *testing.T) {
+	c := NewTypeConverter(&config.TypeMapping{
+		Types: map[string]string{
+			"unsigned":           "uint64",
+			"TINYINT(1)":         "bool",
+			"decimal":            "github.com/shopspring/decimal.Decimal",
+			"decimal(20, 6)":     "float64",
+			"json":               "encoding/json.RawMessage",
+			"varchar":            "*string",
+			"double unsigned":    "float64",
+			"bigint(20) signed":  "int64",
+			"datetime":           "time.Time",
+			"mediumint unsigned": "uint32",
+		},
+		Columns: map[string]string{
+			"bill.Fee": "github.com/shopspring/decimal.NullDecimal",
+		},
+	})
+
+	cases := []struct {
+		column   Column
+		dataType string
+		null     bool
+		expected string
+	}{
+		{Column{Table: "bill", Name: "id", Type: "bigint(20) unsigned"}, "bigint", false, "uint64"},
+		{Column{Table: "bill", Name: "level", Type: "int(10) UNSIGNED ZEROFILL"}, "int", false, "uint64"},
+		{Column{Table: "bill", Name: "count", Type: "mediumint unsigned"}, "mediumint", false, "uint32"},
+		{Column{Table: "bill", Name: "enabled", Type: "tinyint(1)"}, "tinyint", false, "bool"},
+		{Column{Table: "bill", Name: "enabled", Type: "tinyint(1)"}, "tinyint", true, "sql.NullBool"},
+		{Column{Table: "bill", Name: "status", Type: "tinyint(4)"}, "tinyint", false, "int64"},
+		{Column{Table: "bill", Name: "amount", Type: "decimal(10,2)"}, "decimal", false, "decimal.Decimal"},
+		{Column{Table: "bill", Name: "rate", Type: "decimal( 20 ,6 )"}, "decimal", false, "float64"},
+		{Column{Table: "bill", Name: "fee", Type: "decimal(10,2)"}, "decimal", true, "decimal.NullDecimal"},
+		{Column{Table: "bill", Name: "extra", Type: "json"}, "json", true, "json.RawMessage"},
+		{Column{Table: "bill", Name: "name", Type: "varchar(255)"}, "varchar", false, "*string"},
+		{Column{Table: "bill", Name: "score", Type: "double unsigned"}, "double", false, "float64"},
+		{Column{Table: "bill", Name: "create_time", Type: ""}, "timestamp", false, "time.Time"},
+	}
+	for _, each := range cases {
+		v, err := c.ConvertStringDataType(each.column, each.dataType, each.null)
+		assert.Nil(t, err)
+		assert.Equal(t, each.expected, v, each.column.Name)
+	}
+
+	v, err := c.ConvertDataType(Column{Table: "bill", Name: "id", Type: "bigint unsigned"}, parser.BigInt, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "uint64", v)
+
+	assert.Equal(t, "github.com/shopspring/decimal", c.Import("decimal.Decimal"))
+	assert.Equal(t, "github.com/shopspring/decimal", c.Import("decimal.NullDecimal"))
+	assert.Equal(t, "encoding/json", c.Import("json.RawMessage"))
+	assert.Equal(t, "time", c.Import("time.Time"))
+	assert.Equal(t, "", c.Import("*string"))
+	assert.Equal(t, "", c.Import("uint64"))
+
+	c = NewTypeConverter(nil)
+	v, err = c.ConvertStringDataType(Column{Table: "bill", Name: "id", Type: "bigint unsigned"}, "bigint", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "int64", v)
+
+	_, err = c.ConvertStringDataType(Column{Table: "bill", Name: "geo", Type: "geometry"}, "geometry", false)
+	assert.NotNil(t, err)
+}
+
+func TestSplitGoType(t *testing.T) {
+	tp, path, err := splitGoType("github.com/shopspring/decimal.Decimal")
+	assert.Nil(t, err)
+	assert.Equal(t, "decimal.Decimal", tp)
+	assert.Equal(t, "github.com/shopspring/decimal", path)
+
+	tp, path, err = splitGoType("*encoding/json.RawMessage")
+	assert.Nil(t, err)
+	assert.Equal(t, "*json.RawMessage", tp)
+	assert.Equal(t, "encoding/json", path)
+
+	tp, path, err = splitGoType("uint64")
+	assert.Nil(t, err)
+	assert.Equal(t, "uint64", tp)
+	assert.Equal(t, "", path)
+
+	tp, path, err = splitGoType("sql.NullString")
+	assert.Nil(t, err)
+	assert.Equal(t, "sql.NullString", tp)
+	assert.Equal(t, "database/sql", path)
+
+	_, _, err = splitGoType("decimal.Decimal")
+	assert.NotNil(t, err, "the package of non-standard types is written with the import path")
+}
+
+func TestTypeConverterNullable(t *testing.T) {
+	c := NewTypeConverter(&config.TypeMapping{
+		Types: map[string]string{
+			"unsigned": "uint64",
+			"decimal":  "github.com/shopspring/decimal.Decimal",
+			"json":     "encoding/json.RawMessage",
+			"text":     "shopspring.Text",
+		},
+		Columns: map[string]string{
+			"user.name":  "sql.NullString",
+			"user.level": "int8",
+		},
+	})
+
+	v, err := c.ConvertStringDataType(Column{Table: "bill", Name: "fee", Type: "decimal(10,2)"}, "decimal", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "decimal.NullDecimal", v)
+	assert.Equal(t, "github.com/shopspring/decimal", c.Import("decimal.NullDecimal"))
+
+	v, err = c.ConvertStringDataType(Column{Table: "bill", Name: "extra", Type: "json"}, "json", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "json.RawMessage", v)
+
+	v, err = c.ConvertStringDataType(Column{Table: "user", Name: "name", Type: "varchar(255)"}, "varchar", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "sql.NullString", v)
+	assert.Equal(t, "database/sql", c.Import("sql.NullString"))
+
+	_, err = c.ConvertStringDataType(Column{Table: "bill", Name: "count", Type: "int unsigned"}, "int", true)
+	assert.NotNil(t, err, "uint64 can't hold NULL")
+
+	_, err = c.ConvertStringDataType(Column{Table: "user", Name: "level", Type: "tinyint"}, "tinyint", true)
+	assert.NotNil(t, err, "the custom type of column is used as is")
+
+	_, err = c.ConvertStringDataType(Column{Table: "user", Name: "bio", Type: "text"}, "text", false)
+	assert.NotNil(t, err, "the package of type is unknown")
+}
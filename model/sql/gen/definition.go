@@ -0,0 +1,293 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/weitrue/goctl/api/format"
+	"github.com/weitrue/goctl/model/sql/parser"
+	"github.com/weitrue/goctl/model/sql/template"
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/stringx"
+	"github.com/zeromicro/go-zero/core/collection"
+)
+
+type (
+	// definitionTable describes the CRUD messages of a table in api and proto
+	definitionTable struct {
+		// Name is the camel name of table, Table is the source name
+		Name  string
+		Table string
+		// Path is the route of table in api like user-info, Group is the handler group of table
+		Path  string
+		Group string
+		// PrimaryPath is the path parameters of primary key like /:id
+		PrimaryPath string
+		Fields      []definitionField
+		Create      []definitionField
+		Primary     []definitionField
+		Update      []definitionField
+		// PrimaryUpdate is the primary key followed by Update, which is numbered as a proto message
+		PrimaryUpdate []definitionField
+		Unique        []definitionUnique
+	}
+
+	// definitionUnique describes the query of a table by a unique index
+	definitionUnique struct {
+		// Name is the camel columns of index like ClassName, Columns are the source columns joined by comma
+		Name    string
+		Columns string
+		// Path is the route of index like class-name/:class/:name
+		Path   string
+		Fields []definitionField
+	}
+
+	definitionField struct {
+		Name      string
+		JsonName  string
+		ProtoName string
+		ApiType   string
+		ProtoType string
+		Comment   string
+		// Optional is true if the column is nullable
+		Optional bool
+		// Number is the field number in proto message
+		Number int
+	}
+)
+
+// WithApiOutput generates the CRUD api definitions of tables into filename
+func WithApiOutput(filename string) Option {
+	return func(generator *defaultGenerator) {
+		generator.apiOutput = filename
+	}
+}
+
+// WithProtoOutput generates the CRUD rpc definitions of tables into filename
+func WithProtoOutput(filename string) Option {
+	return func(generator *defaultGenerator) {
+		generator.protoOutput = filename
+	}
+}
+
+// GenDefinitions generates the api and proto definitions of the tables generated so far, it does nothing
+// without WithApiOutput or WithProtoOutput
+func (g *defaultGenerator) GenDefinitions() error {
+	if len(g.definitions) == 0 {
+		return nil
+	}
+
+	if len(g.apiOutput) > 0 {
+		if err := g.genApi(g.apiOutput); err != nil {
+			return err
+		}
+	}
+
+	if len(g.protoOutput) > 0 {
+		if err := g.genProto(g.protoOutput); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *defaultGenerator) definitionEnabled() bool {
+	return len(g.apiOutput) > 0 || len(g.protoOutput) > 0
+}
+
+func (g *defaultGenerator) genApi(filename string) error {
+	text, err := util.LoadTemplate(category, apiTemplateFile, template.Api)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, each := range g.definitions {
+		names = append(names, each.Table)
+	}
+
+	buffer, err := util.With("api").Parse(text).Execute(map[string]interface{}{
+		"service":    definitionBase(filename) + "-api",
+		"tableNames": strings.Join(names, ", "),
+		"tables":     g.definitions,
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := format.ApiFormatByContent(buffer.String())
+	if err != nil {
+		return err
+	}
+
+	// the blank lines between routes are indented by the formatter
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	return writeDefinition(filename, strings.Join(lines, "\n")+"\n")
+}
+
+func (g *defaultGenerator) genProto(filename string) error {
+	text, err := util.LoadTemplate(category, protoTemplateFile, template.Proto)
+	if err != nil {
+		return err
+	}
+
+	service := stringx.From(definitionBase(filename)).ToCamel()
+	buffer, err := util.With("proto").Parse(text).Execute(map[string]interface{}{
+		"package": strings.ToLower(service),
+		"service": service,
+		"tables":  g.definitions,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeDefinition(filename, buffer.String())
+}
+
+// definitionBase returns the file name without directory and extension, which names the service
+func definitionBase(filename string) string {
+	base := filepath.Base(filename)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func writeDefinition(filename, content string) error {
+	dir := filepath.Dir(filename)
+	if err := util.MkdirIfNotExist(dir); err != nil {
+		return err
+	}
+
+	return util.WriteFile(filename, []byte(content), os.ModePerm)
+}
+
+// genDefinition returns the CRUD messages of table, the auto increment column, the time columns and the soft
+// delete column are set by the model, so they are excluded from the requests
+func genDefinition(table Table) definitionTable {
+	excluded := collection.NewSet()
+	excluded.AddStr("create_time", "update_time")
+	if table.SoftDeleteField != nil {
+		excluded.AddStr(table.SoftDeleteField.Name.Source())
+	}
+
+	primary := table.PrimaryKey.Columns()
+	primarySet := collection.NewSet()
+	for _, field := range primary {
+		primarySet.AddStr(field.Name.Source())
+	}
+
+	var create, update []*parser.Field
+	for _, field := range table.Fields {
+		name := field.Name.Source()
+		if excluded.Contains(name) {
+			continue
+		}
+
+		if auto := table.PrimaryKey.AutoIncrementColumn(); auto != nil && auto.Name.Source() == name {
+			continue
+		}
+
+		if table.VersionField == nil || table.VersionField.Name.Source() != name {
+			create = append(create, field)
+		}
+		if !primarySet.Contains(name) {
+			update = append(update, field)
+		}
+	}
+
+	ret := definitionTable{
+		Name:          table.Name.ToCamel(),
+		Table:         table.Name.Source(),
+		Path:          kebab(table.Name.Source()),
+		Group:         strings.ToLower(table.Name.ToCamel()),
+		Fields:        definitionFields(table.Fields...),
+		Create:        definitionFields(create...),
+		Primary:       definitionFields(primary...),
+		Update:        definitionFields(update...),
+		PrimaryUpdate: definitionFields(append(append([]*parser.Field{}, primary...), update...)...),
+	}
+	for _, field := range ret.Primary {
+		ret.PrimaryPath += "/:" + field.JsonName
+	}
+
+	var indexes []string
+	for name := range table.UniqueIndex {
+		indexes = append(indexes, name)
+	}
+	sort.Strings(indexes)
+
+	for _, name := range indexes {
+		fields := table.UniqueIndex[name]
+		var names, columns, params []string
+		for _, field := range fields {
+			names = append(names, field.Name.ToCamel())
+			columns = append(columns, field.Name.Source())
+		}
+
+		unique := definitionUnique{
+			Name:    strings.Join(names, ""),
+			Columns: strings.Join(columns, ", "),
+			Fields:  definitionFields(fields...),
+		}
+		for _, field := range unique.Fields {
+			params = append(params, ":"+field.JsonName)
+		}
+		unique.Path = kebab(strings.Join(columns, "_")) + "/" + strings.Join(params, "/")
+		ret.Unique = append(ret.Unique, unique)
+	}
+
+	return ret
+}
+
+// definitionFields returns the fields numbered in order as a proto message
+func definitionFields(fields ...*parser.Field) []definitionField {
+	var list []definitionField
+	for i, field := range fields {
+		apiType, protoType, optional := definitionTypes(field.DataType)
+		list = append(list, definitionField{
+			Name:      field.Name.ToCamel(),
+			JsonName:  stringx.From(field.Name.ToCamel()).Untitle(),
+			ProtoName: field.Name.ToSnake(),
+			ApiType:   apiType,
+			ProtoType: protoType,
+			Comment:   strings.Join(strings.Fields(field.Comment), " "),
+			Optional:  optional,
+			Number:    i + 1,
+		})
+	}
+
+	return list
+}
+
+// definitionTypes returns the types of a go type in api and proto, the time is described as unix seconds,
+// the custom types are described as strings
+func definitionTypes(dataType string) (apiType, protoType string, optional bool) {
+	if strings.HasPrefix(dataType, "sql.Null") || strings.HasPrefix(dataType, "*") {
+		optional = true
+	}
+
+	switch strings.TrimPrefix(strings.TrimPrefix(dataType, "*"), "sql.Null") {
+	case "int", "int8", "int16", "int32", "int64", "Int16", "Int32", "Int64", "Byte", "time.Time", "Time":
+		return "int64", "int64", optional
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return "uint64", "uint64", optional
+	case "float32", "float64", "Float64":
+		return "float64", "double", optional
+	case "bool", "Bool":
+		return "bool", "bool", optional
+	case "[]byte":
+		return "string", "bytes", optional
+	default:
+		return "string", "string", optional
+	}
+}
+
+// kebab returns the snake name as a route like user_info to user-info
+func kebab(name string) string {
+	return strings.ReplaceAll(stringx.From(stringx.From(name).ToCamel()).ToSnake(), "_", "-")
+}
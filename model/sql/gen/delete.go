@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/weitrue/goctl/model/sql/template"
@@ -9,7 +10,7 @@ import (
 	"github.com/zeromicro/go-zero/core/collection"
 )
 
-func genDelete(table Table, withCache, postgreSql bool) (string, string, error) {
+func genDelete(table Table, withCache bool, dialect Dialect) (string, string, error) {
 	keySet := collection.NewSet()
 	keyVariableSet := collection.NewSet()
 	keySet.AddStr(table.PrimaryCacheKey.KeyExpression)
@@ -20,6 +21,20 @@ func genDelete(table Table, withCache, postgreSql bool) (string, string, error)
 	}
 
 	camel := table.Name.ToCamel()
+	in, paramJoinString, originalPrimaryKeyCondition := convertJoin(table.PrimaryCacheKey, dialect)
+	// the soft delete value is placed before the primary keys, and after them for the numbered placeholders
+	var softDeleteSet, softDeleteArgs string
+	if table.SoftDeleteField != nil {
+		column := dialect.Quote(table.SoftDeleteField.Name.Source())
+		if dialect.Numbered() {
+			softDeleteSet = fmt.Sprintf("%s = %s", column, dialect.Placeholder(len(table.PrimaryKey.Columns())+1))
+			softDeleteArgs = fmt.Sprintf("%s, %s", paramJoinString, table.softDeleteValue())
+		} else {
+			softDeleteSet = fmt.Sprintf("%s = ?", column)
+			softDeleteArgs = fmt.Sprintf("%s, %s", table.softDeleteValue(), paramJoinString)
+		}
+	}
+
 	text, err := util.LoadTemplate(category, deleteTemplateFile, template.Delete)
 	if err != nil {
 		return "", "", err
@@ -28,15 +43,23 @@ func genDelete(table Table, withCache, postgreSql bool) (string, string, error)
 	output, err := util.With("delete").
 		Parse(text).
 		Execute(map[string]interface{}{
-			"upperStartCamelObject":     camel,
-			"withCache":                 withCache,
-			"containsIndexCache":        table.ContainsUniqueCacheKey,
-			"lowerStartCamelPrimaryKey": stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
-			"dataType":                  table.PrimaryKey.DataType,
-			"keys":                      strings.Join(keySet.KeysStr(), "\n"),
-			"originalPrimaryKey":        wrapWithRawString(table.PrimaryKey.Name.Source(), postgreSql),
-			"keyValues":                 strings.Join(keyVariableSet.KeysStr(), ", "),
-			"postgreSql":                postgreSql,
+			"upperStartCamelObject":       camel,
+			"withCache":                   withCache,
+			"containsIndexCache":          table.ContainsUniqueCacheKey,
+			"lowerStartCamelPrimaryKey":   stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
+			"lowerStartCamelPrimaryKeys":  paramJoinString,
+			"primaryKeyIn":                in,
+			"dataType":                    table.PrimaryKey.DataType,
+			"keys":                        strings.Join(keySet.KeysStr(), "\n"),
+			"originalPrimaryKey":          dialect.Quote(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKeyCondition": originalPrimaryKeyCondition,
+			"notDeleted":                  table.notDeleted(dialect),
+			"softDelete":                  table.SoftDeleteField != nil,
+			"softDeleteSet":               softDeleteSet,
+			"softDeleteArgs":              softDeleteArgs,
+			"keyValues":                   strings.Join(keyVariableSet.KeysStr(), ", "),
+			"postgreSql":                  dialect == PostgreSql,
+			"dialect":                     dialect.Name(),
 		})
 	if err != nil {
 		return "", "", err
@@ -52,6 +75,7 @@ func genDelete(table Table, withCache, postgreSql bool) (string, string, error)
 		Parse(text).
 		Execute(map[string]interface{}{
 			"lowerStartCamelPrimaryKey": stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
+			"primaryKeyIn":              in,
 			"dataType":                  table.PrimaryKey.DataType,
 		})
 	if err != nil {
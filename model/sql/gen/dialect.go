@@ -0,0 +1,115 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/weitrue/goctl/model/sql/parser"
+)
+
+var (
+	// MySql is the dialect of mysql, which is the default one
+	MySql Dialect = mysqlDialect{}
+	// PostgreSql is the dialect of postgresql
+	PostgreSql Dialect = postgreSqlDialect{}
+	// Sqlite is the dialect of sqlite
+	Sqlite Dialect = sqliteDialect{}
+)
+
+type (
+	// Dialect describes the sql differences of the generated codes between databases
+	Dialect interface {
+		// Name returns the name of dialect, which is passed to the templates as dialect
+		Name() string
+		// Quote wraps the name of a table or a column
+		Quote(name string) string
+		// Placeholder returns the placeholder of the i-th argument of a statement, i starts from 1
+		Placeholder(i int) string
+		// Numbered reports whether the placeholders are numbered, otherwise the arguments are
+		// bound by the positions of placeholders
+		Numbered() bool
+		// TableName returns the go string literal of the table of model
+		TableName(table parser.Table) string
+		// ConflictClause returns the clause of upsert which updates the inserted columns on conflicts
+		ConflictClause(table Table) string
+	}
+
+	mysqlDialect      struct{}
+	postgreSqlDialect struct{}
+	sqliteDialect     struct{}
+)
+
+func (d mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (d mysqlDialect) Quote(name string) string {
+	return wrapWithRawString(name, false)
+}
+
+func (d mysqlDialect) Placeholder(int) string {
+	return "?"
+}
+
+func (d mysqlDialect) Numbered() bool {
+	return false
+}
+
+func (d mysqlDialect) TableName(table parser.Table) string {
+	return fmt.Sprintf(`"%s"`, d.Quote(table.Name.Source()))
+}
+
+// ConflictClause checks all the primary and unique keys, which needs no conflict target
+func (d mysqlDialect) ConflictClause(table Table) string {
+	return onDuplicateKeyClause(table, d)
+}
+
+func (d postgreSqlDialect) Name() string {
+	return "postgresql"
+}
+
+func (d postgreSqlDialect) Quote(name string) string {
+	return name
+}
+
+func (d postgreSqlDialect) Placeholder(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
+
+func (d postgreSqlDialect) Numbered() bool {
+	return true
+}
+
+// TableName qualifies the table with its schema
+func (d postgreSqlDialect) TableName(table parser.Table) string {
+	return "`" + fmt.Sprintf(`"%s"."%s"`, table.Db.Source(), table.Name.Source()) + "`"
+}
+
+func (d postgreSqlDialect) ConflictClause(table Table) string {
+	return onConflictClause(table, d)
+}
+
+func (d sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+// Quote wraps the name with backticks, which are accepted by sqlite for the compatibility with mysql
+func (d sqliteDialect) Quote(name string) string {
+	return wrapWithRawString(name, false)
+}
+
+func (d sqliteDialect) Placeholder(int) string {
+	return "?"
+}
+
+func (d sqliteDialect) Numbered() bool {
+	return false
+}
+
+func (d sqliteDialect) TableName(table parser.Table) string {
+	return fmt.Sprintf(`"%s"`, d.Quote(table.Name.Source()))
+}
+
+// ConflictClause uses the upsert syntax of sqlite 3.24.0 and later, which is the same as postgresql
+func (d sqliteDialect) ConflictClause(table Table) string {
+	return onConflictClause(table, d)
+}
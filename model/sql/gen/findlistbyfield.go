@@ -0,0 +1,111 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weitrue/goctl/model/sql/template"
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/stringx"
+	"github.com/zeromicro/go-zero/core/collection"
+)
+
+type findListCode struct {
+	findListMethod          string
+	findListInterfaceMethod string
+}
+
+func genFindListByField(table Table, withCache, withCount bool, dialect Dialect) (*findListCode, error) {
+	var keys []Key
+	nameSet := collection.NewSet()
+	for _, each := range table.NormalIndex {
+		key := genCacheKey(table.Db, table.Name, each)
+		name := key.FieldNameJoin.Camel().With("").Source()
+		if nameSet.Contains(name) {
+			continue
+		}
+
+		nameSet.AddStr(name)
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].VarLeft < keys[j].VarLeft
+	})
+
+	var orderBy []string
+	for _, field := range table.PrimaryKey.Columns() {
+		orderBy = append(orderBy, dialect.Quote(field.Name.Source()))
+	}
+
+	var methods, interfaceMethods []string
+	camelTableName := table.Name.ToCamel()
+	for _, key := range keys {
+		in, paramJoinString, originalFieldString := convertJoin(key, dialect)
+		limit := fmt.Sprintf("%s offset %s", dialect.Placeholder(len(key.Fields)+1),
+			dialect.Placeholder(len(key.Fields)+2))
+
+		data := map[string]interface{}{
+			"upperStartCamelObject": camelTableName,
+			"lowerStartCamelObject": stringx.From(camelTableName).Untitle(),
+			"upperField":            key.FieldNameJoin.Camel().With("").Source(),
+			"in":                    in,
+			"lowerStartCamelField":  paramJoinString,
+			"originalField":         originalFieldString,
+			"notDeleted":            table.notDeleted(dialect),
+			"orderBy":               strings.Join(orderBy, ", "),
+			"limit":                 limit,
+			"withCache":             withCache,
+			"postgreSql":            dialect == PostgreSql,
+			"dialect":               dialect.Name(),
+		}
+
+		method, err := executeTemplate(findListByFieldTemplateFile, template.FindListByField, data)
+		if err != nil {
+			return nil, err
+		}
+
+		interfaceMethod, err := executeTemplate(findListByFieldMethodTemplateFile, template.FindListByFieldMethod, data)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method)
+		interfaceMethods = append(interfaceMethods, interfaceMethod)
+		if !withCount {
+			continue
+		}
+
+		method, err = executeTemplate(countByFieldTemplateFile, template.CountByField, data)
+		if err != nil {
+			return nil, err
+		}
+
+		interfaceMethod, err = executeTemplate(countByFieldMethodTemplateFile, template.CountByFieldMethod, data)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method)
+		interfaceMethods = append(interfaceMethods, interfaceMethod)
+	}
+
+	return &findListCode{
+		findListMethod:          strings.Join(methods, util.NL),
+		findListInterfaceMethod: strings.Join(interfaceMethods, util.NL),
+	}, nil
+}
+
+func executeTemplate(name, builtin string, data map[string]interface{}) (string, error) {
+	text, err := util.LoadTemplate(category, name, builtin)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := util.With(strings.TrimSuffix(name, ".tpl")).Parse(text).Execute(data)
+	if err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
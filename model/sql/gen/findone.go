@@ -6,8 +6,9 @@ import (
 	"github.com/weitrue/goctl/util/stringx"
 )
 
-func genFindOne(table Table, withCache, postgreSql bool) (string, string, error) {
+func genFindOne(table Table, withCache bool, dialect Dialect) (string, string, error) {
 	camel := table.Name.ToCamel()
+	in, paramJoinString, originalPrimaryKeyCondition := convertJoin(table.PrimaryCacheKey, dialect)
 	text, err := util.LoadTemplate(category, findOneTemplateFile, template.FindOne)
 	if err != nil {
 		return "", "", err
@@ -16,15 +17,20 @@ func genFindOne(table Table, withCache, postgreSql bool) (string, string, error)
 	output, err := util.With("findOne").
 		Parse(text).
 		Execute(map[string]interface{}{
-			"withCache":                 withCache,
-			"upperStartCamelObject":     camel,
-			"lowerStartCamelObject":     stringx.From(camel).Untitle(),
-			"originalPrimaryKey":        wrapWithRawString(table.PrimaryKey.Name.Source(), postgreSql),
-			"lowerStartCamelPrimaryKey": stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
-			"dataType":                  table.PrimaryKey.DataType,
-			"cacheKey":                  table.PrimaryCacheKey.KeyExpression,
-			"cacheKeyVariable":          table.PrimaryCacheKey.KeyLeft,
-			"postgreSql":                postgreSql,
+			"withCache":                   withCache,
+			"upperStartCamelObject":       camel,
+			"lowerStartCamelObject":       stringx.From(camel).Untitle(),
+			"originalPrimaryKey":          dialect.Quote(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKeyCondition": originalPrimaryKeyCondition,
+			"notDeleted":                  table.notDeleted(dialect),
+			"lowerStartCamelPrimaryKey":   stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
+			"lowerStartCamelPrimaryKeys":  paramJoinString,
+			"primaryKeyIn":                in,
+			"dataType":                    table.PrimaryKey.DataType,
+			"cacheKey":                    table.PrimaryCacheKey.KeyExpression,
+			"cacheKeyVariable":            table.PrimaryCacheKey.KeyLeft,
+			"postgreSql":                  dialect == PostgreSql,
+			"dialect":                     dialect.Name(),
 		})
 	if err != nil {
 		return "", "", err
@@ -40,6 +46,7 @@ func genFindOne(table Table, withCache, postgreSql bool) (string, string, error)
 		Execute(map[string]interface{}{
 			"upperStartCamelObject":     camel,
 			"lowerStartCamelPrimaryKey": stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
+			"primaryKeyIn":              in,
 			"dataType":                  table.PrimaryKey.DataType,
 		})
 	if err != nil {
@@ -15,17 +15,31 @@ type findOneCode struct {
 	cacheExtra             string
 }
 
-func genFindOneByField(table Table, withCache, postgreSql bool) (*findOneCode, error) {
+func genFindOneByField(table Table, withCache bool, dialect Dialect) (*findOneCode, error) {
 	text, err := util.LoadTemplate(category, findOneByFieldTemplateFile, template.FindOneByField)
 	if err != nil {
 		return nil, err
 	}
 
+	primaryKeys := table.PrimaryKey.Columns()
+	var primaryValues, primaryFormats, primaryElements []string
+	for i, field := range primaryKeys {
+		primaryValues = append(primaryValues, "resp."+field.Name.ToCamel())
+		primaryFormats = append(primaryFormats, "%v")
+		primaryElements = append(primaryElements, fmt.Sprintf("keys[%d]", i))
+	}
+
+	// the composite primary key is passed as a slice between formatPrimary and queryPrimary
+	primaryKeyValue := primaryValues[0]
+	if table.PrimaryKey.IsComposite() {
+		primaryKeyValue = fmt.Sprintf("[]interface{}{%s}", strings.Join(primaryValues, ", "))
+	}
+
 	t := util.With("findOneByField").Parse(text)
 	var list []string
 	camelTableName := table.Name.ToCamel()
 	for _, key := range table.UniqueCacheKey {
-		in, paramJoinString, originalFieldString := convertJoin(key, postgreSql)
+		in, paramJoinString, originalFieldString := convertJoin(key, dialect)
 
 		output, err := t.Execute(map[string]interface{}{
 			"upperStartCamelObject":     camelTableName,
@@ -37,8 +51,11 @@ func genFindOneByField(table Table, withCache, postgreSql bool) (*findOneCode, e
 			"lowerStartCamelObject":     stringx.From(camelTableName).Untitle(),
 			"lowerStartCamelField":      paramJoinString,
 			"upperStartCamelPrimaryKey": table.PrimaryKey.Name.ToCamel(),
+			"primaryKeyValue":           primaryKeyValue,
 			"originalField":             originalFieldString,
-			"postgreSql":                postgreSql,
+			"notDeleted":                table.notDeleted(dialect),
+			"postgreSql":                dialect == PostgreSql,
+			"dialect":                   dialect.Name(),
 		})
 		if err != nil {
 			return nil, err
@@ -84,12 +101,19 @@ func genFindOneByField(table Table, withCache, postgreSql bool) (*findOneCode, e
 			return nil, err
 		}
 
+		_, _, originalPrimaryKeyCondition := convertJoin(table.PrimaryCacheKey, dialect)
 		out, err := util.With("findOneByFieldExtraMethod").Parse(text).Execute(map[string]interface{}{
-			"upperStartCamelObject": camelTableName,
-			"primaryKeyLeft":        table.PrimaryCacheKey.VarLeft,
-			"lowerStartCamelObject": stringx.From(camelTableName).Untitle(),
-			"originalPrimaryField":  wrapWithRawString(table.PrimaryKey.Name.Source(), postgreSql),
-			"postgreSql":            postgreSql,
+			"upperStartCamelObject":       camelTableName,
+			"primaryKeyLeft":              table.PrimaryCacheKey.VarLeft,
+			"lowerStartCamelObject":       stringx.From(camelTableName).Untitle(),
+			"originalPrimaryField":        dialect.Quote(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKeyCondition": originalPrimaryKeyCondition,
+			"notDeleted":                  table.notDeleted(dialect),
+			"compositePrimary":            table.PrimaryKey.IsComposite(),
+			"primaryKeyFormat":            strings.Join(primaryFormats, ":"),
+			"primaryKeyElements":          strings.Join(primaryElements, ", "),
+			"postgreSql":                  dialect == PostgreSql,
+			"dialect":                     dialect.Name(),
 		})
 		if err != nil {
 			return nil, err
@@ -108,17 +132,13 @@ func genFindOneByField(table Table, withCache, postgreSql bool) (*findOneCode, e
 	}, nil
 }
 
-func convertJoin(key Key, postgreSql bool) (in, paramJoinString, originalFieldString string) {
+func convertJoin(key Key, dialect Dialect) (in, paramJoinString, originalFieldString string) {
 	var inJoin, paramJoin, argJoin Join
 	for index, f := range key.Fields {
 		param := stringx.From(f.Name.ToCamel()).Untitle()
 		inJoin = append(inJoin, fmt.Sprintf("%s %s", param, f.DataType))
 		paramJoin = append(paramJoin, param)
-		if postgreSql {
-			argJoin = append(argJoin, fmt.Sprintf("%s = $%d", wrapWithRawString(f.Name.Source(), postgreSql), index+1))
-		} else {
-			argJoin = append(argJoin, fmt.Sprintf("%s = ?", wrapWithRawString(f.Name.Source(), postgreSql)))
-		}
+		argJoin = append(argJoin, fmt.Sprintf("%s = %s", dialect.Quote(f.Name.Source()), dialect.Placeholder(index+1)))
 	}
 	if len(inJoin) > 0 {
 		in = inJoin.With(", ").Source()
@@ -3,12 +3,12 @@ package gen
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/weitrue/goctl/config"
+	"github.com/weitrue/goctl/model/sql/converter"
 	"github.com/weitrue/goctl/model/sql/model"
 	"github.com/weitrue/goctl/model/sql/parser"
 	"github.com/weitrue/goctl/model/sql/template"
@@ -16,7 +16,9 @@ import (
 	"github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/console"
 	"github.com/weitrue/goctl/util/format"
+	"github.com/weitrue/goctl/util/preview"
 	"github.com/weitrue/goctl/util/stringx"
+	"github.com/zeromicro/go-zero/core/collection"
 )
 
 const (
@@ -29,9 +31,19 @@ type (
 		// source string
 		dir string
 		console.Console
-		pkg          string
-		cfg          *config.Config
-		isPostgreSql bool
+		pkg       string
+		cfg       *config.Config
+		dialect   Dialect
+		withCount bool
+		types     *converter.TypeConverter
+		// softDeleteColumns and versionColumns are the candidates of the conventional columns
+		softDeleteColumns []string
+		versionColumns    []string
+		// apiOutput and protoOutput are the files of the CRUD definitions of tables, definitions are the tables
+		// generated so far
+		apiOutput   string
+		protoOutput string
+		definitions []definitionTable
 	}
 
 	// Option defines a function with argument defaultGenerator
@@ -46,6 +58,7 @@ type (
 		findCode    []string
 		updateCode  string
 		deleteCode  string
+		transCode   string
 		cacheExtra  string
 	}
 )
@@ -85,16 +98,39 @@ func WithConsoleOption(c console.Console) Option {
 	}
 }
 
-// WithPostgreSql marks  defaultGenerator.isPostgreSql true
+// WithPostgreSql generates the models of postgresql
 func WithPostgreSql() Option {
+	return WithDialect(PostgreSql)
+}
+
+// WithDialect generates the models of the sql dialect, mysql by default
+func WithDialect(dialect Dialect) Option {
+	return func(generator *defaultGenerator) {
+		generator.dialect = dialect
+	}
+}
+
+// WithCount generates the count methods for the normal indexes
+func WithCount() Option {
 	return func(generator *defaultGenerator) {
-		generator.isPostgreSql = true
+		generator.withCount = true
+	}
+}
+
+// WithTypeMapping converts the column types with the custom types of mapping
+func WithTypeMapping(mapping *config.TypeMapping) Option {
+	return func(generator *defaultGenerator) {
+		generator.types = converter.NewTypeConverter(mapping)
 	}
 }
 
 func newDefaultOption() Option {
 	return func(generator *defaultGenerator) {
 		generator.Console = console.NewColorConsole()
+		generator.dialect = MySql
+		generator.types = converter.NewTypeConverter(nil)
+		generator.softDeleteColumns = defaultSoftDeleteColumns
+		generator.versionColumns = defaultVersionColumns
 	}
 }
 
@@ -110,7 +146,7 @@ func (g *defaultGenerator) StartFromDDL(filename string, withCache bool, databas
 func (g *defaultGenerator) StartFromInformationSchema(tables map[string]*model.Table, withCache bool) error {
 	m := make(map[string]string)
 	for _, each := range tables {
-		table, err := parser.ConvertDataType(each)
+		table, err := parser.ConvertDataType(each, parser.WithTypeConverter(g.types))
 		if err != nil {
 			return err
 		}
@@ -149,10 +185,14 @@ func (g *defaultGenerator) createFile(modelList map[string]string) error {
 		name := util.SafeString(modelFilename) + ".go"
 		filename := filepath.Join(dirAbs, name)
 		if util.FileExists(filename) {
-			g.Warning("%s already exists, ignored.", name)
+			if preview.Enabled() {
+				preview.Skip(filename)
+			} else {
+				g.Warning("%s already exists, ignored.", name)
+			}
 			continue
 		}
-		err = ioutil.WriteFile(filename, []byte(code), os.ModePerm)
+		err = util.WriteFile(filename, []byte(code), os.ModePerm)
 		if err != nil {
 			return err
 		}
@@ -184,7 +224,12 @@ func (g *defaultGenerator) createFile(modelList map[string]string) error {
 // ret1: key-table name,value-code
 func (g *defaultGenerator) genFromDDL(filename string, withCache bool, database string) (map[string]string, error) {
 	m := make(map[string]string)
-	tables, err := parser.Parse(filename, database)
+	parse := parser.Parse
+	if g.dialect == PostgreSql {
+		parse = parser.ParsePostgreSql
+	}
+
+	tables, err := parse(filename, database, parser.WithTypeConverter(g.types))
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +252,28 @@ type Table struct {
 	PrimaryCacheKey        Key
 	UniqueCacheKey         []Key
 	ContainsUniqueCacheKey bool
+	// SoftDeleteField marks the rows deleted instead of deleting them if it's not nil
+	SoftDeleteField *parser.Field
+	// VersionField is the column of optimistic locking if it's not nil
+	VersionField *parser.Field
+}
+
+// fieldImports returns the import paths of the custom types of fields, except the ones imported by templates
+func (g *defaultGenerator) fieldImports(in parser.Table) []string {
+	var imports []string
+	set := collection.NewSet()
+	set.AddStr("context", "database/sql", "database/sql/driver", "fmt", "strings", "time")
+	for _, field := range in.Fields {
+		path := g.types.Import(field.DataType)
+		if len(path) == 0 || set.Contains(path) {
+			continue
+		}
+
+		set.AddStr(path)
+		imports = append(imports, path)
+	}
+
+	return imports
 }
 
 func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, error) {
@@ -216,57 +283,85 @@ func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, er
 
 	primaryKey, uniqueKey := genCacheKeys(in)
 
-	importsCode, err := genImports(withCache, in.ContainsTime())
-	if err != nil {
-		return "", err
-	}
-
 	var table Table
 	table.Table = in
 	table.PrimaryCacheKey = primaryKey
 	table.UniqueCacheKey = uniqueKey
 	table.ContainsUniqueCacheKey = len(uniqueKey) > 0
+	table.SoftDeleteField = g.softDeleteField(in)
+	table.VersionField = g.versionField(in)
+	if g.definitionEnabled() {
+		g.definitions = append(g.definitions, genDefinition(table))
+	}
+
+	// the soft delete value is always generated with time.Now()
+	importsCode, err := genImports(withCache, in.ContainsTime() || table.SoftDeleteField != nil, g.fieldImports(in))
+	if err != nil {
+		return "", err
+	}
+
+	varsCode, err := genVars(table, withCache, g.dialect)
+	if err != nil {
+		return "", err
+	}
+
+	insertCode, insertCodeMethod, err := genInsert(table, withCache, g.dialect)
+	if err != nil {
+		return "", err
+	}
 
-	varsCode, err := genVars(table, withCache, g.isPostgreSql)
+	insertBatchCode, insertBatchCodeMethod, err := genInsertBatch(table, withCache, g.dialect)
 	if err != nil {
 		return "", err
 	}
 
-	insertCode, insertCodeMethod, err := genInsert(table, withCache, g.isPostgreSql)
+	upsertCode, upsertCodeMethod, err := genUpsert(table, withCache, g.dialect)
 	if err != nil {
 		return "", err
 	}
 
 	findCode := make([]string, 0)
-	findOneCode, findOneCodeMethod, err := genFindOne(table, withCache, g.isPostgreSql)
+	findOneCode, findOneCodeMethod, err := genFindOne(table, withCache, g.dialect)
+	if err != nil {
+		return "", err
+	}
+
+	ret, err := genFindOneByField(table, withCache, g.dialect)
+	if err != nil {
+		return "", err
+	}
+
+	findListCode, err := genFindListByField(table, withCache, g.withCount, g.dialect)
 	if err != nil {
 		return "", err
 	}
 
-	ret, err := genFindOneByField(table, withCache, g.isPostgreSql)
+	findCode = append(findCode, findOneCode, ret.findOneMethod, findListCode.findListMethod)
+	updateCode, updateCodeMethod, err := genUpdate(table, withCache, g.dialect)
 	if err != nil {
 		return "", err
 	}
 
-	findCode = append(findCode, findOneCode, ret.findOneMethod)
-	updateCode, updateCodeMethod, err := genUpdate(table, withCache, g.isPostgreSql)
+	deleteCode, deleteCodeMethod, err := genDelete(table, withCache, g.dialect)
 	if err != nil {
 		return "", err
 	}
 
-	deleteCode, deleteCodeMethod, err := genDelete(table, withCache, g.isPostgreSql)
+	transCode, transCodeMethod, err := genTrans(table, withCache)
 	if err != nil {
 		return "", err
 	}
 
 	var list []string
-	list = append(list, insertCodeMethod, findOneCodeMethod, ret.findOneInterfaceMethod, updateCodeMethod, deleteCodeMethod)
+	list = append(list, insertCodeMethod, insertBatchCodeMethod, upsertCodeMethod, findOneCodeMethod,
+		ret.findOneInterfaceMethod, findListCode.findListInterfaceMethod, updateCodeMethod, deleteCodeMethod,
+		transCodeMethod)
 	typesCode, err := genTypes(table, strings.Join(modelutil.TrimStringSlice(list), util.NL), withCache)
 	if err != nil {
 		return "", err
 	}
 
-	newCode, err := genNew(table, withCache, g.isPostgreSql)
+	newCode, err := genNew(table, withCache, g.dialect)
 	if err != nil {
 		return "", err
 	}
@@ -276,10 +371,11 @@ func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, er
 		varsCode:    varsCode,
 		typesCode:   typesCode,
 		newCode:     newCode,
-		insertCode:  insertCode,
+		insertCode:  strings.Join([]string{insertCode, insertBatchCode, upsertCode}, util.NL),
 		findCode:    findCode,
 		updateCode:  updateCode,
 		deleteCode:  deleteCode,
+		transCode:   transCode,
 		cacheExtra:  ret.cacheExtra,
 	}
 
@@ -309,6 +405,7 @@ func (g *defaultGenerator) executeModel(code *code) (*bytes.Buffer, error) {
 		"find":        strings.Join(code.findCode, "\n"),
 		"update":      code.updateCode,
 		"delete":      code.deleteCode,
+		"trans":       code.transCode,
 		"extraMethod": code.cacheExtra,
 	})
 	if err != nil {
@@ -10,14 +10,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiparser "github.com/weitrue/goctl/api/parser"
 	"github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/model/sql/builderx"
+	"github.com/weitrue/goctl/model/sql/parser"
+	rpcparser "github.com/weitrue/goctl/rpc/parser"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stringx"
 )
 
 var source = "CREATE TABLE `test_user` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `mobile` varchar(255) COLLATE utf8mb4_bin NOT NULL,\n  `class` bigint NOT NULL,\n  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,\n  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n  `update_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n  PRIMARY KEY (`id`),\n  UNIQUE KEY `mobile_unique` (`mobile`),\n  UNIQUE KEY `class_name_unique` (`class`,`name`),\n  KEY `create_index` (`create_time`),\n  KEY `name_index` (`name`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;"
 
+var compositeSource = "CREATE TABLE `tenant_order` (\n  `tenant_id` bigint NOT NULL,\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `sn` varchar(64) NOT NULL DEFAULT '',\n  `amount` bigint NOT NULL DEFAULT 0,\n  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n  PRIMARY KEY (`tenant_id`, `id`),\n  UNIQUE KEY `sn_unique` (`sn`)\n) ENGINE=InnoDB;"
+
 func TestCacheModel(t *testing.T) {
 	logx.Disable()
 	_ = Clean()
@@ -91,6 +96,378 @@ func TestNamingModel(t *testing.T) {
 	}())
 }
 
+func TestCompositePrimaryKey(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(compositeSource), 0o777)
+	assert.Nil(t, err)
+
+	dir := filepath.Join(t.TempDir(), "testmodel")
+	g, err := NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	})
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, true, "go_zero")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "tenantordermodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "FindOne(tenantId int64, id int64) (*TenantOrder, error)")
+	assert.Contains(t, code, "Delete(tenantId int64, id int64) error")
+	assert.Contains(t, code, "where `tenant_id` = ? and `id` = ? limit 1")
+	assert.Contains(t, code, `stringx.Remove(tenantOrderFieldNames, "`+"`id`"+`", "`)
+	assert.Contains(t, code, `stringx.Remove(tenantOrderFieldNames, "`+"`tenant_id`"+`", "`+"`id`"+`", "`)
+	assert.Contains(t, code, "conn.ExecCtx(ctx, query, data.Sn, data.Amount, data.TenantId, data.Id)")
+	assert.Contains(t, code, `fmt.Sprintf("%s%v:%v", cacheGoZeroTenantOrderTenantIdIdPrefix, tenantId, id)`)
+	assert.Contains(t, code, "return []interface{}{resp.TenantId, resp.Id}, nil")
+	assert.Contains(t, code, "conn.QueryRowCtx(ctx, v, query, primary.([]interface{})...)")
+
+	tables, err := parser.Parse(sqlFile, "go_zero")
+	assert.Nil(t, err)
+	primaryKey, uniqueKey := genCacheKeys(*tables[0])
+	code, _, err = genUpdate(Table{
+		Table:           *tables[0],
+		PrimaryCacheKey: primaryKey,
+		UniqueCacheKey:  uniqueKey,
+	}, false, PostgreSql)
+	assert.Nil(t, err)
+	assert.Contains(t, code, "where tenant_id = $1 and id = $4")
+	assert.Contains(t, code, "conn.ExecCtx(ctx, query, data.TenantId, data.Sn, data.Amount, data.Id)")
+}
+
+func TestFindListByField(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(source), 0o777)
+	assert.Nil(t, err)
+
+	dir := filepath.Join(t.TempDir(), "testmodel")
+	g, err := NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	}, WithCount())
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, false, "")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "testusermodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "FindListByName(name string, limit, offset int64) ([]*TestUser, error)")
+	assert.Contains(t, code, "FindListByCreateTime(createTime time.Time, limit, offset int64) ([]*TestUser, error)")
+	assert.Contains(t, code, "where `name` = ? order by `id` limit ? offset ?")
+	assert.Contains(t, code, "m.conn.QueryRowsCtx(ctx, &resp, query, name, limit, offset)")
+	assert.Contains(t, code, "CountByName(name string) (int64, error)")
+	assert.Contains(t, code, "select count(*) from %s where `name` = ?")
+
+	tables, err := parser.Parse(sqlFile, "")
+	assert.Nil(t, err)
+	findList, err := genFindListByField(Table{Table: *tables[0]}, true, false, PostgreSql)
+	assert.Nil(t, err)
+	assert.Contains(t, findList.findListMethod, "where name = $1 order by id limit $2 offset $3")
+	assert.Contains(t, findList.findListMethod, "m.QueryRowsNoCacheCtx(ctx, &resp, query, name, limit, offset)")
+	assert.NotContains(t, findList.findListMethod, "CountBy")
+}
+
+func TestTransAndWithSession(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(source), 0o777)
+	assert.Nil(t, err)
+
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	noCacheDir := filepath.Join(t.TempDir(), "nocache")
+	g, err := NewDefaultGenerator(cacheDir, &config.Config{
+		NamingFormat: "gozero",
+	})
+	assert.Nil(t, err)
+	err = g.StartFromDDL(sqlFile, true, "go_zero")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(cacheDir, "testusermodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "FindOneCtx(ctx context.Context, id int64) (*TestUser, error)")
+	assert.Contains(t, code, "return m.FindOneCtx(context.Background(), id)")
+	assert.Contains(t, code, "FindOneByMobileCtx(ctx context.Context, mobile string) (*TestUser, error)")
+	assert.Contains(t, code, "Trans(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error")
+	assert.Contains(t, code, "WithSession(session sqlx.Session) TestUserModel")
+	assert.Contains(t, code, "return sessionx.TransactCtx(ctx, m.CachedConn, fn)")
+	assert.Contains(t, code, "sqlc.NewConnWithCache(sessionx.NewSqlConn(session), sessionx.NewSessionCache(session, m.cache))")
+
+	g, err = NewDefaultGenerator(noCacheDir, &config.Config{
+		NamingFormat: "gozero",
+	})
+	assert.Nil(t, err)
+	err = g.StartFromDDL(sqlFile, false, "go_zero")
+	assert.Nil(t, err)
+
+	data, err = ioutil.ReadFile(filepath.Join(noCacheDir, "testusermodel.go"))
+	assert.Nil(t, err)
+	code = string(data)
+	assert.Contains(t, code, "m.conn.QueryRowCtx(ctx, &resp, query, id)")
+	assert.Contains(t, code, "return m.conn.TransactCtx(ctx, fn)")
+	assert.Contains(t, code, "conn:  sessionx.NewSqlConn(session)")
+}
+
+func TestInsertBatchAndUpsert(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(source), 0o777)
+	assert.Nil(t, err)
+
+	dir := filepath.Join(t.TempDir(), "testmodel")
+	g, err := NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	})
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, true, "go_zero")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "testusermodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "InsertBatch(ctx context.Context, list []*TestUser) (sql.Result, error)")
+	assert.Contains(t, code, "Upsert(ctx context.Context, data *TestUser) (sql.Result, error)")
+	assert.Contains(t, code, `values = append(values, "(?, ?, ?)")`)
+	assert.Contains(t, code, "fmt.Sprintf(\"%s%v\", cacheGoZeroTestUserIdPrefix, data.Id)")
+	assert.Contains(t, code, "}, keys...)")
+	assert.Contains(t, code, "on duplicate key update `mobile` = values(`mobile`), `class` = values(`class`), `name` = values(`name`)")
+	assert.Contains(t, code, "if old, err := m.FindOneByMobileCtx(ctx, data.Mobile); err == nil {")
+	assert.Contains(t, code, "if old, err := m.FindOneByClassNameCtx(ctx, data.Class, data.Name); err == nil {")
+	assert.Contains(t, code, "fmt.Sprintf(\"%s%v\", cacheGoZeroTestUserIdPrefix, old.Id)")
+	assert.NotContains(t, code, "m.FindOneCtx(ctx, data.Id)", "the auto increment id never conflicts")
+
+	compositeFile := filepath.Join(t.TempDir(), "composite.sql")
+	err = ioutil.WriteFile(compositeFile, []byte(compositeSource), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := parser.Parse(compositeFile, "go_zero")
+	assert.Nil(t, err)
+	table := Table{Table: *tables[0]}
+	table.PrimaryCacheKey, table.UniqueCacheKey = genCacheKeys(*tables[0])
+	assert.Equal(t, "on duplicate key update `sn` = values(`sn`), `amount` = values(`amount`)",
+		MySql.ConflictClause(table))
+	assert.Equal(t, "on conflict (sn) do update set amount = excluded.amount", PostgreSql.ConflictClause(table))
+	keys := conflictKeys(table, PostgreSql)
+	assert.Equal(t, 1, len(keys))
+	assert.Equal(t, "cacheGoZeroTenantOrderSnPrefix", keys[0].VarLeft)
+
+	insertBatch, _, err := genInsertBatch(table, false, PostgreSql)
+	assert.Nil(t, err)
+	assert.Contains(t, insertBatch, `fmt.Sprintf("($%d, $%d, $%d)", len(args)+1, len(args)+2, len(args)+3)`)
+	assert.Contains(t, insertBatch, "return m.conn.ExecCtx(ctx, query, args...)")
+}
+
+func TestSoftDeleteAndVersion(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte("CREATE TABLE `post` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `title` varchar(255) NOT NULL,\n  `version` bigint NOT NULL DEFAULT 0,\n"+
+		"  `delete_time` timestamp NULL DEFAULT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;\n"+
+		"CREATE TABLE `tag` (\n  `id` bigint NOT NULL,\n  `name` varchar(64) NOT NULL,\n"+
+		"  `removed` bigint NOT NULL DEFAULT 0,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;"), 0o777)
+	assert.Nil(t, err)
+
+	dir := filepath.Join(t.TempDir(), "testmodel")
+	g, err := NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	})
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, false, "go_zero")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "postmodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "where `id` = ? and `delete_time` is null limit 1")
+	assert.Contains(t, code, "update %s set `delete_time` = ? where `id` = ? and `delete_time` is null")
+	assert.Contains(t, code, "m.conn.ExecCtx(ctx, query, time.Now(), id)")
+	assert.Contains(t, code, "update %s set %s, `version` = `version` + 1 where `id` = ? and `version` = ? and `delete_time` is null")
+	assert.Contains(t, code, "m.conn.ExecCtx(ctx, query, data.Title, data.Id, data.Version)")
+	assert.Contains(t, code, "return ErrConcurrentUpdate")
+	assert.Contains(t, code, "\"`id`\", \"`delete_time`\", \"`version`\",")
+	assert.NotContains(t, code, "delete from")
+	assert.Contains(t, code, "on duplicate key update `title` = values(`title`), `delete_time` = null, `version` = `version` + 1\"")
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "vars.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "ErrConcurrentUpdate = errors.New(\"concurrent update\")")
+
+	tables, err := parser.Parse(sqlFile, "go_zero")
+	assert.Nil(t, err)
+	g, err = NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	}, WithSoftDeleteColumns("removed"), WithVersionColumns())
+	assert.Nil(t, err)
+
+	table := Table{Table: *tables[1], SoftDeleteField: g.softDeleteField(*tables[1])}
+	table.PrimaryCacheKey, table.UniqueCacheKey = genCacheKeys(*tables[1])
+	post := Table{Table: *tables[0], VersionField: tables[0].Fields[2], SoftDeleteField: tables[0].Fields[3]}
+	assert.Equal(t, `on conflict (id) do update set title = excluded.title, delete_time = null, version = \"post\".version + 1`,
+		PostgreSql.ConflictClause(post))
+	assert.Nil(t, g.versionField(*tables[0]))
+	assert.Equal(t, " and removed = 0", table.notDeleted(PostgreSql))
+	assert.Equal(t, "on duplicate key update `name` = values(`name`), `removed` = 0", MySql.ConflictClause(table))
+
+	deleteCode, _, err := genDelete(table, false, PostgreSql)
+	assert.Nil(t, err)
+	assert.Contains(t, deleteCode, "update %s set removed = $2 where id = $1 and removed = 0")
+	assert.Contains(t, deleteCode, "m.conn.ExecCtx(ctx, query, id, time.Now().Unix())")
+}
+
+func TestDefinitions(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(source+"\n"+compositeSource+"\n"+
+		"CREATE TABLE `post` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `title` varchar(255) NOT NULL COMMENT 'the title\n of post',\n  `summary` varchar(255) NULL,\n"+
+		"  `version` bigint NOT NULL DEFAULT 0,\n  `delete_time` timestamp NULL DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;"), 0o777)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	apiFile := filepath.Join(dir, "admin.api")
+	protoFile := filepath.Join(dir, "admin.proto")
+	g, err := NewDefaultGenerator(filepath.Join(dir, "model"), &config.Config{
+		NamingFormat: "gozero",
+	}, WithApiOutput(apiFile), WithProtoOutput(protoFile))
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, false, "go_zero")
+	assert.Nil(t, err)
+	assert.Nil(t, g.GenDefinitions())
+
+	api, err := apiparser.Parse(apiFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "admin-api", api.Service.Name)
+	assert.Len(t, api.Service.Groups, 3)
+
+	data, err := ioutil.ReadFile(apiFile)
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "get /test-user/by-class-name/:class/:name (GetTestUserByClassNameReq) returns (TestUser)")
+	assert.Contains(t, code, "put /tenant-order/:tenantId/:id (UpdateTenantOrderReq)")
+	assert.Contains(t, code, "// the title of post\n\t\tTitle   string `json:\"title\"`")
+	assert.Contains(t, code, "Summary string `json:\"summary,optional\"`")
+	assert.Contains(t, code, "PageSize int64 `form:\"pageSize,default=20\"`")
+	assert.NotContains(t, code, "DeleteTime int64 `json:\"deleteTime")
+
+	proto, err := rpcparser.NewDefaultProtoParser().Parse(protoFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "Admin", proto.Service.Name)
+	assert.Len(t, proto.Service.RPC, 18)
+
+	data, err = ioutil.ReadFile(protoFile)
+	assert.Nil(t, err)
+	code = string(data)
+	assert.Contains(t, code, "message CreatePostReq {\n  // the title of post\n  string title = 1;\n  string summary = 2;\n}")
+	assert.Contains(t, code, "message UpdatePostReq {\n  int64 id = 1;\n  // the title of post\n  string title = 2;\n"+
+		"  string summary = 3;\n  int64 version = 4;\n}")
+	assert.Contains(t, code, "message CreateTenantOrderResp {\n  int64 tenant_id = 1;\n  int64 id = 2;\n}")
+}
+
+func TestDefinitionTypes(t *testing.T) {
+	cases := map[string][]interface{}{
+		"int64":           {"int64", "int64", false},
+		"uint32":          {"uint64", "uint64", false},
+		"sql.NullInt64":   {"int64", "int64", true},
+		"time.Time":       {"int64", "int64", false},
+		"sql.NullTime":    {"int64", "int64", true},
+		"float64":         {"float64", "double", false},
+		"sql.NullBool":    {"bool", "bool", true},
+		"[]byte":          {"string", "bytes", false},
+		"decimal.Decimal": {"string", "string", false},
+		"*string":         {"string", "string", true},
+	}
+	for dataType, expected := range cases {
+		apiType, protoType, optional := definitionTypes(dataType)
+		assert.Equal(t, expected, []interface{}{apiType, protoType, optional}, dataType)
+	}
+}
+
+func TestTypeMapping(t *testing.T) {
+	logx.Disable()
+	_ = Clean()
+
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte("CREATE TABLE `bill` (\n  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `enabled` tinyint(1) NOT NULL DEFAULT '0',\n  `amount` decimal(10,2) NOT NULL DEFAULT '0.00',\n"+
+		"  `extra` json DEFAULT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;"), 0o777)
+	assert.Nil(t, err)
+
+	dir := filepath.Join(t.TempDir(), "testmodel")
+	g, err := NewDefaultGenerator(dir, &config.Config{
+		NamingFormat: "gozero",
+	}, WithTypeMapping(&config.TypeMapping{
+		Types: map[string]string{
+			"unsigned":   "uint64",
+			"tinyint(1)": "bool",
+			"decimal":    "github.com/shopspring/decimal.Decimal",
+			"json":       "encoding/json.RawMessage",
+		},
+	}))
+	assert.Nil(t, err)
+
+	err = g.StartFromDDL(sqlFile, false, "")
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "billmodel.go"))
+	assert.Nil(t, err)
+	code := string(data)
+	assert.Contains(t, code, "\"encoding/json\"\n\t\"fmt\"")
+	assert.Contains(t, code, "\n\n\t\"github.com/shopspring/decimal\"")
+	assert.Regexp(t, `Id\s+uint64`, code)
+	assert.Regexp(t, `Enabled\s+bool`, code)
+	assert.Regexp(t, `Amount\s+decimal.Decimal`, code)
+	assert.Regexp(t, `Extra\s+json.RawMessage`, code)
+	assert.Contains(t, code, "FindOne(id uint64) (*Bill, error)")
+}
+
+func TestDialect(t *testing.T) {
+	compositeFile := filepath.Join(t.TempDir(), "composite.sql")
+	err := ioutil.WriteFile(compositeFile, []byte(compositeSource), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := parser.Parse(compositeFile, "go_zero")
+	assert.Nil(t, err)
+	table := Table{Table: *tables[0]}
+	table.PrimaryCacheKey, table.UniqueCacheKey = genCacheKeys(*tables[0])
+
+	for _, dialect := range []Dialect{MySql, PostgreSql, Sqlite} {
+		assert.Equal(t, dialect == PostgreSql, dialect.Numbered())
+	}
+	assert.Equal(t, "?", Sqlite.Placeholder(2))
+	assert.Equal(t, "$2", PostgreSql.Placeholder(2))
+	assert.Equal(t, "\"`tenant_order`\"", Sqlite.TableName(table.Table))
+	assert.Equal(t, "`\"go_zero\".\"tenant_order\"`", PostgreSql.TableName(table.Table))
+	assert.Equal(t, "on conflict (`sn`) do update set `amount` = excluded.`amount`", Sqlite.ConflictClause(table))
+
+	findOne, _, err := genFindOne(table, false, Sqlite)
+	assert.Nil(t, err)
+	assert.Contains(t, findOne, "where `tenant_id` = ? and `id` = ? limit 1")
+
+	update, _, err := genUpdate(table, false, Sqlite)
+	assert.Nil(t, err)
+	assert.Contains(t, update, "m.conn.ExecCtx(ctx, query, data.Sn, data.Amount, data.TenantId, data.Id)")
+}
+
 func TestWrapWithRawString(t *testing.T) {
 	assert.Equal(t, "``", wrapWithRawString("", false))
 	assert.Equal(t, "``", wrapWithRawString("``", false))
@@ -1,11 +1,24 @@
 package gen
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/weitrue/goctl/model/sql/template"
 	"github.com/weitrue/goctl/util"
 )
 
-func genImports(withCache, timeImport bool) (string, error) {
+func genImports(withCache, timeImport bool, extraImports []string) (string, error) {
+	// the standard packages have no dots in the first elements of paths
+	var stdImports, thirdImports []string
+	for _, each := range extraImports {
+		if strings.Contains(strings.Split(each, "/")[0], ".") {
+			thirdImports = append(thirdImports, fmt.Sprintf("%q", each))
+		} else {
+			stdImports = append(stdImports, fmt.Sprintf("%q\n\t", each))
+		}
+	}
+
 	if withCache {
 		text, err := util.LoadTemplate(category, importsTemplateFile, template.Imports)
 		if err != nil {
@@ -13,7 +26,9 @@ func genImports(withCache, timeImport bool) (string, error) {
 		}
 
 		buffer, err := util.With("import").Parse(text).Execute(map[string]interface{}{
-			"time": timeImport,
+			"time":         timeImport,
+			"stdImports":   strings.Join(stdImports, ""),
+			"thirdImports": strings.Join(thirdImports, "\n\t"),
 		})
 		if err != nil {
 			return "", err
@@ -28,7 +43,9 @@ func genImports(withCache, timeImport bool) (string, error) {
 	}
 
 	buffer, err := util.With("import").Parse(text).Execute(map[string]interface{}{
-		"time": timeImport,
+		"time":         timeImport,
+		"stdImports":   strings.Join(stdImports, ""),
+		"thirdImports": strings.Join(thirdImports, "\n\t"),
 	})
 	if err != nil {
 		return "", err
@@ -1,16 +1,16 @@
 package gen
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/weitrue/goctl/model/sql/parser"
 	"github.com/weitrue/goctl/model/sql/template"
 	"github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/stringx"
 	"github.com/zeromicro/go-zero/core/collection"
 )
 
-func genInsert(table Table, withCache, postgreSql bool) (string, string, error) {
+func genInsert(table Table, withCache bool, dialect Dialect) (string, string, error) {
 	keySet := collection.NewSet()
 	keyVariableSet := collection.NewSet()
 	for _, key := range table.UniqueCacheKey {
@@ -18,29 +18,7 @@ func genInsert(table Table, withCache, postgreSql bool) (string, string, error)
 		keyVariableSet.AddStr(key.KeyLeft)
 	}
 
-	expressions := make([]string, 0)
-	expressionValues := make([]string, 0)
-	var count int
-	for _, field := range table.Fields {
-		camel := field.Name.ToCamel()
-		if camel == "CreateTime" || camel == "UpdateTime" {
-			continue
-		}
-
-		if field.Name.Source() == table.PrimaryKey.Name.Source() {
-			if table.PrimaryKey.AutoIncrement {
-				continue
-			}
-		}
-
-		count += 1
-		if postgreSql {
-			expressions = append(expressions, fmt.Sprintf("$%d", count))
-		} else {
-			expressions = append(expressions, "?")
-		}
-		expressionValues = append(expressionValues, "data."+camel)
-	}
+	expressions, expressionValues := genInsertExpressions(table, dialect)
 
 	camel := table.Name.ToCamel()
 	text, err := util.LoadTemplate(category, insertTemplateFile, template.Insert)
@@ -79,3 +57,35 @@ func genInsert(table Table, withCache, postgreSql bool) (string, string, error)
 
 	return output.String(), insertMethodOutput.String(), nil
 }
+
+// insertFields returns the fields which are set on inserting, the auto set time columns
+// and the auto increment column are excluded
+func insertFields(table Table) []*parser.Field {
+	var fields []*parser.Field
+	for _, field := range table.Fields {
+		camel := field.Name.ToCamel()
+		if camel == "CreateTime" || camel == "UpdateTime" {
+			continue
+		}
+
+		if autoIncrement := table.PrimaryKey.AutoIncrementColumn(); autoIncrement != nil &&
+			field.Name.Source() == autoIncrement.Name.Source() {
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func genInsertExpressions(table Table, dialect Dialect) ([]string, []string) {
+	expressions := make([]string, 0)
+	expressionValues := make([]string, 0)
+	for i, field := range insertFields(table) {
+		expressions = append(expressions, dialect.Placeholder(i+1))
+		expressionValues = append(expressionValues, "data."+field.Name.ToCamel())
+	}
+
+	return expressions, expressionValues
+}
@@ -0,0 +1,69 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weitrue/goctl/model/sql/template"
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/stringx"
+)
+
+func genInsertBatch(table Table, withCache bool, dialect Dialect) (string, string, error) {
+	expressions, expressionValues := genInsertExpressions(table, dialect)
+
+	// the numbered placeholders of rows follow the arguments appended before
+	batchExpression := fmt.Sprintf(`"(%s)"`, strings.Join(expressions, ", "))
+	if dialect.Numbered() {
+		placeholders := make([]string, 0, len(expressions))
+		offsets := make([]string, 0, len(expressions))
+		for i := range expressions {
+			placeholders = append(placeholders, "$%d")
+			offsets = append(offsets, fmt.Sprintf("len(args)+%d", i+1))
+		}
+		batchExpression = fmt.Sprintf(`fmt.Sprintf("(%s)", %s)`, strings.Join(placeholders, ", "),
+			strings.Join(offsets, ", "))
+	}
+
+	batchKeys := []string{table.PrimaryCacheKey.DataKeyRight}
+	for _, key := range table.UniqueCacheKey {
+		batchKeys = append(batchKeys, key.DataKeyRight)
+	}
+
+	camel := table.Name.ToCamel()
+	text, err := util.LoadTemplate(category, insertBatchTemplateFile, template.InsertBatch)
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := util.With("insertBatch").
+		Parse(text).
+		Execute(map[string]interface{}{
+			"withCache":             withCache,
+			"upperStartCamelObject": camel,
+			"lowerStartCamelObject": stringx.From(camel).Untitle(),
+			"fieldCount":            len(expressions),
+			"batchExpression":       batchExpression,
+			"expressionValues":      strings.Join(expressionValues, ", "),
+			"keyCount":              len(batchKeys),
+			"batchKeys":             strings.Join(batchKeys, ", "),
+		})
+	if err != nil {
+		return "", "", err
+	}
+
+	// interface method
+	text, err = util.LoadTemplate(category, insertBatchMethodTemplateFile, template.InsertBatchMethod)
+	if err != nil {
+		return "", "", err
+	}
+
+	insertBatchMethodOutput, err := util.With("insertBatchMethod").Parse(text).Execute(map[string]interface{}{
+		"upperStartCamelObject": camel,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return output.String(), insertBatchMethodOutput.String(), nil
+}
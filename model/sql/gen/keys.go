@@ -40,7 +40,7 @@ type Join []string
 func genCacheKeys(table parser.Table) (Key, []Key) {
 	var primaryKey Key
 	var uniqueKey []Key
-	primaryKey = genCacheKey(table.Db, table.Name, []*parser.Field{&table.PrimaryKey.Field})
+	primaryKey = genCacheKey(table.Db, table.Name, table.PrimaryKey.Columns())
 	for _, each := range table.UniqueIndex {
 		uniqueKey = append(uniqueKey, genCacheKey(table.Db, table.Name, each))
 	}
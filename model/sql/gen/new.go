@@ -1,27 +1,20 @@
 package gen
 
 import (
-	"fmt"
-
 	"github.com/weitrue/goctl/model/sql/template"
 	"github.com/weitrue/goctl/util"
 )
 
-func genNew(table Table, withCache, postgreSql bool) (string, error) {
+func genNew(table Table, withCache bool, dialect Dialect) (string, error) {
 	text, err := util.LoadTemplate(category, modelNewTemplateFile, template.New)
 	if err != nil {
 		return "", err
 	}
 
-	t := fmt.Sprintf(`"%s"`, wrapWithRawString(table.Name.Source(), postgreSql))
-	if postgreSql {
-		t = "`" + fmt.Sprintf(`"%s"."%s"`, table.Db.Source(), table.Name.Source()) + "`"
-	}
-
 	output, err := util.With("new").
 		Parse(text).
 		Execute(map[string]interface{}{
-			"table":                 t,
+			"table":                 dialect.TableName(table.Table),
 			"withCache":             withCache,
 			"upperStartCamelObject": table.Name.ToCamel(),
 		})
@@ -0,0 +1,133 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/weitrue/goctl/model/sql/parser"
+	"github.com/zeromicro/go-zero/core/collection"
+)
+
+var (
+	defaultSoftDeleteColumns = []string{"delete_time", "deleted_at"}
+	defaultVersionColumns    = []string{"version"}
+	integerTypes             = collection.NewSet()
+)
+
+func init() {
+	integerTypes.AddStr("int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64")
+}
+
+// WithSoftDeleteColumns replaces the candidates of the soft delete column, the first one found in a table is used
+func WithSoftDeleteColumns(columns ...string) Option {
+	return func(generator *defaultGenerator) {
+		generator.softDeleteColumns = columns
+	}
+}
+
+// WithVersionColumns replaces the candidates of the optimistic locking column
+func WithVersionColumns(columns ...string) Option {
+	return func(generator *defaultGenerator) {
+		generator.versionColumns = columns
+	}
+}
+
+// findField returns the first field named in columns, the primary keys are never returned
+func findField(table parser.Table, columns []string) *parser.Field {
+	primarySet := collection.NewSet()
+	for _, field := range table.PrimaryKey.Columns() {
+		primarySet.AddStr(field.Name.Source())
+	}
+
+	for _, column := range columns {
+		for _, field := range table.Fields {
+			if field.Name.Source() == column && !primarySet.Contains(column) {
+				return field
+			}
+		}
+	}
+
+	return nil
+}
+
+// softDeleteField returns the soft delete field of table, the rows are deleted if it's not null,
+// or not zero for the integers
+func (g *defaultGenerator) softDeleteField(table parser.Table) *parser.Field {
+	field := findField(table, g.softDeleteColumns)
+	if field == nil {
+		return nil
+	}
+
+	switch {
+	case field.DataType == "sql.NullTime", field.DataType == "sql.NullInt64", integerTypes.Contains(field.DataType):
+		return field
+	default:
+		g.Warning("table %s: soft delete column %s of %s is ignored, expected a nullable time or an integer",
+			table.Name.Source(), field.Name.Source(), field.DataType)
+		return nil
+	}
+}
+
+// versionField returns the optimistic locking field of table, which must be an integer
+func (g *defaultGenerator) versionField(table parser.Table) *parser.Field {
+	field := findField(table, g.versionColumns)
+	if field == nil {
+		return nil
+	}
+
+	if !integerTypes.Contains(field.DataType) {
+		g.Warning("table %s: version column %s of %s is ignored, expected an integer",
+			table.Name.Source(), field.Name.Source(), field.DataType)
+		return nil
+	}
+
+	return field
+}
+
+// notDeleted returns the condition which filters out the soft deleted rows, it's empty without soft delete
+func (t Table) notDeleted(dialect Dialect) string {
+	if t.SoftDeleteField == nil {
+		return ""
+	}
+
+	column := dialect.Quote(t.SoftDeleteField.Name.Source())
+	if integerTypes.Contains(t.SoftDeleteField.DataType) {
+		return fmt.Sprintf(" and %s = 0", column)
+	}
+
+	return fmt.Sprintf(" and %s is null", column)
+}
+
+// restoreAssignment returns the assignment which marks a row not deleted, it's empty without soft delete
+func (t Table) restoreAssignment(dialect Dialect) string {
+	if t.SoftDeleteField == nil {
+		return ""
+	}
+
+	column := dialect.Quote(t.SoftDeleteField.Name.Source())
+	if integerTypes.Contains(t.SoftDeleteField.DataType) {
+		return fmt.Sprintf("%s = 0", column)
+	}
+
+	return fmt.Sprintf("%s = null", column)
+}
+
+// softDeleteValue returns the expression of the value which marks a row deleted
+func (t Table) softDeleteValue() string {
+	if t.SoftDeleteField.DataType == "sql.NullTime" {
+		return "time.Now()"
+	}
+
+	return "time.Now().Unix()"
+}
+
+// excludedKeys returns the columns which are never set by the updated data
+func (t Table) excludedKeys(dialect Dialect) []string {
+	var keys []string
+	for _, field := range []*parser.Field{t.SoftDeleteField, t.VersionField} {
+		if field != nil {
+			keys = append(keys, fmt.Sprintf("%q", dialect.Quote(field.Name.Source())))
+		}
+	}
+
+	return keys
+}
@@ -18,18 +18,30 @@ const (
 	findOneByFieldTemplateFile            = "find-one-by-field.tpl"
 	findOneByFieldMethodTemplateFile      = "interface-find-one-by-field.tpl"
 	findOneByFieldExtraMethodTemplateFile = "find-one-by-field-extra-method.tpl"
+	findListByFieldTemplateFile           = "find-list-by-field.tpl"
+	findListByFieldMethodTemplateFile     = "interface-find-list-by-field.tpl"
+	countByFieldTemplateFile              = "count-by-field.tpl"
+	countByFieldMethodTemplateFile        = "interface-count-by-field.tpl"
 	importsTemplateFile                   = "import.tpl"
 	importsWithNoCacheTemplateFile        = "import-no-cache.tpl"
 	insertTemplateFile                    = "insert.tpl"
 	insertTemplateMethodFile              = "interface-insert.tpl"
+	insertBatchTemplateFile               = "insert-batch.tpl"
+	insertBatchMethodTemplateFile         = "interface-insert-batch.tpl"
 	modelTemplateFile                     = "model.tpl"
 	modelNewTemplateFile                  = "model-new.tpl"
 	tagTemplateFile                       = "tag.tpl"
+	transTemplateFile                     = "trans.tpl"
+	transMethodTemplateFile               = "interface-trans.tpl"
 	typesTemplateFile                     = "types.tpl"
 	updateTemplateFile                    = "update.tpl"
 	updateMethodTemplateFile              = "interface-update.tpl"
+	upsertTemplateFile                    = "upsert.tpl"
+	upsertMethodTemplateFile              = "interface-upsert.tpl"
 	varTemplateFile                       = "var.tpl"
 	errTemplateFile                       = "err.tpl"
+	apiTemplateFile                       = "api.tpl"
+	protoTemplateFile                     = "proto.tpl"
 )
 
 var templates = map[string]string{
@@ -41,18 +53,30 @@ var templates = map[string]string{
 	findOneByFieldTemplateFile:            template.FindOneByField,
 	findOneByFieldMethodTemplateFile:      template.FindOneByFieldMethod,
 	findOneByFieldExtraMethodTemplateFile: template.FindOneByFieldExtraMethod,
+	findListByFieldTemplateFile:           template.FindListByField,
+	findListByFieldMethodTemplateFile:     template.FindListByFieldMethod,
+	countByFieldTemplateFile:              template.CountByField,
+	countByFieldMethodTemplateFile:        template.CountByFieldMethod,
 	importsTemplateFile:                   template.Imports,
 	importsWithNoCacheTemplateFile:        template.ImportsNoCache,
 	insertTemplateFile:                    template.Insert,
 	insertTemplateMethodFile:              template.InsertMethod,
+	insertBatchTemplateFile:               template.InsertBatch,
+	insertBatchMethodTemplateFile:         template.InsertBatchMethod,
 	modelTemplateFile:                     template.Model,
 	modelNewTemplateFile:                  template.New,
 	tagTemplateFile:                       template.Tag,
+	transTemplateFile:                     template.Trans,
+	transMethodTemplateFile:               template.TransMethod,
 	typesTemplateFile:                     template.Types,
 	updateTemplateFile:                    template.Update,
 	updateMethodTemplateFile:              template.UpdateMethod,
+	upsertTemplateFile:                    template.Upsert,
+	upsertMethodTemplateFile:              template.UpsertMethod,
 	varTemplateFile:                       template.Vars,
 	errTemplateFile:                       template.Error,
+	apiTemplateFile:                       template.Api,
+	protoTemplateFile:                     template.Proto,
 }
 
 // Category returns model const value
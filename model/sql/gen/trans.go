@@ -0,0 +1,41 @@
+package gen
+
+import (
+	"github.com/weitrue/goctl/model/sql/template"
+	"github.com/weitrue/goctl/util"
+)
+
+func genTrans(table Table, withCache bool) (string, string, error) {
+	camel := table.Name.ToCamel()
+	text, err := util.LoadTemplate(category, transTemplateFile, template.Trans)
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := util.With("trans").
+		Parse(text).
+		Execute(map[string]interface{}{
+			"withCache":             withCache,
+			"upperStartCamelObject": camel,
+		})
+	if err != nil {
+		return "", "", err
+	}
+
+	// interface method
+	text, err = util.LoadTemplate(category, transMethodTemplateFile, template.TransMethod)
+	if err != nil {
+		return "", "", err
+	}
+
+	transMethodOut, err := util.With("transMethod").
+		Parse(text).
+		Execute(map[string]interface{}{
+			"upperStartCamelObject": camel,
+		})
+	if err != nil {
+		return "", "", err
+	}
+
+	return output.String(), transMethodOut.String(), nil
+}
@@ -1,15 +1,28 @@
 package gen
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/weitrue/goctl/model/sql/parser"
 	"github.com/weitrue/goctl/model/sql/template"
 	"github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/stringx"
 	"github.com/zeromicro/go-zero/core/collection"
 )
 
-func genUpdate(table Table, withCache, postgreSql bool) (string, string, error) {
+func genUpdate(table Table, withCache bool, dialect Dialect) (string, string, error) {
+	primaryKeys := table.PrimaryKey.Columns()
+	excludedSet := collection.NewSet()
+	for _, field := range primaryKeys {
+		excludedSet.AddStr(field.Name.Source())
+	}
+	for _, field := range []*parser.Field{table.SoftDeleteField, table.VersionField} {
+		if field != nil {
+			excludedSet.AddStr(field.Name.Source())
+		}
+	}
+
 	expressionValues := make([]string, 0)
 	for _, field := range table.Fields {
 		camel := field.Name.ToCamel()
@@ -17,7 +30,7 @@ func genUpdate(table Table, withCache, postgreSql bool) (string, string, error)
 			continue
 		}
 
-		if field.Name.Source() == table.PrimaryKey.Name.Source() {
+		if excludedSet.Contains(field.Name.Source()) {
 			continue
 		}
 
@@ -33,11 +46,35 @@ func genUpdate(table Table, withCache, postgreSql bool) (string, string, error)
 		keyVariableSet.AddStr(key.KeyLeft)
 	}
 
-	if postgreSql {
-		expressionValues = append([]string{"data." + table.PrimaryKey.Name.ToCamel()}, expressionValues...)
-	} else {
-		expressionValues = append(expressionValues, "data."+table.PrimaryKey.Name.ToCamel())
+	// the numbered placeholders of rows start from $2 by builderx.PostgreSqlJoin, $1 is left for the first
+	// column of primary key, the others are placed after the updated columns
+	var conditions []string
+	count := len(expressionValues)
+	for i, field := range primaryKeys {
+		value := "data." + field.Name.ToCamel()
+		switch {
+		case !dialect.Numbered():
+			conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.Quote(field.Name.Source()), dialect.Placeholder(count+i+1)))
+			expressionValues = append(expressionValues, value)
+		case i == 0:
+			conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.Quote(field.Name.Source()), dialect.Placeholder(1)))
+			expressionValues = append([]string{value}, expressionValues...)
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.Quote(field.Name.Source()), dialect.Placeholder(count+i+1)))
+			expressionValues = append(expressionValues, value)
+		}
 	}
+
+	// the version is checked after the primary keys and increased by the update
+	var versionSet string
+	if table.VersionField != nil {
+		column := dialect.Quote(table.VersionField.Name.Source())
+		versionSet = fmt.Sprintf(", %s = %s + 1", column, column)
+		placeholder := dialect.Placeholder(count + len(primaryKeys) + 1)
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, placeholder))
+		expressionValues = append(expressionValues, "data."+table.VersionField.Name.ToCamel())
+	}
+
 	camelTableName := table.Name.ToCamel()
 	text, err := util.LoadTemplate(category, updateTemplateFile, template.Update)
 	if err != nil {
@@ -47,16 +84,21 @@ func genUpdate(table Table, withCache, postgreSql bool) (string, string, error)
 	output, err := util.With("update").
 		Parse(text).
 		Execute(map[string]interface{}{
-			"withCache":             withCache,
-			"upperStartCamelObject": camelTableName,
-			"keys":                  strings.Join(keySet.KeysStr(), "\n"),
-			"keyValues":             strings.Join(keyVariableSet.KeysStr(), ", "),
-			"primaryCacheKey":       table.PrimaryCacheKey.DataKeyExpression,
-			"primaryKeyVariable":    table.PrimaryCacheKey.KeyLeft,
-			"lowerStartCamelObject": stringx.From(camelTableName).Untitle(),
-			"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source(), postgreSql),
-			"expressionValues":      strings.Join(expressionValues, ", "),
-			"postgreSql":            postgreSql,
+			"withCache":                   withCache,
+			"upperStartCamelObject":       camelTableName,
+			"keys":                        strings.Join(keySet.KeysStr(), "\n"),
+			"keyValues":                   strings.Join(keyVariableSet.KeysStr(), ", "),
+			"primaryCacheKey":             table.PrimaryCacheKey.DataKeyExpression,
+			"primaryKeyVariable":          table.PrimaryCacheKey.KeyLeft,
+			"lowerStartCamelObject":       stringx.From(camelTableName).Untitle(),
+			"originalPrimaryKey":          dialect.Quote(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKeyCondition": strings.Join(conditions, " and "),
+			"notDeleted":                  table.notDeleted(dialect),
+			"version":                     table.VersionField != nil,
+			"versionSet":                  versionSet,
+			"expressionValues":            strings.Join(expressionValues, ", "),
+			"postgreSql":                  dialect == PostgreSql,
+			"dialect":                     dialect.Name(),
 		})
 	if err != nil {
 		return "", "", nil
@@ -0,0 +1,213 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weitrue/goctl/model/sql/parser"
+	"github.com/weitrue/goctl/model/sql/template"
+	"github.com/weitrue/goctl/util"
+	"github.com/weitrue/goctl/util/stringx"
+	"github.com/zeromicro/go-zero/core/collection"
+)
+
+func genUpsert(table Table, withCache bool, dialect Dialect) (string, string, error) {
+	expressions, expressionValues := genInsertExpressions(table, dialect)
+
+	keySet := collection.NewSet()
+	keyVariableSet := collection.NewSet()
+	keySet.AddStr(table.PrimaryCacheKey.DataKeyExpression)
+	keyVariableSet.AddStr(table.PrimaryCacheKey.KeyLeft)
+	for _, key := range table.UniqueCacheKey {
+		keySet.AddStr(key.DataKeyExpression)
+		keyVariableSet.AddStr(key.KeyLeft)
+	}
+
+	// the cached rows which conflict with data are found before upsert, since the keys of data
+	// may not be the ones of the updated row, such as the unset auto increment id
+	var lookups, oldKeyValues []string
+	for _, key := range append([]Key{table.PrimaryCacheKey}, table.UniqueCacheKey...) {
+		oldKeyValues = append(oldKeyValues, strings.Replace(key.DataKeyRight, "data.", "old.", -1))
+	}
+	for _, key := range conflictKeys(table, dialect) {
+		var values []string
+		for _, field := range key.Fields {
+			values = append(values, "data."+field.Name.ToCamel())
+		}
+
+		method := "FindOneCtx"
+		if key.VarLeft != table.PrimaryCacheKey.VarLeft {
+			method = fmt.Sprintf("FindOneBy%sCtx", key.FieldNameJoin.Camel().With("").Source())
+		}
+		lookups = append(lookups, fmt.Sprintf("%s(ctx, %s)", method, strings.Join(values, ", ")))
+	}
+
+	camel := table.Name.ToCamel()
+	text, err := util.LoadTemplate(category, upsertTemplateFile, template.Upsert)
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := util.With("upsert").
+		Parse(text).
+		Execute(map[string]interface{}{
+			"withCache":             withCache,
+			"upperStartCamelObject": camel,
+			"lowerStartCamelObject": stringx.From(camel).Untitle(),
+			"expression":            strings.Join(expressions, ", "),
+			"expressionValues":      strings.Join(expressionValues, ", "),
+			"conflict":              dialect.ConflictClause(table),
+			"keys":                  strings.Join(keySet.KeysStr(), "\n"),
+			"keyValues":             strings.Join(keyVariableSet.KeysStr(), ", "),
+			"lookups":               lookups,
+			"oldKeyValues":          strings.Join(oldKeyValues, ", "),
+		})
+	if err != nil {
+		return "", "", err
+	}
+
+	// interface method
+	text, err = util.LoadTemplate(category, upsertMethodTemplateFile, template.UpsertMethod)
+	if err != nil {
+		return "", "", err
+	}
+
+	upsertMethodOutput, err := util.With("upsertMethod").Parse(text).Execute(map[string]interface{}{
+		"upperStartCamelObject": camel,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return output.String(), upsertMethodOutput.String(), nil
+}
+
+// onDuplicateKeyClause generates the clause of mysql to update the inserted columns except the primary key
+// on conflicts, which checks all the primary and unique keys
+func onDuplicateKeyClause(table Table, dialect Dialect) string {
+	conflictFields := table.PrimaryKey.Columns()
+	var assignments []string
+	for _, field := range conflictAssignments(table, conflictFields) {
+		name := dialect.Quote(field.Name.Source())
+		assignments = append(assignments, fmt.Sprintf("%s = values(%s)", name, name))
+	}
+	if restore := table.restoreAssignment(dialect); restore != "" {
+		assignments = append(assignments, restore)
+	}
+	if table.VersionField != nil {
+		name := dialect.Quote(table.VersionField.Name.Source())
+		assignments = append(assignments, fmt.Sprintf("%s = %s + 1", name, name))
+	}
+
+	if len(assignments) == 0 {
+		name := dialect.Quote(conflictFields[0].Name.Source())
+		assignments = append(assignments, fmt.Sprintf("%s = %s", name, name))
+	}
+
+	return "on duplicate key update " + strings.Join(assignments, ", ")
+}
+
+// onConflictClause generates the clause to update the inserted columns except the primary key and the
+// conflict target on conflicts, the conflict target is the primary key unless it is auto increment,
+// otherwise the first unique index by name
+func onConflictClause(table Table, dialect Dialect) string {
+	conflictFields := conflictTarget(table)
+	var assignments []string
+	for _, field := range conflictAssignments(table, conflictFields) {
+		name := dialect.Quote(field.Name.Source())
+		assignments = append(assignments, fmt.Sprintf("%s = excluded.%s", name, name))
+	}
+	if restore := table.restoreAssignment(dialect); restore != "" {
+		assignments = append(assignments, restore)
+	}
+	// the bare column is ambiguous with the excluded row in postgresql, so that it's qualified by the table,
+	// whose name is quoted like the one of model
+	if table.VersionField != nil {
+		name := dialect.Quote(table.VersionField.Name.Source())
+		qualifier := dialect.Quote(table.Name.Source())
+		if dialect == PostgreSql {
+			qualifier = fmt.Sprintf(`\"%s\"`, table.Name.Source())
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = %s.%s + 1", name, qualifier, name))
+	}
+
+	var columns []string
+	for _, field := range conflictFields {
+		columns = append(columns, dialect.Quote(field.Name.Source()))
+	}
+	if len(assignments) == 0 {
+		return fmt.Sprintf("on conflict (%s) do nothing", strings.Join(columns, ", "))
+	}
+
+	return fmt.Sprintf("on conflict (%s) do update set %s", strings.Join(columns, ", "),
+		strings.Join(assignments, ", "))
+}
+
+// conflictAssignments returns the inserted fields except the primary key and the conflict target. The
+// version is increased instead of being set, and the soft delete column is reset instead, so that the
+// upserted row always exists afterwards.
+func conflictAssignments(table Table, conflictFields []*parser.Field) []*parser.Field {
+	keySet := collection.NewSet()
+	for _, field := range conflictFields {
+		keySet.AddStr(field.Name.Source())
+	}
+	for _, field := range []*parser.Field{table.SoftDeleteField, table.VersionField} {
+		if field != nil {
+			keySet.AddStr(field.Name.Source())
+		}
+	}
+	for _, field := range table.PrimaryKey.Columns() {
+		keySet.AddStr(field.Name.Source())
+	}
+
+	var fields []*parser.Field
+	for _, field := range insertFields(table) {
+		if !keySet.Contains(field.Name.Source()) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// conflictKeys returns the cache keys of the existing rows which upsert may update, mysql checks all
+// the primary and unique keys, the others check the conflict target only. The auto increment primary
+// key is not inserted, so that it never conflicts.
+func conflictKeys(table Table, dialect Dialect) []Key {
+	var keys []Key
+	if table.PrimaryKey.AutoIncrementColumn() == nil {
+		keys = append(keys, table.PrimaryCacheKey)
+	}
+	keys = append(keys, table.UniqueCacheKey...)
+	if dialect == MySql {
+		return keys
+	}
+
+	var target Join
+	for _, field := range conflictTarget(table) {
+		target = append(target, field.Name.Source())
+	}
+	for _, key := range keys {
+		if key.FieldNameJoin.With(",").Source() == target.With(",").Source() {
+			return []Key{key}
+		}
+	}
+
+	return nil
+}
+
+func conflictTarget(table Table) []*parser.Field {
+	primaryKeys := table.PrimaryKey.Columns()
+	if table.PrimaryKey.AutoIncrementColumn() == nil || len(table.UniqueIndex) == 0 {
+		return primaryKeys
+	}
+
+	var names []string
+	for name := range table.UniqueIndex {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return table.UniqueIndex[names[0]]
+}
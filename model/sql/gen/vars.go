@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/weitrue/goctl/model/sql/template"
@@ -8,13 +9,29 @@ import (
 	"github.com/weitrue/goctl/util/stringx"
 )
 
-func genVars(table Table, withCache, postgreSql bool) (string, error) {
+func genVars(table Table, withCache bool, dialect Dialect) (string, error) {
 	keys := make([]string, 0)
 	keys = append(keys, table.PrimaryCacheKey.VarExpression)
 	for _, v := range table.UniqueCacheKey {
 		keys = append(keys, v.VarExpression)
 	}
 
+	var primaryKeys []string
+	for _, field := range table.PrimaryKey.Columns() {
+		primaryKeys = append(primaryKeys, fmt.Sprintf(`"%s"`, dialect.Quote(field.Name.Source())))
+	}
+
+	var autoIncrementKey string
+	if autoIncrement := table.PrimaryKey.AutoIncrementColumn(); autoIncrement != nil {
+		autoIncrementKey = dialect.Quote(autoIncrement.Name.Source())
+	}
+
+	// the soft delete and version columns are never updated by the data
+	var excludedKeys string
+	for _, key := range table.excludedKeys(dialect) {
+		excludedKeys += fmt.Sprintf(" %s,", key)
+	}
+
 	camel := table.Name.ToCamel()
 	text, err := util.LoadTemplate(category, varTemplateFile, template.Vars)
 	if err != nil {
@@ -23,13 +40,17 @@ func genVars(table Table, withCache, postgreSql bool) (string, error) {
 
 	output, err := util.With("var").Parse(text).
 		GoFmt(true).Execute(map[string]interface{}{
-		"lowerStartCamelObject": stringx.From(camel).Untitle(),
-		"upperStartCamelObject": camel,
-		"cacheKeys":             strings.Join(keys, "\n"),
-		"autoIncrement":         table.PrimaryKey.AutoIncrement,
-		"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source(), postgreSql),
-		"withCache":             withCache,
-		"postgreSql":            postgreSql,
+		"lowerStartCamelObject":    stringx.From(camel).Untitle(),
+		"upperStartCamelObject":    camel,
+		"cacheKeys":                strings.Join(keys, "\n"),
+		"autoIncrement":            len(autoIncrementKey) > 0,
+		"originalPrimaryKey":       dialect.Quote(table.PrimaryKey.Name.Source()),
+		"originalPrimaryKeys":      strings.Join(primaryKeys, ", "),
+		"originalExcludedKeys":     excludedKeys,
+		"originalAutoIncrementKey": autoIncrementKey,
+		"withCache":                withCache,
+		"postgreSql":               dialect == PostgreSql,
+		"dialect":                  dialect.Name(),
 	})
 	if err != nil {
 		return "", err
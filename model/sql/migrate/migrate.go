@@ -0,0 +1,230 @@
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/weitrue/goctl/model/sql/parser"
+)
+
+var (
+	// columnTypeRegex matches the column type at the beginning of column definition like bigint(20) unsigned
+	columnTypeRegex = regexp.MustCompile(`(?i)^\w+(?:\s*\([^()]*\))?(?:\s+(?:unsigned|signed|zerofill)\b)*`)
+	lengthRegex     = regexp.MustCompile(`\s*\([^()]*\)`)
+	// lengthTypeRegex matches the types with a length like varchar(64)
+	lengthTypeRegex = regexp.MustCompile(`^(\w+)\s*\((\d+)\)$`)
+	// integerRanks are the ranks of integer types by the range of values
+	integerRanks = map[string]int{
+		"tinyint":   1,
+		"smallint":  2,
+		"mediumint": 3,
+		"int":       4,
+		"integer":   4,
+		"bigint":    5,
+	}
+)
+
+// Statement describes a statement of migration
+type Statement struct {
+	SQL string
+	// Destructive is true if the data may be lost by the statement, Reason describes the lost data
+	Destructive bool
+	Reason      string
+}
+
+// Diff returns the statements which migrate the tables in from into the ones in to. The tables, the columns and
+// the indexes are matched by name, so the renamed ones are dropped and added again
+func Diff(from, to []*parser.Table) []Statement {
+	fromTables := make(map[string]*parser.Table)
+	for _, table := range from {
+		fromTables[table.Name.Source()] = table
+	}
+
+	toTables := make(map[string]*parser.Table)
+	var list []Statement
+	for _, table := range to {
+		toTables[table.Name.Source()] = table
+		old, ok := fromTables[table.Name.Source()]
+		if !ok {
+			list = append(list, Statement{
+				SQL: strings.TrimSuffix(strings.TrimSpace(table.Statement), ";"),
+			})
+			continue
+		}
+
+		list = append(list, diffTable(old, table)...)
+	}
+
+	for _, table := range from {
+		if _, ok := toTables[table.Name.Source()]; ok {
+			continue
+		}
+
+		list = append(list, Statement{
+			SQL:         fmt.Sprintf("DROP TABLE %s", quote(table.Name.Source())),
+			Destructive: true,
+			Reason:      fmt.Sprintf("drop table %s, all of its rows will be lost", quote(table.Name.Source())),
+		})
+	}
+
+	return list
+}
+
+// Script returns the sql script of statements, the destructive ones are marked by comments
+func Script(statements []Statement) string {
+	var builder strings.Builder
+	for _, each := range statements {
+		if each.Destructive {
+			builder.WriteString(fmt.Sprintf("-- DESTRUCTIVE: %s\n", each.Reason))
+		}
+		builder.WriteString(each.SQL)
+		builder.WriteString(";\n\n")
+	}
+
+	return builder.String()
+}
+
+// diffTable returns the alter table statements in the order which keeps the references valid, the indexes are
+// dropped before the columns are dropped and added after the columns are added
+func diffTable(from, to *parser.Table) []Statement {
+	var (
+		list      []Statement
+		table     = quote(to.Name.Source())
+		fromField = make(map[string]*parser.Field)
+		toField   = make(map[string]*parser.Field)
+		fromIndex = make(map[string]*parser.Index)
+		toIndex   = make(map[string]*parser.Index)
+	)
+	alter := func(format string, args ...interface{}) Statement {
+		return Statement{
+			SQL: fmt.Sprintf("ALTER TABLE %s ", table) + fmt.Sprintf(format, args...),
+		}
+	}
+
+	for _, field := range from.Fields {
+		fromField[field.Name.Source()] = field
+	}
+	for _, field := range to.Fields {
+		toField[field.Name.Source()] = field
+	}
+	for _, index := range from.Indexes {
+		fromIndex[index.Name] = index
+	}
+	for _, index := range to.Indexes {
+		toIndex[index.Name] = index
+	}
+
+	for _, index := range from.Indexes {
+		if each, ok := toIndex[index.Name]; !ok || !strings.EqualFold(each.Definition, index.Definition) {
+			list = append(list, alter("DROP INDEX %s", quote(index.Name)))
+		}
+	}
+
+	for i, field := range to.Fields {
+		name := field.Name.Source()
+		old, ok := fromField[name]
+		if !ok {
+			position := "FIRST"
+			if i > 0 {
+				position = "AFTER " + quote(to.Fields[i-1].Name.Source())
+			}
+			list = append(list, alter("ADD COLUMN %s %s %s", quote(name), field.Definition, position))
+			continue
+		}
+
+		if strings.EqualFold(old.Definition, field.Definition) {
+			continue
+		}
+
+		statement := alter("MODIFY COLUMN %s %s", quote(name), field.Definition)
+		oldType := columnTypeRegex.FindString(old.Definition)
+		newType := columnTypeRegex.FindString(field.Definition)
+		if !widens(oldType, newType) {
+			statement.Destructive = true
+			statement.Reason = fmt.Sprintf("change the type of column %s.%s from %s to %s, the values may be truncated",
+				table, quote(name), oldType, newType)
+		}
+		list = append(list, statement)
+	}
+
+	fromPrimary, toPrimary := primaryColumns(from), primaryColumns(to)
+	if !strings.EqualFold(fromPrimary, toPrimary) {
+		switch {
+		case len(fromPrimary) == 0:
+			list = append(list, alter("ADD PRIMARY KEY (%s)", toPrimary))
+		case len(toPrimary) == 0:
+			list = append(list, alter("DROP PRIMARY KEY"))
+		default:
+			// the primary key is replaced in a statement, an auto increment column can't be out of a key
+			list = append(list, alter("DROP PRIMARY KEY, ADD PRIMARY KEY (%s)", toPrimary))
+		}
+	}
+
+	for _, field := range from.Fields {
+		name := field.Name.Source()
+		if _, ok := toField[name]; ok {
+			continue
+		}
+
+		statement := alter("DROP COLUMN %s", quote(name))
+		statement.Destructive = true
+		statement.Reason = fmt.Sprintf("drop column %s.%s, its values will be lost", table, quote(name))
+		list = append(list, statement)
+	}
+
+	for _, index := range to.Indexes {
+		if each, ok := fromIndex[index.Name]; !ok || !strings.EqualFold(each.Definition, index.Definition) {
+			list = append(list, alter("ADD %s", index.Definition))
+		}
+	}
+
+	return list
+}
+
+// widens returns true if the values of the old type can be kept by the new type, such as varchar(64) to varchar(128)
+// and int to bigint, the other changes of types are treated as lossy
+func widens(oldType, newType string) bool {
+	oldType, newType = strings.ToLower(oldType), strings.ToLower(newType)
+	if oldType == newType {
+		return true
+	}
+
+	oldMatch, newMatch := lengthTypeRegex.FindStringSubmatch(oldType), lengthTypeRegex.FindStringSubmatch(newType)
+	if oldMatch != nil && newMatch != nil && oldMatch[1] == newMatch[1] && integerRanks[oldMatch[1]] == 0 {
+		oldLength, _ := strconv.Atoi(oldMatch[2])
+		newLength, _ := strconv.Atoi(newMatch[2])
+		return newLength >= oldLength
+	}
+
+	// the display widths of integers don't matter
+	oldFields := strings.Fields(lengthRegex.ReplaceAllString(oldType, ""))
+	newFields := strings.Fields(lengthRegex.ReplaceAllString(newType, ""))
+	if len(oldFields) == 0 || len(newFields) == 0 {
+		return false
+	}
+
+	oldRank, newRank := integerRanks[oldFields[0]], integerRanks[newFields[0]]
+	if oldRank == 0 || newRank == 0 {
+		return false
+	}
+
+	return newRank >= oldRank && strings.Join(oldFields[1:], " ") == strings.Join(newFields[1:], " ")
+}
+
+// primaryColumns returns the quoted columns of primary key joined by comma, it's empty without primary key
+func primaryColumns(table *parser.Table) string {
+	var list []string
+	for _, field := range table.PrimaryKey.Columns() {
+		if len(field.Name.Source()) > 0 {
+			list = append(list, quote(field.Name.Source()))
+		}
+	}
+
+	return strings.Join(list, ", ")
+}
+
+func quote(name string) string {
+	return "`" + name + "`"
+}
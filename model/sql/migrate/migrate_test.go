@@ -0,0 +1,110 @@
+package migrate
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/model/sql/parser"
+)
+
+const (
+	oldSql = "CREATE TABLE `user` (\n  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'name',\n  `nickname` varchar(64) NOT NULL DEFAULT '',\n" +
+		"  `age` int NOT NULL DEFAULT 0,\n  `mobile` char(11) NOT NULL DEFAULT '',\n  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `mobile_index` (`mobile`),\n  KEY `name_index` (`name`)\n) ENGINE=InnoDB;\n\n" +
+		"CREATE TABLE `legacy` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;\n"
+	newSql = "CREATE TABLE `user` (\n  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(128) NOT NULL DEFAULT '' COMMENT 'name',\n  `age` tinyint NOT NULL DEFAULT 0,\n" +
+		"  `email` varchar(128) NOT NULL DEFAULT '',\n  `mobile` char(11) NOT NULL DEFAULT '',\n  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `mobile_index` (`mobile`, `email`),\n  KEY `name_index` (`name`)\n) ENGINE=InnoDB;\n\n" +
+		"CREATE TABLE `tag` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;\n"
+)
+
+func TestDiff(t *testing.T) {
+	from := parse(t, oldSql)
+	to := parse(t, newSql)
+
+	assert.Equal(t, 0, len(Diff(from, from)))
+
+	up := Diff(from, to)
+	assert.Equal(t, []string{
+		"ALTER TABLE `user` DROP INDEX `mobile_index`",
+		"ALTER TABLE `user` MODIFY COLUMN `name` varchar(128) NOT NULL DEFAULT '' COMMENT 'name'",
+		"ALTER TABLE `user` MODIFY COLUMN `age` tinyint NOT NULL DEFAULT 0",
+		"ALTER TABLE `user` ADD COLUMN `email` varchar(128) NOT NULL DEFAULT '' AFTER `age`",
+		"ALTER TABLE `user` DROP COLUMN `nickname`",
+		"ALTER TABLE `user` ADD UNIQUE KEY `mobile_index` (`mobile`, `email`)",
+		"CREATE TABLE `tag` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB",
+		"DROP TABLE `legacy`",
+	}, statements(up))
+	assert.Equal(t, []bool{false, false, true, false, true, false, false, true}, destructive(up))
+
+	down := Diff(to, from)
+	assert.Equal(t, []string{
+		"ALTER TABLE `user` DROP INDEX `mobile_index`",
+		"ALTER TABLE `user` MODIFY COLUMN `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'name'",
+		"ALTER TABLE `user` ADD COLUMN `nickname` varchar(64) NOT NULL DEFAULT '' AFTER `name`",
+		"ALTER TABLE `user` MODIFY COLUMN `age` int NOT NULL DEFAULT 0",
+		"ALTER TABLE `user` DROP COLUMN `email`",
+		"ALTER TABLE `user` ADD UNIQUE KEY `mobile_index` (`mobile`)",
+		"CREATE TABLE `legacy` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB",
+		"DROP TABLE `tag`",
+	}, statements(down))
+	assert.Equal(t, []bool{false, true, false, false, true, false, false, true}, destructive(down))
+
+	script := Script(up)
+	assert.Contains(t, script, "-- DESTRUCTIVE: drop column `user`.`nickname`, its values will be lost\n"+
+		"ALTER TABLE `user` DROP COLUMN `nickname`;\n")
+	assert.Contains(t, script, "-- DESTRUCTIVE: drop table `legacy`, all of its rows will be lost\nDROP TABLE `legacy`;\n")
+}
+
+func TestDiffPrimaryKey(t *testing.T) {
+	from := parse(t, "CREATE TABLE `tag` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `tenant_id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;")
+	to := parse(t, "CREATE TABLE `tag` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `tenant_id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`tenant_id`, `id`)\n) ENGINE=InnoDB;")
+
+	assert.Equal(t, []string{"ALTER TABLE `tag` DROP PRIMARY KEY, ADD PRIMARY KEY (`tenant_id`, `id`)"},
+		statements(Diff(from, to)))
+}
+
+func TestWidens(t *testing.T) {
+	assert.True(t, widens("varchar(64)", "VARCHAR(128)"))
+	assert.True(t, widens("int(11)", "bigint(20)"))
+	assert.True(t, widens("int unsigned", "bigint unsigned"))
+	assert.False(t, widens("varchar(128)", "varchar(64)"))
+	assert.False(t, widens("bigint", "int"))
+	assert.False(t, widens("int unsigned", "bigint"))
+	assert.False(t, widens("varchar(64)", "text"))
+	assert.False(t, widens("", "int"))
+}
+
+func parse(t *testing.T, ddl string) []*parser.Table {
+	filename := filepath.Join(t.TempDir(), "ddl.sql")
+	err := ioutil.WriteFile(filename, []byte(ddl), 0o644)
+	assert.Nil(t, err)
+
+	tables, err := parser.Parse(filename, "")
+	assert.Nil(t, err)
+	return tables
+}
+
+func statements(list []Statement) []string {
+	var ret []string
+	for _, each := range list {
+		ret = append(ret, each.SQL)
+	}
+
+	return ret
+}
+
+func destructive(list []Statement) []bool {
+	var ret []bool
+	for _, each := range list {
+		ret = append(ret, each.Destructive)
+	}
+
+	return ret
+}
@@ -26,6 +26,7 @@ type (
 	DbColumn struct {
 		Name            string      `db:"COLUMN_NAME"`
 		DataType        string      `db:"DATA_TYPE"`
+		ColumnType      string      `db:"COLUMN_TYPE"`
 		Extra           string      `db:"EXTRA"`
 		Comment         string      `db:"COLUMN_COMMENT"`
 		ColumnDefault   interface{} `db:"COLUMN_DEFAULT"`
@@ -54,7 +55,10 @@ type (
 		Columns []*Column
 		// Primary key not included
 		UniqueIndex map[string][]*Column
-		PrimaryKey  *Column
+		// PrimaryKey is the first column of PrimaryKeys
+		PrimaryKey *Column
+		// PrimaryKeys are the columns of primary key in order, a composite primary key has more than one
+		PrimaryKeys []*Column
 		NormalIndex map[string][]*Column
 	}
 
@@ -87,7 +91,7 @@ func (m *InformationSchemaModel) GetAllTables(database string) ([]string, error)
 
 // FindColumns return columns in specified database and table
 func (m *InformationSchemaModel) FindColumns(db, table string) (*ColumnData, error) {
-	querySql := `SELECT c.COLUMN_NAME,c.DATA_TYPE,EXTRA,c.COLUMN_COMMENT,c.COLUMN_DEFAULT,c.IS_NULLABLE,c.ORDINAL_POSITION from COLUMNS c WHERE c.TABLE_SCHEMA = ? and c.TABLE_NAME = ? `
+	querySql := `SELECT c.COLUMN_NAME,c.DATA_TYPE,c.COLUMN_TYPE,EXTRA,c.COLUMN_COMMENT,c.COLUMN_DEFAULT,c.IS_NULLABLE,c.ORDINAL_POSITION from COLUMNS c WHERE c.TABLE_SCHEMA = ? and c.TABLE_NAME = ? `
 	var reply []*DbColumn
 	err := m.conn.QueryRowsPartial(&reply, querySql, db, table)
 	if err != nil {
@@ -163,11 +167,11 @@ func (c *ColumnData) Convert() (*Table, error) {
 		return nil, fmt.Errorf("db:%s, table:%s, missing primary key", c.Db, c.Table)
 	}
 
-	if len(primaryColumns) > 1 {
-		return nil, fmt.Errorf("db:%s, table:%s, joint primary key is not supported", c.Db, c.Table)
-	}
-
+	sort.SliceStable(primaryColumns, func(i, j int) bool {
+		return primaryColumns[i].Index.SeqInIndex < primaryColumns[j].Index.SeqInIndex
+	})
 	table.PrimaryKey = primaryColumns[0]
+	table.PrimaryKeys = primaryColumns
 	for indexName, columns := range m {
 		if indexName == indexPri {
 			continue
@@ -131,6 +131,7 @@ func (m *PostgreSqlModel) getColumns(schema, table string, in []*PostgreColumn)
 					DbColumn: &DbColumn{
 						Name:            e.Field.String,
 						DataType:        m.convertPostgreSqlTypeIntoMysqlType(e.Type.String),
+						ColumnType:      e.Type.String,
 						Extra:           extra,
 						Comment:         e.Comment.String,
 						ColumnDefault:   dft,
@@ -145,6 +146,7 @@ func (m *PostgreSqlModel) getColumns(schema, table string, in []*PostgreColumn)
 				DbColumn: &DbColumn{
 					Name:            e.Field.String,
 					DataType:        m.convertPostgreSqlTypeIntoMysqlType(e.Type.String),
+					ColumnType:      e.Type.String,
 					Extra:           extra,
 					Comment:         e.Comment.String,
 					ColumnDefault:   dft,
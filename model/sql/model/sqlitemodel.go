@@ -0,0 +1,275 @@
+package model
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// the declared types of sqlite are free, the known ones are converted into mysql types and the others
+// are converted by the type affinities, see https://www.sqlite.org/datatype3.html
+var (
+	s2m = map[string]string{
+		"int2":             "smallint",
+		"int8":             "bigint",
+		"real":             "double",
+		"double precision": "double",
+		"numeric":          "decimal",
+		"clob":             "text",
+	}
+	sqliteMysqlTypes = map[string]bool{
+		"bool":      true,
+		"boolean":   true,
+		"tinyint":   true,
+		"smallint":  true,
+		"mediumint": true,
+		"int":       true,
+		"integer":   true,
+		"bigint":    true,
+		"float":     true,
+		"double":    true,
+		"decimal":   true,
+		"date":      true,
+		"datetime":  true,
+		"timestamp": true,
+		"time":      true,
+		"year":      true,
+		"char":      true,
+		"varchar":   true,
+		"text":      true,
+		"json":      true,
+		"blob":      true,
+	}
+	sqliteTypeLengthRegex = regexp.MustCompile(`\s*\(.*\)`)
+)
+
+// SqliteModel gets table information from sqlite_master and the table-valued pragma functions
+type SqliteModel struct {
+	conn sqlx.SqlConn
+}
+
+// SqliteColumn describes a column of pragma table_info
+type SqliteColumn struct {
+	Cid       int            `db:"cid"`
+	Name      string         `db:"name"`
+	Type      string         `db:"type"`
+	NotNull   bool           `db:"notnull"`
+	DfltValue sql.NullString `db:"dflt_value"`
+	// Pk is the position of column in primary key starting from 1, 0 if it's not in primary key
+	Pk int `db:"pk"`
+}
+
+// SqliteIndex describes an index of pragma index_list
+type SqliteIndex struct {
+	Name   string `db:"name"`
+	Unique bool   `db:"unique"`
+	// Origin is c for the index created by CREATE INDEX, u for UNIQUE constraint and pk for PRIMARY KEY
+	Origin  string `db:"origin"`
+	Partial bool   `db:"partial"`
+}
+
+// SqliteIndexColumn describes a column of pragma index_info
+type SqliteIndexColumn struct {
+	SeqNo int `db:"seqno"`
+	// Name is empty for the expressions
+	Name string `db:"name"`
+}
+
+// NewSqliteModel creates an instance and return
+func NewSqliteModel(conn sqlx.SqlConn) *SqliteModel {
+	return &SqliteModel{
+		conn: conn,
+	}
+}
+
+// GetAllTables selects all tables except the internal ones of sqlite
+func (m *SqliteModel) GetAllTables() ([]string, error) {
+	query := `select name from sqlite_master where type = 'table' and name not like 'sqlite_%' order by name`
+	var tables []string
+	err := m.conn.QueryRows(&tables, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// FindColumns return columns in specified table, db is only the name of the returned ColumnData
+func (m *SqliteModel) FindColumns(db, table string) (*ColumnData, error) {
+	querySql := `select cid, name, type, "notnull", dflt_value, pk from pragma_table_info(?) order by cid`
+	var reply []*SqliteColumn
+	err := m.conn.QueryRowsPartial(&reply, querySql, table)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := m.getColumns(table, reply)
+	if err != nil {
+		return nil, err
+	}
+
+	var columnData ColumnData
+	columnData.Db = db
+	columnData.Table = table
+	columnData.Columns = list
+	return &columnData, nil
+}
+
+func (m *SqliteModel) getColumns(table string, in []*SqliteColumn) ([]*Column, error) {
+	index, err := m.getIndex(table)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryCount int
+	for _, e := range in {
+		if e.Pk > 0 {
+			primaryCount++
+			index[e.Name] = append(index[e.Name], &DbIndex{
+				IndexName:  indexPri,
+				SeqInIndex: e.Pk,
+			})
+		}
+	}
+
+	var list []*Column
+	for _, e := range in {
+		var dft interface{}
+		if len(e.DfltValue.String) > 0 {
+			dft = e.DfltValue
+		}
+
+		isNullAble := "YES"
+		if e.NotNull || e.Pk > 0 {
+			isNullAble = "NO"
+		}
+
+		// the single INTEGER PRIMARY KEY is an alias of rowid, which is auto increment with or
+		// without AUTOINCREMENT
+		var extra string
+		if e.Pk > 0 && primaryCount == 1 && strings.EqualFold(e.Type, "integer") {
+			extra = "auto_increment"
+		}
+
+		column := &DbColumn{
+			Name:            e.Name,
+			DataType:        m.convertSqliteTypeIntoMysqlType(e.Type),
+			ColumnType:      strings.ToLower(e.Type),
+			Extra:           extra,
+			ColumnDefault:   dft,
+			IsNullAble:      isNullAble,
+			OrdinalPosition: e.Cid + 1,
+		}
+		if len(index[e.Name]) > 0 {
+			for _, i := range index[e.Name] {
+				list = append(list, &Column{
+					DbColumn: column,
+					Index:    i,
+				})
+			}
+		} else {
+			list = append(list, &Column{
+				DbColumn: column,
+			})
+		}
+	}
+
+	return list, nil
+}
+
+func (m *SqliteModel) convertSqliteTypeIntoMysqlType(in string) string {
+	tp := strings.ToLower(strings.TrimSpace(sqliteTypeLengthRegex.ReplaceAllString(in, "")))
+	if r, ok := s2m[tp]; ok {
+		return r
+	}
+
+	if sqliteMysqlTypes[tp] {
+		return tp
+	}
+
+	switch {
+	case strings.Contains(tp, "int"):
+		return "bigint"
+	case strings.Contains(tp, "char"), strings.Contains(tp, "clob"), strings.Contains(tp, "text"):
+		return "text"
+	case strings.Contains(tp, "blob"), len(tp) == 0:
+		return "blob"
+	case strings.Contains(tp, "real"), strings.Contains(tp, "floa"), strings.Contains(tp, "doub"):
+		return "double"
+	default:
+		return "decimal"
+	}
+}
+
+// getIndex returns the indexes keyed by columns except the primary key, which is described by table_info
+func (m *SqliteModel) getIndex(table string) (map[string][]*DbIndex, error) {
+	indexes, err := m.FindIndex(table)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string][]*DbIndex)
+	for _, e := range indexes {
+		if e.Origin == "pk" {
+			continue
+		}
+
+		columns, err := m.FindIndexColumns(e.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// the indexes of expressions can't be queried by columns
+		var expression bool
+		for _, column := range columns {
+			if len(column.Name) == 0 {
+				expression = true
+			}
+		}
+		if expression {
+			continue
+		}
+
+		// the partial unique indexes don't guarantee the uniqueness of all rows
+		nonUnique := 0
+		if !e.Unique || e.Partial {
+			nonUnique = 1
+		}
+
+		for _, column := range columns {
+			index[column.Name] = append(index[column.Name], &DbIndex{
+				IndexName:  e.Name,
+				NonUnique:  nonUnique,
+				SeqInIndex: column.SeqNo + 1,
+			})
+		}
+	}
+
+	return index, nil
+}
+
+// FindIndex finds the indexes of table
+func (m *SqliteModel) FindIndex(table string) ([]*SqliteIndex, error) {
+	querySql := `select name, "unique", origin, partial from pragma_index_list(?) order by name`
+	var reply []*SqliteIndex
+	err := m.conn.QueryRowsPartial(&reply, querySql, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// FindIndexColumns finds the columns of index in order
+func (m *SqliteModel) FindIndexColumns(index string) ([]*SqliteIndexColumn, error) {
+	querySql := `select seqno, name from pragma_index_info(?) order by seqno`
+	var reply []*SqliteIndexColumn
+	err := m.conn.QueryRowsPartial(&reply, querySql, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
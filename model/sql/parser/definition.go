@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// indexDefinitionRegex matches the key definitions of create table, the primary keys, the foreign keys and
+	// the checks are matched to be told from the columns
+	indexDefinitionRegex = regexp.MustCompile("(?is)^(?:constraint(?:\\s+`?(\\w+)`?)?\\s+)?(primary\\s+key|unique(?:\\s+(?:key|index))?|" +
+		"(?:fulltext|spatial)(?:\\s+(?:key|index))?|key|index|foreign\\s+key|check)\\b\\s*(.*)$")
+	// columnKeyRegex matches the keys declared in column definitions, KEY is the primary key there
+	columnKeyRegex = regexp.MustCompile(`(?i)\s+(?:primary\s+key|(unique)(?:\s+key)?|key)\b`)
+	spacesRegex    = regexp.MustCompile(`\s+`)
+)
+
+type tableDefinition struct {
+	statement string
+	columns   map[string]string
+	indexes   []*Index
+}
+
+// parseDefinitions returns the statements, the column definitions and the indexes of create table by table name
+func parseDefinitions(content string) map[string]*tableDefinition {
+	ret := make(map[string]*tableDefinition)
+	tables := createTableRegex.FindAllStringSubmatchIndex(content, -1)
+	for i, loc := range tables {
+		end := len(content)
+		if i+1 < len(tables) {
+			end = tables[i+1][0]
+		}
+
+		definitions, n := splitDefinitions(content[loc[1]:end])
+		definition := &tableDefinition{
+			statement: strings.TrimSpace(content[loc[0] : loc[1]+n]),
+			columns:   make(map[string]string),
+		}
+		for _, each := range definitions {
+			definition.add(each)
+		}
+
+		ret[trimTableName(content[loc[2]:loc[3]])] = definition
+	}
+
+	return ret
+}
+
+// splitDefinitions splits the definitions in the parentheses of create table by the commas out of the parentheses
+// and the quoted strings, n is the length of the statement till the semicolon
+func splitDefinitions(body string) (definitions []string, n int) {
+	masked := maskQuoted(body)
+	start := strings.IndexByte(masked, '(')
+	if start < 0 {
+		return nil, len(body)
+	}
+
+	depth := 0
+	last := start + 1
+	for i := start; i < len(masked); i++ {
+		switch masked[i] {
+		case '(':
+			depth++
+		case ',':
+			if depth == 1 {
+				definitions = append(definitions, strings.TrimSpace(body[last:i]))
+				last = i + 1
+			}
+		case ')':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			definitions = append(definitions, strings.TrimSpace(body[last:i]))
+			if end := strings.IndexByte(masked[i:], ';'); end >= 0 {
+				return definitions, i + end + 1
+			}
+
+			return definitions, len(body)
+		}
+	}
+
+	return definitions, len(body)
+}
+
+func (t *tableDefinition) add(definition string) {
+	if len(definition) == 0 {
+		return
+	}
+
+	match := indexDefinitionRegex.FindStringSubmatch(definition)
+	if match == nil {
+		t.addColumn(definition)
+		return
+	}
+
+	kind := strings.ToUpper(strings.Fields(match[2])[0])
+	switch kind {
+	case "PRIMARY", "FOREIGN", "CHECK":
+		return
+	case "INDEX":
+		kind = "KEY"
+	}
+	if kind != "KEY" {
+		kind += " KEY"
+	}
+
+	rest := match[3]
+	var name string
+	if !strings.HasPrefix(rest, "(") && !strings.HasPrefix(strings.ToLower(rest), "using") {
+		index := strings.IndexAny(rest, " \t\r\n(")
+		if index < 0 {
+			return
+		}
+
+		name = strings.Trim(rest[:index], "`\"")
+		rest = strings.TrimSpace(rest[index:])
+	}
+
+	if len(name) == 0 && kind == "UNIQUE KEY" {
+		name = match[1]
+	}
+
+	// the name of index is the first column by default
+	if len(name) == 0 {
+		columns := rest[strings.IndexByte(rest, '(')+1:]
+		fields := strings.Fields(indexPrefixRegex.ReplaceAllString(strings.Split(columns, ",")[0], ""))
+		if len(fields) == 0 {
+			return
+		}
+
+		name = strings.Trim(strings.TrimSuffix(fields[0], ")"), "`\"")
+	}
+
+	t.indexes = append(t.indexes, &Index{
+		Name:       name,
+		Unique:     kind == "UNIQUE KEY",
+		Definition: fmt.Sprintf("%s `%s` %s", kind, name, normalizeSpace(rest)),
+	})
+}
+
+func (t *tableDefinition) addColumn(definition string) {
+	index := strings.IndexAny(definition, " \t\r\n")
+	if index < 0 {
+		return
+	}
+
+	name := strings.Trim(definition[:index], "`\"")
+	definition = definition[index:]
+
+	// the keys are removed from the column definitions, the primary keys are described by Table.PrimaryKey and the
+	// unique keys are added into the indexes
+	var (
+		builder strings.Builder
+		last    int
+		unique  bool
+	)
+	for _, loc := range columnKeyRegex.FindAllStringSubmatchIndex(maskQuoted(definition), -1) {
+		builder.WriteString(definition[last:loc[0]])
+		last = loc[1]
+		if loc[2] >= 0 {
+			unique = true
+		}
+	}
+	builder.WriteString(definition[last:])
+
+	t.columns[name] = normalizeSpace(builder.String())
+	if unique {
+		t.indexes = append(t.indexes, &Index{
+			Name:       name,
+			Unique:     true,
+			Definition: fmt.Sprintf("UNIQUE KEY `%s` (`%s`)", name, name),
+		})
+	}
+}
+
+// maskQuoted replaces the characters in the quoted strings with x, so that the keywords and the separators
+// are matched out of them, the length of string is not changed
+func maskQuoted(s string) string {
+	return quotedRegex.ReplaceAllStringFunc(s, func(quoted string) string {
+		return "'" + strings.Repeat("x", len(quoted)-2) + "'"
+	})
+}
+
+// normalizeSpace replaces the spaces out of the quoted strings with a single space
+func normalizeSpace(s string) string {
+	var (
+		builder strings.Builder
+		last    int
+	)
+	for _, loc := range spacesRegex.FindAllStringIndex(maskQuoted(s), -1) {
+		builder.WriteString(s[last:loc[0]])
+		builder.WriteByte(' ')
+		last = loc[1]
+	}
+	builder.WriteString(s[last:])
+
+	return strings.TrimSpace(builder.String())
+}
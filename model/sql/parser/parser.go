@@ -2,7 +2,9 @@ package parser
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -17,6 +19,18 @@ import (
 
 const timeImport = "time.Time"
 
+var (
+	createTableRegex = regexp.MustCompile("(?i)create\\s+table\\s+(?:if\\s+not\\s+exists\\s+)?([`\"\\w.]+)")
+	// normalIndexRegex matches the normal index declarations like KEY `name_index` (`name`), which are
+	// dropped by the ddl parser
+	normalIndexRegex = regexp.MustCompile("(?i)[(,]\\s*(?:key|index)\\b\\s*(?:`?(\\w+)`?\\s*)?(?:using\\s+\\w+\\s*)?\\(((?:[^()]|\\([^()]*\\))*)\\)")
+	indexPrefixRegex = regexp.MustCompile(`\(\d+\)`)
+	// columnTypeRegex matches the column definitions like `id` bigint(20) unsigned, the full column types
+	// are dropped by the ddl parser
+	columnTypeRegex = regexp.MustCompile("(?i)[(,]\\s*[`\"]?(\\w+)[`\"]?\\s+(\\w+(?:\\s*\\([^()]*\\))?(?:\\s+(?:unsigned|signed|zerofill)\\b)*)")
+	quotedRegex     = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+)
+
 type (
 	// Table describes a mysql table
 	Table struct {
@@ -24,13 +38,31 @@ type (
 		Db          stringx.String
 		PrimaryKey  Primary
 		UniqueIndex map[string][]*Field
+		NormalIndex map[string][]*Field
 		Fields      []*Field
+		// Indexes are the indexes except primary key with the names in ddl, the names of UniqueIndex
+		// are generated by columns. It's only set by Parse
+		Indexes []*Index
+		// Statement is the create table statement in ddl, it's only set by Parse
+		Statement string
 	}
 
-	// Primary describes a primary key
+	// Index describes an index declared in ddl
+	Index struct {
+		Name   string
+		Unique bool
+		// Definition is the normalized index definition like UNIQUE KEY `name_index` (`name`)
+		Definition string
+	}
+
+	// Primary describes a primary key, Field is the first column of a composite primary key
 	Primary struct {
 		Field
 		AutoIncrement bool
+		// Fields describes the columns of primary key in order
+		Fields []*Field
+		// AutoIncrementField describes the auto increment column of primary key
+		AutoIncrementField *Field
 	}
 
 	// Field describes a table field
@@ -40,14 +72,44 @@ type (
 		Comment         string
 		SeqInIndex      int
 		OrdinalPosition int
+		// Definition is the column definition in ddl without name and keys like varchar(255) NOT NULL DEFAULT '',
+		// it's only set by Parse
+		Definition string
 	}
 
 	// KeyType types alias of int
 	KeyType int
+
+	// Option defines a function to customize the conversion of tables
+	Option func(o *options)
+
+	options struct {
+		types *converter.TypeConverter
+	}
 )
 
+// WithTypeConverter converts the column types into golang types by c
+func WithTypeConverter(c *converter.TypeConverter) Option {
+	return func(o *options) {
+		o.types = c
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.types == nil {
+		o.types = converter.NewTypeConverter(nil)
+	}
+
+	return o
+}
+
 // Parse parses ddl into golang structure
-func Parse(filename, database string) ([]*Table, error) {
+func Parse(filename, database string, opts ...Option) ([]*Table, error) {
+	o := newOptions(opts)
 	p := parser.NewParser()
 	tables, err := p.From(filename)
 	if err != nil {
@@ -58,23 +120,39 @@ func Parse(filename, database string) ([]*Table, error) {
 		return strings.Join(column, "_")
 	}
 
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	normalIndexes := parseNormalIndexes(string(content))
+	columnTypes := parseColumnTypes(string(content))
+	definitions := parseDefinitions(string(content))
 	prefix := filepath.Base(filename)
 	var list []*Table
 	for _, e := range tables {
 		columns := e.Columns
+		tableName := trimTableName(e.Name)
 
 		var (
 			primaryColumnSet = collection.NewSet()
 
-			primaryColumn string
-			uniqueKeyMap  = make(map[string][]string)
-			normalKeyMap  = make(map[string][]string)
+			primaryColumns []string
+			uniqueKeyMap   = make(map[string][]string)
+			normalKeyMap   = make(map[string][]string)
 		)
 
+		addPrimaryColumn := func(column string) {
+			if !primaryColumnSet.Contains(column) {
+				primaryColumnSet.AddStr(column)
+				primaryColumns = append(primaryColumns, column)
+			}
+		}
+
 		for _, column := range columns {
 			if column.Constraint != nil {
 				if column.Constraint.Primary {
-					primaryColumnSet.AddStr(column.Name)
+					addPrimaryColumn(column.Name)
 				}
 
 				if column.Constraint.Unique {
@@ -90,13 +168,8 @@ func Parse(filename, database string) ([]*Table, error) {
 		}
 
 		for _, e := range e.Constraints {
-			if len(e.ColumnPrimaryKey) > 1 {
-				return nil, fmt.Errorf("%s: unexpected join primary key", prefix)
-			}
-
-			if len(e.ColumnPrimaryKey) == 1 {
-				primaryColumn = e.ColumnPrimaryKey[0]
-				primaryColumnSet.AddStr(e.ColumnPrimaryKey[0])
+			for _, column := range e.ColumnPrimaryKey {
+				addPrimaryColumn(column)
 			}
 
 			if len(e.ColumnUniqueKey) > 0 {
@@ -107,23 +180,30 @@ func Parse(filename, database string) ([]*Table, error) {
 			}
 		}
 
-		if primaryColumnSet.Count() > 1 {
-			return nil, fmt.Errorf("%s: unexpected join primary key", prefix)
+		for indexName, columns := range normalIndexes[tableName] {
+			normalKeyMap[indexName] = columns
 		}
 
-		primaryKey, fieldM, err := convertColumns(columns, primaryColumn)
+		primaryKey, fieldM, err := convertColumns(tableName, columns, primaryColumns, columnTypes[tableName], o.types)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s: %w", prefix, err)
 		}
 
+		definition := definitions[tableName]
 		var fields []*Field
 		// sort
 		for _, c := range columns {
 			field, ok := fieldM[c.Name]
 			if ok {
+				if definition != nil {
+					field.Definition = definition.columns[c.Name]
+				}
 				fields = append(fields, field)
 			}
 		}
+		if len(primaryKey.Fields) > 0 {
+			primaryKey.Field = *primaryKey.Fields[0]
+		}
 
 		var (
 			uniqueIndex = make(map[string][]*Field)
@@ -137,25 +217,112 @@ func Parse(filename, database string) ([]*Table, error) {
 		}
 
 		for indexName, each := range normalKeyMap {
+			var list []*Field
 			for _, columnName := range each {
-				normalIndex[indexName] = append(normalIndex[indexName], fieldM[columnName])
+				if field, ok := fieldM[columnName]; ok {
+					list = append(list, field)
+				}
+			}
+			if len(list) != len(each) {
+				console.NewColorConsole().Warning("table %s: index %s is ignored, unknown columns %s", e.Name, indexName, strings.Join(each, ","))
+				continue
 			}
+
+			normalIndex[indexName] = list
 		}
 
 		checkDuplicateUniqueIndex(uniqueIndex, e.Name)
 
-		list = append(list, &Table{
+		table := &Table{
 			Name:        stringx.From(e.Name),
 			Db:          stringx.From(database),
 			PrimaryKey:  primaryKey,
 			UniqueIndex: uniqueIndex,
+			NormalIndex: normalIndex,
 			Fields:      fields,
-		})
+		}
+		if definition != nil {
+			table.Indexes = definition.indexes
+			table.Statement = definition.statement
+		}
+		list = append(list, table)
 	}
 
 	return list, nil
 }
 
+// parseNormalIndexes returns the columns of normal indexes by index name and table name, the quoted
+// strings like comments are skipped
+func parseNormalIndexes(content string) map[string]map[string][]string {
+	ret := make(map[string]map[string][]string)
+	eachCreateTable(content, func(table, body string) {
+		indexes := make(map[string][]string)
+		for _, match := range normalIndexRegex.FindAllStringSubmatch(quotedRegex.ReplaceAllString(body, "''"), -1) {
+			var columns []string
+			for _, column := range strings.Split(indexPrefixRegex.ReplaceAllString(match[2], ""), ",") {
+				fields := strings.Fields(column)
+				if len(fields) == 0 {
+					continue
+				}
+
+				columns = append(columns, strings.Trim(fields[0], "`\""))
+			}
+			if len(columns) == 0 {
+				continue
+			}
+
+			indexName := match[1]
+			if len(indexName) == 0 {
+				indexName = strings.Join(columns, "_")
+			}
+			indexes[indexName] = columns
+		}
+
+		ret[table] = indexes
+	})
+
+	return ret
+}
+
+// parseColumnTypes returns the full column types like tinyint(1) unsigned by column name and table name
+func parseColumnTypes(content string) map[string]map[string]string {
+	ret := make(map[string]map[string]string)
+	eachCreateTable(content, func(table, body string) {
+		types := make(map[string]string)
+		for _, match := range columnTypeRegex.FindAllStringSubmatch(quotedRegex.ReplaceAllString(body, "''"), -1) {
+			if _, ok := types[match[1]]; !ok {
+				types[match[1]] = match[2]
+			}
+		}
+
+		ret[table] = types
+	})
+
+	return ret
+}
+
+// eachCreateTable calls fn with the table name and the statement body of each create table statement
+func eachCreateTable(content string, fn func(table, body string)) {
+	tables := createTableRegex.FindAllStringSubmatchIndex(content, -1)
+	for i, loc := range tables {
+		end := len(content)
+		if i+1 < len(tables) {
+			end = tables[i+1][0]
+		}
+
+		fn(trimTableName(content[loc[2]:loc[3]]), content[loc[1]:end])
+	}
+}
+
+func trimTableName(name string) string {
+	name = strings.Trim(name, "`\"")
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		name = strings.Trim(name[index+1:], "`\"")
+	}
+
+	return name
+}
+
 func checkDuplicateUniqueIndex(uniqueIndex map[string][]*Field, tableName string) {
 	log := console.NewColorConsole()
 	uniqueSet := collection.NewSet()
@@ -176,13 +343,20 @@ func checkDuplicateUniqueIndex(uniqueIndex map[string][]*Field, tableName string
 	}
 }
 
-func convertColumns(columns []*parser.Column, primaryColumn string) (Primary, map[string]*Field, error) {
+func convertColumns(table string, columns []*parser.Column, primaryColumns []string, columnTypes map[string]string,
+	types *converter.TypeConverter) (Primary, map[string]*Field, error) {
 	var (
-		primaryKey Primary
-		fieldM     = make(map[string]*Field)
-		log        = console.NewColorConsole()
+		primaryKey       Primary
+		fieldM           = make(map[string]*Field)
+		autoIncrementSet = collection.NewSet()
+		log              = console.NewColorConsole()
 	)
 
+	primaryColumnSet := collection.NewSet()
+	for _, column := range primaryColumns {
+		primaryColumnSet.AddStr(column)
+	}
+
 	for _, column := range columns {
 		if column == nil {
 			continue
@@ -200,19 +374,23 @@ func convertColumns(columns []*parser.Column, primaryColumn string) (Primary, ma
 				isDefaultNull = false
 			}
 
-			if column.Name == primaryColumn {
+			if primaryColumnSet.Contains(column.Name) {
 				isDefaultNull = false
 			}
 		}
 
-		dataType, err := converter.ConvertDataType(column.DataType.Type(), isDefaultNull)
+		dataType, err := types.ConvertDataType(converter.Column{
+			Table: table,
+			Name:  column.Name,
+			Type:  columnTypes[column.Name],
+		}, column.DataType.Type(), isDefaultNull)
 		if err != nil {
 			return Primary{}, nil, err
 		}
 
 		if column.Constraint != nil {
-			if column.Name == primaryColumn {
-				if !column.Constraint.AutoIncrement && dataType == "int64" {
+			if primaryColumnSet.Contains(column.Name) {
+				if len(primaryColumns) == 1 && !column.Constraint.AutoIncrement && dataType == "int64" {
 					log.Warning("%s: The primary key is recommended to add constraint `AUTO_INCREMENT`", column.Name)
 				}
 			} else if column.Constraint.NotNull && !column.Constraint.HasDefaultValue {
@@ -225,20 +403,59 @@ func convertColumns(columns []*parser.Column, primaryColumn string) (Primary, ma
 		field.DataType = dataType
 		field.Comment = util.TrimNewLine(comment)
 
-		if field.Name.Source() == primaryColumn {
-			primaryKey = Primary{
-				Field: field,
-			}
-			if column.Constraint != nil {
-				primaryKey.AutoIncrement = column.Constraint.AutoIncrement
-			}
+		if column.Constraint != nil && column.Constraint.AutoIncrement {
+			autoIncrementSet.AddStr(column.Name)
 		}
 
 		fieldM[field.Name.Source()] = &field
 	}
+
+	for _, column := range primaryColumns {
+		field, ok := fieldM[column]
+		if !ok {
+			return Primary{}, nil, fmt.Errorf("primary key %s is not a column", column)
+		}
+
+		primaryKey.Fields = append(primaryKey.Fields, field)
+		if autoIncrementSet.Contains(column) {
+			primaryKey.AutoIncrement = true
+			primaryKey.AutoIncrementField = field
+		}
+	}
+	if len(primaryKey.Fields) > 0 {
+		primaryKey.Field = *primaryKey.Fields[0]
+	}
+
 	return primaryKey, fieldM, nil
 }
 
+// Columns returns the columns of primary key in order
+func (p Primary) Columns() []*Field {
+	if len(p.Fields) > 0 {
+		return p.Fields
+	}
+
+	return []*Field{&p.Field}
+}
+
+// IsComposite returns true if the primary key consists of more than one column
+func (p Primary) IsComposite() bool {
+	return len(p.Fields) > 1
+}
+
+// AutoIncrementColumn returns the auto increment column of primary key, it returns nil if there is none
+func (p Primary) AutoIncrementColumn() *Field {
+	if p.AutoIncrementField != nil {
+		return p.AutoIncrementField
+	}
+
+	if p.AutoIncrement {
+		return &p.Field
+	}
+
+	return nil
+}
+
 // ContainsTime returns true if contains golang type time.Time
 func (t *Table) ContainsTime() bool {
 	for _, item := range t.Fields {
@@ -250,34 +467,54 @@ func (t *Table) ContainsTime() bool {
 }
 
 // ConvertDataType converts mysql data type into golang data type
-func ConvertDataType(table *model.Table) (*Table, error) {
-	isPrimaryDefaultNull := table.PrimaryKey.ColumnDefault == nil && table.PrimaryKey.IsNullAble == "YES"
-	primaryDataType, err := converter.ConvertStringDataType(table.PrimaryKey.DataType, isPrimaryDefaultNull)
-	if err != nil {
-		return nil, err
-	}
-
+func ConvertDataType(table *model.Table, opts ...Option) (*Table, error) {
+	o := newOptions(opts)
 	var reply Table
 	reply.UniqueIndex = map[string][]*Field{}
 	reply.Name = stringx.From(table.Table)
 	reply.Db = stringx.From(table.Db)
-	seqInIndex := 0
-	if table.PrimaryKey.Index != nil {
-		seqInIndex = table.PrimaryKey.Index.SeqInIndex
+
+	primaryColumns := table.PrimaryKeys
+	if len(primaryColumns) == 0 && table.PrimaryKey != nil {
+		primaryColumns = []*model.Column{table.PrimaryKey}
 	}
 
-	reply.PrimaryKey = Primary{
-		Field: Field{
-			Name:            stringx.From(table.PrimaryKey.Name),
+	primarySet := collection.NewSet()
+	for _, column := range primaryColumns {
+		isPrimaryDefaultNull := column.ColumnDefault == nil && column.IsNullAble == "YES"
+		primaryDataType, err := o.types.ConvertStringDataType(converter.Column{
+			Table: table.Table,
+			Name:  column.Name,
+			Type:  column.ColumnType,
+		}, column.DataType, isPrimaryDefaultNull)
+		if err != nil {
+			return nil, err
+		}
+
+		seqInIndex := 0
+		if column.Index != nil {
+			seqInIndex = column.Index.SeqInIndex
+		}
+
+		field := &Field{
+			Name:            stringx.From(column.Name),
 			DataType:        primaryDataType,
-			Comment:         table.PrimaryKey.Comment,
+			Comment:         column.Comment,
 			SeqInIndex:      seqInIndex,
-			OrdinalPosition: table.PrimaryKey.OrdinalPosition,
-		},
-		AutoIncrement: strings.Contains(table.PrimaryKey.Extra, "auto_increment"),
+			OrdinalPosition: column.OrdinalPosition,
+		}
+		reply.PrimaryKey.Fields = append(reply.PrimaryKey.Fields, field)
+		if strings.Contains(column.Extra, "auto_increment") {
+			reply.PrimaryKey.AutoIncrement = true
+			reply.PrimaryKey.AutoIncrementField = field
+		}
+		primarySet.AddStr(column.Name)
+	}
+	if len(reply.PrimaryKey.Fields) > 0 {
+		reply.PrimaryKey.Field = *reply.PrimaryKey.Fields[0]
 	}
 
-	fieldM, err := getTableFields(table)
+	fieldM, err := getTableFields(table, o.types)
 	if err != nil {
 		return nil, err
 	}
@@ -299,14 +536,6 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 			return false
 		})
 
-		if len(each) == 1 {
-			one := each[0]
-			if one.Name == table.PrimaryKey.Name {
-				log.Warning("table %s: duplicate unique index with primary key, %s", table.Table, one.Name)
-				continue
-			}
-		}
-
 		var list []*Field
 		var uniqueJoin []string
 		for _, c := range each {
@@ -315,6 +544,11 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 		}
 
 		uniqueKey := strings.Join(uniqueJoin, ",")
+		if isPrimaryIndex(uniqueJoin, primarySet) {
+			log.Warning("table %s: duplicate unique index with primary key, %s", table.Table, uniqueKey)
+			continue
+		}
+
 		if uniqueIndexSet.Contains(uniqueKey) {
 			log.Warning("table %s: duplicate unique index, %s", table.Table, uniqueKey)
 			continue
@@ -324,14 +558,49 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 		reply.UniqueIndex[indexName] = list
 	}
 
+	reply.NormalIndex = map[string][]*Field{}
+	for indexName, each := range table.NormalIndex {
+		sort.Slice(each, func(i, j int) bool {
+			if each[i].Index != nil {
+				return each[i].Index.SeqInIndex < each[j].Index.SeqInIndex
+			}
+			return false
+		})
+
+		var list []*Field
+		for _, c := range each {
+			list = append(list, fieldM[c.Name])
+		}
+		reply.NormalIndex[indexName] = list
+	}
+
 	return &reply, nil
 }
 
-func getTableFields(table *model.Table) (map[string]*Field, error) {
+// isPrimaryIndex returns true if the index consists of exactly the columns of primary key
+func isPrimaryIndex(columns []string, primarySet *collection.Set) bool {
+	if len(columns) != primarySet.Count() {
+		return false
+	}
+
+	for _, column := range columns {
+		if !primarySet.Contains(column) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getTableFields(table *model.Table, types *converter.TypeConverter) (map[string]*Field, error) {
 	fieldM := make(map[string]*Field)
 	for _, each := range table.Columns {
 		isDefaultNull := each.ColumnDefault == nil && each.IsNullAble == "YES"
-		dt, err := converter.ConvertStringDataType(each.DataType, isDefaultNull)
+		dt, err := types.ConvertStringDataType(converter.Column{
+			Table: table.Table,
+			Name:  each.Name,
+			Type:  each.ColumnType,
+		}, each.DataType, isDefaultNull)
 		if err != nil {
 			return nil, err
 		}
@@ -3,9 +3,12 @@ package parser
 import (
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/weitrue/goctl/config"
+	"github.com/weitrue/goctl/model/sql/converter"
 	"github.com/weitrue/goctl/model/sql/model"
 	"github.com/weitrue/goctl/model/sql/util"
 )
@@ -42,6 +45,8 @@ func TestParseCreateTable(t *testing.T) {
 	assert.Equal(t, "id", table.PrimaryKey.Name.Source())
 	assert.Equal(t, true, table.ContainsTime())
 	assert.Equal(t, 2, len(table.UniqueIndex))
+	assert.Equal(t, 2, len(table.NormalIndex))
+	assert.Equal(t, "create_time", table.NormalIndex["create_index"][0].Name.Source())
 	assert.True(t, func() bool {
 		for _, e := range table.Fields {
 			if e.Comment != util.TrimNewLine(e.Comment) {
@@ -53,6 +58,80 @@ func TestParseCreateTable(t *testing.T) {
 	}())
 }
 
+func TestParseNormalIndexes(t *testing.T) {
+	indexes := parseNormalIndexes("CREATE TABLE `user` (\n  `id` bigint NOT NULL,\n  `name` varchar(255) NOT NULL,\n" +
+		"  `class` bigint NOT NULL COMMENT 'see (foo, key (bar))',\n  PRIMARY KEY (`id`),\n  UNIQUE KEY `name_unique` (`name`),\n" +
+		"  KEY `name_index` (`name`(10)),\n  INDEX `class_name_index` USING BTREE (`class`, `name` DESC)\n);\n" +
+		"create table if not exists go_zero.`class` (id bigint, name varchar(255), primary key (id), key (name));")
+	assert.Equal(t, map[string]map[string][]string{
+		"user": {
+			"name_index":       {"name"},
+			"class_name_index": {"class", "name"},
+		},
+		"class": {
+			"name": {"name"},
+		},
+	}, indexes)
+}
+
+func TestParseColumnTypes(t *testing.T) {
+	types := parseColumnTypes("CREATE TABLE `bill` (\n  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `enabled` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'a, fake int',\n  `amount` decimal(10, 2) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n  KEY `amount_index` (`amount`)\n);\n" +
+		"create table go_zero.class (id int unsigned zerofill, name varchar(255));")
+	assert.Equal(t, "bigint(20) unsigned", types["bill"]["id"])
+	assert.Equal(t, "tinyint(1)", types["bill"]["enabled"])
+	assert.Equal(t, "decimal(10, 2)", types["bill"]["amount"])
+	assert.NotContains(t, types["bill"], "fake")
+	assert.Equal(t, "int unsigned zerofill", types["class"]["id"])
+	assert.Equal(t, "varchar(255)", types["class"]["name"])
+}
+
+func TestParseWithTypeConverter(t *testing.T) {
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte("CREATE TABLE `bill` (\n  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `enabled` tinyint(1) NOT NULL DEFAULT '0',\n  `level` tinyint NOT NULL DEFAULT 0,\n"+
+		"  `fee` decimal(10,2) DEFAULT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB;"), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := Parse(sqlFile, "go_zero", WithTypeConverter(converter.NewTypeConverter(&config.TypeMapping{
+		Types: map[string]string{
+			"unsigned":   "uint64",
+			"tinyint(1)": "bool",
+		},
+		Columns: map[string]string{
+			"bill.fee": "github.com/shopspring/decimal.NullDecimal",
+		},
+	})))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tables))
+	var types []string
+	for _, field := range tables[0].Fields {
+		types = append(types, field.DataType)
+	}
+	assert.Equal(t, []string{"uint64", "bool", "int64", "decimal.NullDecimal"}, types)
+	assert.Equal(t, "uint64", tables[0].PrimaryKey.DataType)
+}
+
+func TestParseCompositePrimaryKey(t *testing.T) {
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte("CREATE TABLE `tenant_order` (\n  `tenant_id` bigint NOT NULL,\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `sn` varchar(64) NOT NULL DEFAULT '',\n  PRIMARY KEY (`tenant_id`, `id`)\n) ENGINE=InnoDB;"), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := Parse(sqlFile, "go_zero")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tables))
+	primaryKey := tables[0].PrimaryKey
+	assert.True(t, primaryKey.IsComposite())
+	assert.Equal(t, "tenant_id", primaryKey.Name.Source())
+	assert.Equal(t, []string{"tenant_id", "id"}, []string{
+		primaryKey.Columns()[0].Name.Source(),
+		primaryKey.Columns()[1].Name.Source(),
+	})
+	assert.True(t, primaryKey.AutoIncrement)
+	assert.Equal(t, "id", primaryKey.AutoIncrementColumn().Name.Source())
+}
+
 func TestConvertColumn(t *testing.T) {
 	t.Run("missingPrimaryKey", func(t *testing.T) {
 		columnData := model.ColumnData{
@@ -83,7 +162,8 @@ func TestConvertColumn(t *testing.T) {
 						DataType: "bigint",
 					},
 					Index: &model.DbIndex{
-						IndexName: "PRIMARY",
+						IndexName:  "PRIMARY",
+						SeqInIndex: 2,
 					},
 				},
 				{
@@ -93,14 +173,23 @@ func TestConvertColumn(t *testing.T) {
 						Comment:  "手机号",
 					},
 					Index: &model.DbIndex{
-						IndexName: "PRIMARY",
+						IndexName:  "PRIMARY",
+						SeqInIndex: 1,
 					},
 				},
 			},
 		}
-		_, err := columnData.Convert()
-		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), "joint primary key is not supported")
+		table, err := columnData.Convert()
+		assert.Nil(t, err)
+		assert.Equal(t, "mobile", table.PrimaryKey.Name)
+		assert.Equal(t, 2, len(table.PrimaryKeys))
+		assert.Equal(t, "id", table.PrimaryKeys[1].Name)
+
+		reply, err := ConvertDataType(table)
+		assert.Nil(t, err)
+		assert.True(t, reply.PrimaryKey.IsComposite())
+		assert.Equal(t, "mobile", reply.PrimaryKey.Name.Source())
+		assert.Equal(t, "id", reply.PrimaryKey.Fields[1].Name.Source())
 	})
 
 	t.Run("normal", func(t *testing.T) {
@@ -144,3 +233,199 @@ func TestConvertColumn(t *testing.T) {
 		}
 	})
 }
+
+func TestParsePostgreSql(t *testing.T) {
+	sqlFile := filepath.Join(t.TempDir(), "schema.sql")
+	err := ioutil.WriteFile(sqlFile, []byte(`-- the migrations
+CREATE TYPE order_status AS ENUM ('new', 'paid');
+
+CREATE TABLE IF NOT EXISTS shop.orders (
+    id bigserial PRIMARY KEY,
+    sn uuid NOT NULL UNIQUE,
+    items jsonb,
+    tags varchar(32)[] NOT NULL DEFAULT '{}',
+    amount numeric(10, 2) NOT NULL DEFAULT 0,
+    status order_status NOT NULL DEFAULT 'new',
+    note text, /* ; */
+    create_time timestamptz NOT NULL DEFAULT now(),
+    pay_time timestamp(6) with time zone,
+    CONSTRAINT orders_amount_check CHECK (amount >= 0)
+);
+CREATE INDEX orders_status_idx ON shop.orders USING btree (status, create_time DESC);
+CREATE UNIQUE INDEX orders_note_key ON shop.orders (lower(note));
+CREATE UNIQUE INDEX orders_pay_time_key ON shop.orders (pay_time) WHERE status = 'paid';
+COMMENT ON COLUMN shop.orders.sn IS 'the serial number; it''s unique';
+
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN NEW.create_time = now(); RETURN NEW; END; $$ LANGUAGE plpgsql;
+
+CREATE TABLE order_item (
+    order_id bigint NOT NULL,
+    line integer NOT NULL,
+    sku text NOT NULL,
+    PRIMARY KEY (order_id, line),
+    CONSTRAINT order_item_sku UNIQUE (order_id, sku)
+);
+
+CREATE TABLE public.account (
+    id integer NOT NULL,
+    name text
+);
+ALTER TABLE ONLY public.account ALTER COLUMN id SET DEFAULT nextval('public.account_id_seq'::regclass);
+ALTER TABLE ONLY public.account ADD CONSTRAINT account_pkey PRIMARY KEY (id);
+
+CREATE TABLE tmp (id int GENERATED ALWAYS AS IDENTITY PRIMARY KEY);
+DROP TABLE tmp;`), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := ParsePostgreSql(sqlFile, "public")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(tables))
+
+	orders := tables[0]
+	assert.Equal(t, "orders", orders.Name.Source())
+	assert.Equal(t, "shop", orders.Db.Source())
+	assert.True(t, orders.PrimaryKey.AutoIncrement)
+	var types []string
+	for _, field := range orders.Fields {
+		types = append(types, field.DataType)
+	}
+	assert.Equal(t, []string{"int64", "string", "sql.NullString", "string", "float64", "string", "sql.NullString",
+		"time.Time", "sql.NullTime"}, types)
+	assert.Equal(t, "the serial number; it's unique", orders.Fields[1].Comment)
+	assert.Equal(t, 1, len(orders.UniqueIndex))
+	assert.Equal(t, "sn", orders.UniqueIndex["orders_sn_key"][0].Name.Source())
+	assert.Equal(t, 2, len(orders.NormalIndex))
+	assert.Equal(t, []string{"status", "create_time"}, []string{
+		orders.NormalIndex["orders_status_idx"][0].Name.Source(),
+		orders.NormalIndex["orders_status_idx"][1].Name.Source(),
+	})
+	assert.Equal(t, "pay_time", orders.NormalIndex["orders_pay_time_key"][0].Name.Source())
+
+	item := tables[1]
+	assert.Equal(t, "public", item.Db.Source())
+	assert.True(t, item.PrimaryKey.IsComposite())
+	assert.False(t, item.PrimaryKey.AutoIncrement)
+	assert.Equal(t, 2, len(item.UniqueIndex["order_item_sku"]))
+
+	account := tables[2]
+	assert.Equal(t, "id", account.PrimaryKey.Name.Source())
+	assert.True(t, account.PrimaryKey.AutoIncrement)
+
+	c := converter.NewTypeConverter(&config.TypeMapping{
+		Types: map[string]string{
+			"varchar[]": "github.com/lib/pq.StringArray",
+			"uuid":      "github.com/google/uuid.UUID",
+		},
+	})
+	tables, err = ParsePostgreSql(sqlFile, "public", WithTypeConverter(c))
+	assert.Nil(t, err)
+	assert.Equal(t, "uuid.UUID", tables[0].Fields[1].DataType)
+	assert.Equal(t, "pq.StringArray", tables[0].Fields[3].DataType)
+
+	err = ioutil.WriteFile(sqlFile, []byte("CREATE TABLE t (id int, location point, PRIMARY KEY (id));"), 0o777)
+	assert.Nil(t, err)
+	_, err = ParsePostgreSql(sqlFile, "public")
+	assert.NotNil(t, err)
+
+	err = ioutil.WriteFile(sqlFile, []byte("CREATE TABLE t (id int);"), 0o777)
+	assert.Nil(t, err)
+	_, err = ParsePostgreSql(sqlFile, "public")
+	assert.NotNil(t, err)
+
+	for _, column := range []string{`"Name"`, `"first name"`, `"user"`} {
+		err = ioutil.WriteFile(sqlFile, []byte("CREATE TABLE t (id int PRIMARY KEY, "+column+" text);"), 0o777)
+		assert.Nil(t, err)
+		_, err = ParsePostgreSql(sqlFile, "public")
+		assert.NotNil(t, err, column)
+	}
+
+	err = ioutil.WriteFile(sqlFile, []byte(`CREATE TABLE t (id int PRIMARY KEY, "name" text);`), 0o777)
+	assert.Nil(t, err)
+	_, err = ParsePostgreSql(sqlFile, "public")
+	assert.Nil(t, err)
+}
+
+func TestParsePostgreSqlMigrations(t *testing.T) {
+	tables, warnings, err := parsePostgreSqlTables(`SET client_encoding = 'UTF8';
+SET search_path = public;
+CREATE EXTENSION IF NOT EXISTS citext;
+CREATE TABLE account (
+    id bigserial PRIMARY KEY,
+    name text,
+    legacy text UNIQUE
+);
+ALTER TABLE account ADD COLUMN email citext NOT NULL DEFAULT '', ADD COLUMN IF NOT EXISTS name text;
+ALTER TABLE account ADD CONSTRAINT account_email_key UNIQUE (email);
+ALTER TABLE account DROP COLUMN legacy;
+ALTER TABLE account ALTER COLUMN name SET NOT NULL, ALTER COLUMN name TYPE varchar(64) USING name::varchar;
+ALTER TABLE account OWNER TO postgres;
+ALTER TABLE account RENAME COLUMN name TO nickname;
+ALTER TABLE missing ADD COLUMN note text;`, "public")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"statement SET is ignored, 2 times",
+		"statement CREATE EXTENSION is ignored",
+		"ALTER TABLE account OWNER TO is ignored",
+		"ALTER TABLE account RENAME COLUMN is ignored",
+		"statement ALTER TABLE of unknown table missing is ignored",
+	}, warnings)
+
+	assert.Equal(t, 1, len(tables))
+	var columns []string
+	for _, column := range tables[0].Columns {
+		columns = append(columns, column.Name)
+	}
+	assert.Equal(t, []string{"id", "name", "email"}, columns)
+	assert.Equal(t, "NO", tables[0].Columns[1].IsNullAble)
+	assert.Equal(t, "varchar", tables[0].Columns[1].DataType)
+	assert.Equal(t, 1, len(tables[0].UniqueIndex))
+	assert.Equal(t, "email", tables[0].UniqueIndex["account_email_key"][0].Name)
+
+	_, _, err = parsePostgreSqlTables(`CREATE TABLE t (id int PRIMARY KEY);
+ALTER TABLE t ADD COLUMN "Note" text;`, "public")
+	assert.NotNil(t, err)
+}
+
+func TestParseDefinitions(t *testing.T) {
+	sqlFile := filepath.Join(t.TempDir(), "tmp.sql")
+	err := ioutil.WriteFile(sqlFile, []byte("-- users\nCREATE TABLE `user` (\n  `id` bigint(10) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(255)  NOT NULL DEFAULT '' COMMENT 'a,  (b) key',\n  `email` varchar(64) NOT NULL DEFAULT '' UNIQUE KEY,\n"+
+		"  `mobile` char(11) NOT NULL DEFAULT '',\n  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `mobile_index` (`mobile`),\n  CONSTRAINT uk_name UNIQUE (`name`, `mobile`),\n"+
+		"  KEY `name_index` (`name`(10)) USING BTREE,\n  INDEX (`create_time` DESC)\n) ENGINE=InnoDB;\n\nselect 1;"), 0o777)
+	assert.Nil(t, err)
+
+	tables, err := Parse(sqlFile, "go_zero")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tables))
+	table := tables[0]
+	assert.True(t, strings.HasPrefix(table.Statement, "CREATE TABLE `user` (\n"))
+	assert.True(t, strings.HasSuffix(table.Statement, ") ENGINE=InnoDB;"))
+
+	var definitions []string
+	for _, field := range table.Fields {
+		definitions = append(definitions, field.Definition)
+	}
+	assert.Equal(t, []string{
+		"bigint(10) unsigned NOT NULL AUTO_INCREMENT",
+		"varchar(255) NOT NULL DEFAULT '' COMMENT 'a,  (b) key'",
+		"varchar(64) NOT NULL DEFAULT ''",
+		"char(11) NOT NULL DEFAULT ''",
+		"timestamp NULL DEFAULT CURRENT_TIMESTAMP",
+	}, definitions)
+	assert.Equal(t, definitions[0], table.PrimaryKey.Definition)
+
+	var indexes []string
+	for _, index := range table.Indexes {
+		indexes = append(indexes, index.Definition)
+	}
+	assert.Equal(t, []string{
+		"UNIQUE KEY `email` (`email`)",
+		"UNIQUE KEY `mobile_index` (`mobile`)",
+		"UNIQUE KEY `uk_name` (`name`, `mobile`)",
+		"KEY `name_index` (`name`(10)) USING BTREE",
+		"KEY `create_time` (`create_time` DESC)",
+	}, indexes)
+	assert.True(t, table.Indexes[0].Unique)
+	assert.False(t, table.Indexes[3].Unique)
+}
@@ -0,0 +1,1125 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/weitrue/goctl/model/sql/model"
+	"github.com/weitrue/goctl/util/console"
+)
+
+const (
+	indexPri      = "PRIMARY"
+	autoIncrement = "auto_increment"
+)
+
+var (
+	// pgTypes converts the postgresql types into the mysql ones which are known by converter,
+	// the serial types are converted into integers with auto increment
+	pgTypes = map[string]string{
+		"smallint":                    "smallint",
+		"int2":                        "smallint",
+		"smallserial":                 "smallint",
+		"serial2":                     "smallint",
+		"integer":                     "integer",
+		"int":                         "integer",
+		"int4":                        "integer",
+		"serial":                      "integer",
+		"serial4":                     "integer",
+		"bigint":                      "bigint",
+		"int8":                        "bigint",
+		"bigserial":                   "bigint",
+		"serial8":                     "bigint",
+		"real":                        "float",
+		"float4":                      "float",
+		"double precision":            "double",
+		"float8":                      "double",
+		"float":                       "double",
+		"numeric":                     "decimal",
+		"decimal":                     "decimal",
+		"boolean":                     "bool",
+		"bool":                        "bool",
+		"character varying":           "varchar",
+		"varchar":                     "varchar",
+		"character":                   "char",
+		"char":                        "char",
+		"bpchar":                      "char",
+		"text":                        "text",
+		"citext":                      "text",
+		"uuid":                        "uuid",
+		"json":                        "json",
+		"jsonb":                       "jsonb",
+		"bytea":                       "blob",
+		"date":                        "date",
+		"time":                        "time",
+		"timetz":                      "time",
+		"time without time zone":      "time",
+		"time with time zone":         "time",
+		"timestamp":                   "timestamp",
+		"timestamptz":                 "timestamp",
+		"timestamp without time zone": "timestamp",
+		"timestamp with time zone":    "timestamp",
+		"interval":                    "varchar",
+		"inet":                        "varchar",
+		"cidr":                        "varchar",
+		"macaddr":                     "varchar",
+	}
+	pgSerialTypes = map[string]bool{
+		"smallserial": true,
+		"serial2":     true,
+		"serial":      true,
+		"serial4":     true,
+		"bigserial":   true,
+		"serial8":     true,
+	}
+	// pgColumnKeywords start the constraints of column definition, which end the type of column
+	pgColumnKeywords = map[string]bool{
+		"constraint": true,
+		"not":        true,
+		"null":       true,
+		"default":    true,
+		"primary":    true,
+		"unique":     true,
+		"references": true,
+		"check":      true,
+		"generated":  true,
+		"collate":    true,
+	}
+	pgTableConstraints = map[string]bool{
+		"constraint": true,
+		"primary":    true,
+		"unique":     true,
+		"foreign":    true,
+		"check":      true,
+		"exclude":    true,
+		"like":       true,
+	}
+	// pgReservedWords can't be the names of columns without quotes
+	pgReservedWords = map[string]bool{
+		"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+		"asc": true, "asymmetric": true, "authorization": true, "binary": true, "both": true, "case": true,
+		"cast": true, "check": true, "collate": true, "collation": true, "column": true, "concurrently": true,
+		"constraint": true, "create": true, "cross": true, "current_catalog": true, "current_date": true,
+		"current_role": true, "current_schema": true, "current_time": true, "current_timestamp": true,
+		"current_user": true, "default": true, "deferrable": true, "desc": true, "distinct": true, "do": true,
+		"else": true, "end": true, "except": true, "false": true, "fetch": true, "for": true, "foreign": true,
+		"freeze": true, "from": true, "full": true, "grant": true, "group": true, "having": true, "ilike": true,
+		"in": true, "initially": true, "inner": true, "intersect": true, "into": true, "is": true, "isnull": true,
+		"join": true, "lateral": true, "leading": true, "left": true, "like": true, "limit": true,
+		"localtime": true, "localtimestamp": true, "natural": true, "not": true, "notnull": true, "null": true,
+		"offset": true, "on": true, "only": true, "or": true, "order": true, "outer": true, "overlaps": true,
+		"placing": true, "primary": true, "references": true, "returning": true, "right": true, "select": true,
+		"session_user": true, "similar": true, "some": true, "symmetric": true, "system_user": true,
+		"table": true, "tablesample": true, "then": true, "to": true, "trailing": true, "true": true,
+		"union": true, "unique": true, "user": true, "using": true, "variadic": true, "verbose": true,
+		"when": true, "where": true, "window": true, "with": true,
+	}
+	pgTypeLengthRegex = regexp.MustCompile(`\s*\(.*?\)`)
+	pgDollarTagRegex  = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+	pgPlainNameRegex  = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+)
+
+type (
+	pgToken struct {
+		// text is lower case for the unquoted words
+		text string
+		// quoted is true for the "identifiers"
+		quoted bool
+		// str is true for the 'strings' and $$dollar quoted strings$$
+		str bool
+	}
+
+	pgTokens []pgToken
+
+	pgColumn struct {
+		name          string
+		declaredType  string
+		notNull       bool
+		hasDefault    bool
+		autoIncrement bool
+		comment       string
+	}
+
+	pgIndex struct {
+		name    string
+		columns []string
+		unique  bool
+	}
+
+	pgTable struct {
+		schema  string
+		name    string
+		columns []*pgColumn
+		primary []string
+		indexes []*pgIndex
+	}
+
+	pgSchema struct {
+		tables []*pgTable
+		enums  map[string]bool
+		// ignored counts the skipped statements by the warning messages, which are reported in order
+		ignored      map[string]int
+		ignoredOrder []string
+	}
+)
+
+// ParsePostgreSql parses the postgresql ddl, such as the migrations or the output of pg_dump --schema-only,
+// into golang structure. It understands CREATE TABLE, CREATE INDEX, CREATE TYPE AS ENUM, DROP TABLE,
+// DROP INDEX, ALTER TABLE on constraints and columns, and COMMENT ON COLUMN, the other statements are
+// ignored with warnings. The schema is used for the tables without qualified schema. The generated code
+// uses the column names without quotes, so that the ones which need quotes are rejected.
+func ParsePostgreSql(filename, schema string, opts ...Option) ([]*Table, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, warnings, err := parsePostgreSqlTables(string(content), schema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(filename), err)
+	}
+
+	log := console.NewColorConsole()
+	for _, warning := range warnings {
+		log.Warning("%s: %s", filepath.Base(filename), warning)
+	}
+
+	var list []*Table
+	for _, each := range tables {
+		table, err := ConvertDataType(each, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(filename), err)
+		}
+
+		list = append(list, table)
+	}
+
+	return list, nil
+}
+
+// parsePostgreSqlTables parses ddl into the tables in the order of the definitions, the warnings tell
+// the ignored statements
+func parsePostgreSqlTables(ddl, schema string) ([]*model.Table, []string, error) {
+	tokens, err := tokenizePostgreSql(ddl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &pgSchema{
+		enums:   make(map[string]bool),
+		ignored: make(map[string]int),
+	}
+	for _, statement := range tokens.split(";") {
+		if err := s.parseStatement(statement, schema); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var warnings []string
+	for _, message := range s.ignoredOrder {
+		if count := s.ignored[message]; count > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored, %d times", message, count))
+		} else {
+			warnings = append(warnings, message+" is ignored")
+		}
+	}
+
+	var list []*model.Table
+	for _, each := range s.tables {
+		table, err := s.convert(each)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		list = append(list, table)
+	}
+
+	return list, warnings, nil
+}
+
+func tokenizePostgreSql(ddl string) (pgTokens, error) {
+	var tokens pgTokens
+	for i := 0; i < len(ddl); {
+		c := ddl[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(ddl[i:], "--"):
+			end := strings.IndexByte(ddl[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(ddl[i:], "/*"):
+			end := strings.Index(ddl[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case c == '\'' || c == '"':
+			text, n, err := readQuoted(ddl[i:], c)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, pgToken{text: text, quoted: c == '"', str: c == '\''})
+			i += n
+		case c == '$':
+			tag := pgDollarTagRegex.FindString(ddl[i:])
+			if len(tag) == 0 {
+				tokens = append(tokens, pgToken{text: "$"})
+				i++
+				continue
+			}
+			end := strings.Index(ddl[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated dollar quoted string %s", tag)
+			}
+			tokens = append(tokens, pgToken{text: ddl[i+len(tag) : i+len(tag)+end], str: true})
+			i += len(tag)*2 + end
+		case isWordStart(c) || isDigit(c):
+			j := i + 1
+			for j < len(ddl) && (isWordStart(ddl[j]) || isDigit(ddl[j]) || ddl[j] == '$' ||
+				isDigit(c) && ddl[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, pgToken{text: strings.ToLower(ddl[i:j])})
+			i = j
+		case strings.HasPrefix(ddl[i:], "::"):
+			tokens = append(tokens, pgToken{text: "::"})
+			i += 2
+		default:
+			tokens = append(tokens, pgToken{text: string(c)})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+// readQuoted reads the string quoted by quote, the doubled quotes are escaped ones
+func readQuoted(s string, quote byte) (string, int, error) {
+	var builder strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != quote {
+			builder.WriteByte(s[i])
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == quote {
+			builder.WriteByte(quote)
+			i++
+			continue
+		}
+
+		return builder.String(), i + 1, nil
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted string %s", s[:1])
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// is returns true if the token is the unquoted word or the punctuation
+func (t pgToken) is(word string) bool {
+	return !t.quoted && !t.str && t.text == word
+}
+
+// identifier returns true if the token can be a name
+func (t pgToken) identifier() bool {
+	return t.quoted || !t.str && len(t.text) > 0 && isWordStart(t.text[0])
+}
+
+// split splits tokens by the separator out of parentheses, the empty parts are dropped
+func (ts pgTokens) split(separator string) []pgTokens {
+	var (
+		list  []pgTokens
+		depth int
+		start int
+	)
+	for i, t := range ts {
+		switch {
+		case t.is("(") || t.is("["):
+			depth++
+		case t.is(")") || t.is("]"):
+			depth--
+		case depth == 0 && t.is(separator):
+			if i > start {
+				list = append(list, ts[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if len(ts) > start {
+		list = append(list, ts[start:])
+	}
+
+	return list
+}
+
+// hasPrefix returns true if the tokens start with the unquoted words
+func (ts pgTokens) hasPrefix(words ...string) bool {
+	if len(ts) < len(words) {
+		return false
+	}
+
+	for i, word := range words {
+		if !ts[i].is(word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skip drops the leading words if they are present
+func (ts pgTokens) skip(words ...string) pgTokens {
+	if ts.hasPrefix(words...) {
+		return ts[len(words):]
+	}
+
+	return ts
+}
+
+// group returns the tokens in the parentheses starting at the first token and the rest after them
+func (ts pgTokens) group() (pgTokens, pgTokens, bool) {
+	if len(ts) == 0 || !ts[0].is("(") {
+		return nil, ts, false
+	}
+
+	depth := 0
+	for i, t := range ts {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+			if depth == 0 {
+				return ts[1:i], ts[i+1:], true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+// index returns the position of the first unquoted word out of parentheses, -1 if not found
+func (ts pgTokens) index(word string) int {
+	depth := 0
+	for i, t := range ts {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case depth == 0 && t.is(word):
+			return i
+		}
+	}
+
+	return -1
+}
+
+// qualifiedName reads the name like schema.table, the schema is empty if it's not qualified
+func (ts pgTokens) qualifiedName() (names []string, rest pgTokens) {
+	for len(ts) > 0 && ts[0].identifier() {
+		names = append(names, ts[0].text)
+		ts = ts[1:]
+		if len(ts) == 0 || !ts[0].is(".") {
+			break
+		}
+		ts = ts[1:]
+	}
+
+	return names, ts
+}
+
+// columns reads the column names in the parentheses, ok is false if there are expressions
+func (ts pgTokens) columns() (columns []string, rest pgTokens, ok bool) {
+	group, rest, ok := ts.group()
+	if !ok {
+		return nil, ts, false
+	}
+
+	for _, item := range group.split(",") {
+		if !item[0].identifier() || len(item) > 1 && !pgIndexOptions(item[1:]) {
+			return nil, rest, false
+		}
+
+		columns = append(columns, item[0].text)
+	}
+
+	return columns, rest, len(columns) > 0
+}
+
+// pgIndexOptions returns true if the tokens after the column of index are the options, such as the
+// collation, the operator class and the ordering, rather than an expression
+func pgIndexOptions(ts pgTokens) bool {
+	for _, t := range ts {
+		if !t.identifier() && !t.is(".") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *pgSchema) parseStatement(ts pgTokens, schema string) error {
+	switch {
+	case ts.hasPrefix("create", "type"):
+		s.parseCreateType(ts[2:])
+	case ts.hasPrefix("create"):
+		rest := ts[1:].skip("or", "replace")
+		rest = rest.skip("global").skip("local")
+		rest = rest.skip("temporary").skip("temp").skip("unlogged")
+		switch {
+		case rest.hasPrefix("table"):
+			return s.parseCreateTable(rest[1:], schema)
+		case rest.hasPrefix("index"), rest.hasPrefix("unique", "index"):
+			s.parseCreateIndex(rest)
+		default:
+			s.ignore("statement %s", ts.kind())
+		}
+	case ts.hasPrefix("alter", "table"):
+		return s.parseAlterTable(ts[2:])
+	case ts.hasPrefix("drop", "table"):
+		s.parseDropTable(ts[2:])
+	case ts.hasPrefix("drop", "index"):
+		s.parseDropIndex(ts[2:])
+	case ts.hasPrefix("comment", "on", "column"):
+		s.parseComment(ts[3:])
+	default:
+		s.ignore("statement %s", ts.kind())
+	}
+
+	return nil
+}
+
+// ignore records the skipped statement, the same messages are counted
+func (s *pgSchema) ignore(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if s.ignored[message] == 0 {
+		s.ignoredOrder = append(s.ignoredOrder, message)
+	}
+	s.ignored[message]++
+}
+
+// kind returns the leading words of the statement in upper case, such as CREATE FUNCTION and SET
+func (ts pgTokens) kind() string {
+	words := []string{ts[0].text}
+	switch rest := ts[1:].skip("or", "replace"); {
+	case len(rest) == 0 || !rest[0].identifier() || rest[0].quoted:
+	case ts.hasPrefix("create"), ts.hasPrefix("alter"), ts.hasPrefix("drop"), ts.hasPrefix("comment"):
+		words = append(words, rest[0].text)
+		if rest.hasPrefix("on") && len(rest) > 1 {
+			words = append(words, rest[1].text)
+		}
+	}
+
+	return strings.ToUpper(strings.Join(words, " "))
+}
+
+// parseCreateType records the enum types, which are converted into strings
+func (s *pgSchema) parseCreateType(ts pgTokens) {
+	names, rest := ts.qualifiedName()
+	if len(names) > 0 && rest.hasPrefix("as", "enum") {
+		s.enums[names[len(names)-1]] = true
+		return
+	}
+
+	s.ignore("statement CREATE TYPE %s", strings.Join(names, "."))
+}
+
+func (s *pgSchema) parseCreateTable(ts pgTokens, schema string) error {
+	names, rest := ts.skip("if", "not", "exists").qualifiedName()
+	if len(names) == 0 {
+		return nil
+	}
+
+	// the partitions and the tables created by select share the columns of others
+	definitions, _, ok := rest.group()
+	if !ok {
+		s.ignore("statement CREATE TABLE %s without column definitions", names[len(names)-1])
+		return nil
+	}
+
+	table := &pgTable{
+		schema: schema,
+		name:   names[len(names)-1],
+	}
+	if len(names) > 1 {
+		table.schema = names[len(names)-2]
+	}
+
+	for _, definition := range definitions.split(",") {
+		if pgTableConstraints[definition[0].text] && !definition[0].quoted {
+			table.parseConstraint(definition)
+			continue
+		}
+
+		column, err := parseColumn(table, definition)
+		if err != nil {
+			return fmt.Errorf("table %s: %w", table.name, err)
+		}
+
+		table.columns = append(table.columns, column)
+	}
+
+	if s.findTable(table.name) != nil {
+		return fmt.Errorf("duplicate table %s", table.name)
+	}
+
+	s.tables = append(s.tables, table)
+	return nil
+}
+
+func parseColumn(table *pgTable, ts pgTokens) (*pgColumn, error) {
+	if !ts[0].identifier() || len(ts) < 2 {
+		return nil, fmt.Errorf("invalid column definition %s", ts.String())
+	}
+
+	// postgresql folds the names without quotes into lower case, which are used by the generated code
+	if ts[0].quoted && (!pgPlainNameRegex.MatchString(ts[0].text) || pgReservedWords[ts[0].text]) {
+		return nil, fmt.Errorf("column %q needs quotes, which are not supported, expected a lower case name", ts[0].text)
+	}
+
+	column := &pgColumn{
+		name: ts[0].text,
+	}
+
+	end := 1
+	for end < len(ts) && !(pgColumnKeywords[ts[end].text] && !ts[end].quoted && !ts[end].str) {
+		end++
+	}
+	column.declaredType = ts[1:end].typeString()
+
+	for rest := ts[end:]; len(rest) > 0; {
+		switch {
+		case rest.hasPrefix("not", "null"):
+			column.notNull = true
+			rest = rest[2:]
+		case rest.hasPrefix("default"):
+			column.hasDefault = true
+			rest = rest[1:]
+			if rest.hasPrefix("nextval") {
+				column.autoIncrement = true
+			}
+			rest = rest.skipUntil(pgColumnKeywords)
+		case rest.hasPrefix("primary", "key"):
+			table.primary = []string{column.name}
+			rest = rest[2:]
+		case rest.hasPrefix("unique"):
+			table.indexes = append(table.indexes, &pgIndex{
+				name:    fmt.Sprintf("%s_%s_key", table.name, column.name),
+				columns: []string{column.name},
+				unique:  true,
+			})
+			rest = rest[1:].skipUntil(pgColumnKeywords)
+		case rest.hasPrefix("generated"):
+			next := rest[1:].skipUntil(pgColumnKeywords)
+			if rest[:len(rest)-len(next)].index("identity") >= 0 {
+				column.autoIncrement = true
+			}
+			column.hasDefault = true
+			rest = next
+		default:
+			rest = rest[1:].skipUntil(pgColumnKeywords)
+		}
+	}
+
+	return column, nil
+}
+
+// skipUntil drops the tokens until one of the unquoted words out of parentheses
+func (ts pgTokens) skipUntil(words map[string]bool) pgTokens {
+	depth := 0
+	for i, t := range ts {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case depth == 0 && !t.quoted && !t.str && words[t.text]:
+			return ts[i:]
+		}
+	}
+
+	return nil
+}
+
+// typeString joins the tokens of type, such as character varying(255), numeric(10,2) and text[]
+func (ts pgTokens) typeString() string {
+	var builder strings.Builder
+	for i, t := range ts {
+		if t.is("array") {
+			builder.WriteString("[]")
+			continue
+		}
+
+		if i > 0 && t.identifier() && (ts[i-1].identifier() || ts[i-1].is(")")) {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(t.text)
+	}
+
+	return builder.String()
+}
+
+// String returns the tokens separated by spaces for the error messages
+func (ts pgTokens) String() string {
+	var list []string
+	for _, t := range ts {
+		list = append(list, t.text)
+	}
+
+	return strings.Join(list, " ")
+}
+
+// parseConstraint parses the table constraint, only PRIMARY KEY and UNIQUE are used
+func (t *pgTable) parseConstraint(ts pgTokens) {
+	var name string
+	if ts.hasPrefix("constraint") && len(ts) > 1 {
+		name = ts[1].text
+		ts = ts[2:]
+	}
+
+	switch {
+	case ts.hasPrefix("primary", "key"):
+		if columns, _, ok := ts[2:].columns(); ok {
+			t.primary = columns
+		}
+	case ts.hasPrefix("unique"):
+		columns, _, ok := ts[1:].skip("nulls", "not", "distinct").skip("nulls", "distinct").columns()
+		if !ok {
+			return
+		}
+
+		if len(name) == 0 {
+			name = fmt.Sprintf("%s_%s_key", t.name, strings.Join(columns, "_"))
+		}
+		t.indexes = append(t.indexes, &pgIndex{
+			name:    name,
+			columns: columns,
+			unique:  true,
+		})
+	}
+}
+
+// parseCreateIndex parses CREATE [UNIQUE] INDEX, the expression indexes are ignored
+// and the partial unique indexes are treated as the normal ones
+func (s *pgSchema) parseCreateIndex(ts pgTokens) {
+	unique := ts.hasPrefix("unique")
+	ts = ts.skip("unique")[1:].skip("concurrently").skip("if", "not", "exists")
+
+	var name string
+	if !ts.hasPrefix("on") {
+		if len(ts) == 0 || !ts[0].identifier() {
+			s.ignore("statement CREATE INDEX %s", ts.String())
+			return
+		}
+		names, rest := ts.qualifiedName()
+		name = names[len(names)-1]
+		ts = rest
+	}
+
+	names, rest := ts.skip("on").skip("only").qualifiedName()
+	if len(names) == 0 {
+		s.ignore("statement CREATE INDEX %s", name)
+		return
+	}
+
+	table := s.findTable(names[len(names)-1])
+	if table == nil {
+		s.ignore("index %s of unknown table %s", name, names[len(names)-1])
+		return
+	}
+
+	if rest.hasPrefix("using") && len(rest) > 1 {
+		rest = rest[2:]
+	}
+
+	columns, rest, ok := rest.columns()
+	if !ok {
+		s.ignore("expression index %s of table %s", name, table.name)
+		return
+	}
+
+	if rest.index("where") >= 0 {
+		unique = false
+	}
+
+	if len(name) == 0 {
+		name = fmt.Sprintf("%s_%s_idx", table.name, strings.Join(columns, "_"))
+	}
+	table.indexes = append(table.indexes, &pgIndex{
+		name:    name,
+		columns: columns,
+		unique:  unique,
+	})
+}
+
+// parseAlterTable parses the constraints, the sequences and the columns changed by ALTER TABLE, which
+// are used by pg_dump and the migrations
+func (s *pgSchema) parseAlterTable(ts pgTokens) error {
+	names, rest := ts.skip("if", "exists").skip("only").qualifiedName()
+	if len(names) == 0 {
+		s.ignore("statement ALTER TABLE %s", ts.String())
+		return nil
+	}
+
+	table := s.findTable(names[len(names)-1])
+	if table == nil {
+		s.ignore("statement ALTER TABLE of unknown table %s", names[len(names)-1])
+		return nil
+	}
+
+	for _, action := range rest.split(",") {
+		ok, err := table.parseAlterAction(action)
+		if err != nil {
+			return fmt.Errorf("table %s: %w", table.name, err)
+		}
+
+		if !ok {
+			words := action[:1]
+			if len(action) > 1 && action[1].identifier() && !action[1].quoted {
+				words = action[:2]
+			}
+			s.ignore("ALTER TABLE %s %s", table.name, strings.ToUpper(words.String()))
+		}
+	}
+
+	return nil
+}
+
+// parseAlterAction parses the action of ALTER TABLE, ok is false if the action is not understood.
+// The constraints other than PRIMARY KEY and UNIQUE, such as the foreign keys, are ignored on purpose.
+func (t *pgTable) parseAlterAction(action pgTokens) (ok bool, err error) {
+	switch {
+	case action.hasPrefix("add"):
+		action = action[1:]
+		if len(action) > 0 && pgTableConstraints[action[0].text] && !action[0].quoted {
+			t.parseConstraint(action)
+			return true, nil
+		}
+
+		action = action.skip("column")
+		ifNotExists := action.hasPrefix("if", "not", "exists")
+		action = action.skip("if", "not", "exists")
+		if len(action) == 0 {
+			return false, nil
+		}
+
+		if t.findColumn(action[0].text) != nil {
+			if ifNotExists {
+				return true, nil
+			}
+			return false, fmt.Errorf("duplicate column %s", action[0].text)
+		}
+
+		column, err := parseColumn(t, action)
+		if err != nil {
+			return false, err
+		}
+
+		t.columns = append(t.columns, column)
+		return true, nil
+	case action.hasPrefix("drop", "constraint"):
+		action = action[2:].skip("if", "exists")
+		if len(action) > 0 {
+			t.dropIndex(action[0].text)
+		}
+		return true, nil
+	case action.hasPrefix("drop"):
+		action = action[1:].skip("column").skip("if", "exists")
+		if len(action) == 0 {
+			return false, nil
+		}
+
+		t.dropColumn(action[0].text)
+		return true, nil
+	case action.hasPrefix("alter"):
+		action = action[1:].skip("column")
+		if len(action) < 2 {
+			return false, nil
+		}
+
+		column := t.findColumn(action[0].text)
+		if column == nil {
+			return false, fmt.Errorf("unknown column %s", action[0].text)
+		}
+
+		return column.parseAlter(action[1:]), nil
+	}
+
+	return false, nil
+}
+
+// parseAlter parses the action of ALTER COLUMN, false is returned if the action is not understood
+func (c *pgColumn) parseAlter(action pgTokens) bool {
+	switch {
+	case action.hasPrefix("set", "default"):
+		c.hasDefault = true
+		c.autoIncrement = action.hasPrefix("set", "default", "nextval")
+	case action.hasPrefix("drop", "default"), action.hasPrefix("drop", "identity"):
+		c.hasDefault = false
+		c.autoIncrement = false
+	case action.hasPrefix("add", "generated"):
+		c.hasDefault = true
+		c.autoIncrement = true
+	case action.hasPrefix("set", "not", "null"):
+		c.notNull = true
+	case action.hasPrefix("drop", "not", "null"):
+		c.notNull = false
+	case action.hasPrefix("type"), action.hasPrefix("set", "data", "type"):
+		action = action.skip("set", "data").skip("type")
+		end := 0
+		for end < len(action) && !action[end].is("using") && !action[end].is("collate") {
+			end++
+		}
+		c.declaredType = action[:end].typeString()
+	default:
+		return false
+	}
+
+	return true
+}
+
+// dropColumn removes the column, and the indexes and the primary key with it like postgresql
+func (t *pgTable) dropColumn(name string) {
+	for i, column := range t.columns {
+		if column.name == name {
+			t.columns = append(t.columns[:i], t.columns[i+1:]...)
+			break
+		}
+	}
+
+	for _, column := range t.primary {
+		if column == name {
+			t.primary = nil
+			break
+		}
+	}
+
+	var indexes []*pgIndex
+	for _, index := range t.indexes {
+		var dropped bool
+		for _, column := range index.columns {
+			dropped = dropped || column == name
+		}
+		if !dropped {
+			indexes = append(indexes, index)
+		}
+	}
+	t.indexes = indexes
+}
+
+// dropIndex removes the index or the unique constraint by name
+func (t *pgTable) dropIndex(name string) bool {
+	for i, index := range t.indexes {
+		if index.name == name {
+			t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseDropTable removes the dropped tables, so that the migrations can recreate them
+func (s *pgSchema) parseDropTable(ts pgTokens) {
+	for _, each := range ts.skip("if", "exists").split(",") {
+		names, _ := each.qualifiedName()
+		if len(names) == 0 {
+			continue
+		}
+
+		for i, table := range s.tables {
+			if table.name == names[len(names)-1] {
+				s.tables = append(s.tables[:i], s.tables[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// parseDropIndex removes the dropped indexes from the tables
+func (s *pgSchema) parseDropIndex(ts pgTokens) {
+	for _, each := range ts.skip("concurrently").skip("if", "exists").split(",") {
+		names, _ := each.qualifiedName()
+		if len(names) == 0 {
+			continue
+		}
+
+		var dropped bool
+		for _, table := range s.tables {
+			if table.dropIndex(names[len(names)-1]) {
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			s.ignore("statement DROP INDEX of unknown index %s", names[len(names)-1])
+		}
+	}
+}
+
+// parseComment parses COMMENT ON COLUMN [schema.]table.column IS 'comment'
+func (s *pgSchema) parseComment(ts pgTokens) {
+	names, rest := ts.qualifiedName()
+	if len(names) < 2 || !rest.hasPrefix("is") || len(rest) < 2 || !rest[1].str {
+		s.ignore("statement COMMENT ON COLUMN %s", strings.Join(names, "."))
+		return
+	}
+
+	table := s.findTable(names[len(names)-2])
+	if table == nil {
+		s.ignore("comment on column of unknown table %s", names[len(names)-2])
+		return
+	}
+
+	if column := table.findColumn(names[len(names)-1]); column != nil {
+		column.comment = rest[1].text
+		return
+	}
+
+	s.ignore("comment on unknown column %s.%s", table.name, names[len(names)-1])
+}
+
+func (s *pgSchema) findTable(name string) *pgTable {
+	for _, each := range s.tables {
+		if each.name == name {
+			return each
+		}
+	}
+
+	return nil
+}
+
+func (t *pgTable) findColumn(name string) *pgColumn {
+	for _, each := range t.columns {
+		if each.name == name {
+			return each
+		}
+	}
+
+	return nil
+}
+
+// convert converts the table into the model table like the ones from information_schema
+func (s *pgSchema) convert(table *pgTable) (*model.Table, error) {
+	index := make(map[string][]*model.DbIndex)
+	for i, column := range table.primary {
+		if table.findColumn(column) == nil {
+			return nil, fmt.Errorf("table %s: primary key %s is not a column", table.name, column)
+		}
+
+		index[column] = append(index[column], &model.DbIndex{
+			IndexName:  indexPri,
+			SeqInIndex: i + 1,
+		})
+	}
+
+	for _, each := range table.indexes {
+		nonUnique := 0
+		if !each.unique {
+			nonUnique = 1
+		}
+
+		for i, column := range each.columns {
+			if table.findColumn(column) == nil {
+				return nil, fmt.Errorf("table %s: index %s: %s is not a column", table.name, each.name, column)
+			}
+
+			index[column] = append(index[column], &model.DbIndex{
+				IndexName:  each.name,
+				NonUnique:  nonUnique,
+				SeqInIndex: i + 1,
+			})
+		}
+	}
+
+	primarySet := make(map[string]bool)
+	for _, column := range table.primary {
+		primarySet[column] = true
+	}
+
+	var list []*model.Column
+	for i, each := range table.columns {
+		dataType, columnType, serial := s.convertType(each.declaredType)
+		if len(dataType) == 0 {
+			return nil, fmt.Errorf("table %s: column %s: unsupported type %s", table.name, each.name, each.declaredType)
+		}
+
+		var dft interface{}
+		if each.hasDefault || serial || each.autoIncrement {
+			dft = "default"
+		}
+
+		isNullAble := "YES"
+		if each.notNull || serial || primarySet[each.name] {
+			isNullAble = "NO"
+		}
+
+		var extra string
+		if serial || each.autoIncrement {
+			extra = autoIncrement
+		}
+
+		column := &model.DbColumn{
+			Name:            each.name,
+			DataType:        dataType,
+			ColumnType:      columnType,
+			Extra:           extra,
+			Comment:         each.comment,
+			ColumnDefault:   dft,
+			IsNullAble:      isNullAble,
+			OrdinalPosition: i + 1,
+		}
+		if len(index[each.name]) == 0 {
+			list = append(list, &model.Column{
+				DbColumn: column,
+			})
+			continue
+		}
+
+		for _, i := range index[each.name] {
+			list = append(list, &model.Column{
+				DbColumn: column,
+				Index:    i,
+			})
+		}
+	}
+
+	columnData := model.ColumnData{
+		Db:      table.schema,
+		Table:   table.name,
+		Columns: list,
+	}
+	return columnData.Convert()
+}
+
+// convertType returns the mysql data type and the column type for the declared type, the arrays are
+// converted into text and keep the column type like int[], which can be mapped by the custom types
+func (s *pgSchema) convertType(declared string) (dataType, columnType string, serial bool) {
+	columnType = declared
+	if strings.HasSuffix(declared, "]") {
+		element := strings.TrimSpace(declared[:strings.Index(declared, "[")])
+		return "text", pgTypeLengthRegex.ReplaceAllString(element, "") + "[]", false
+	}
+
+	names := strings.Split(declared, ".")
+	base := pgTypeLengthRegex.ReplaceAllString(names[len(names)-1], "")
+	if s.enums[base] {
+		return "enum", columnType, false
+	}
+
+	return pgTypes[base], columnType, pgSerialTypes[base]
+}
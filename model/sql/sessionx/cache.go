@@ -0,0 +1,144 @@
+package sessionx
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type (
+	// Transactor starts transactions, such as sqlx.SqlConn and sqlc.CachedConn
+	Transactor interface {
+		TransactCtx(ctx context.Context, fn func(context.Context, sqlx.Session) error) error
+	}
+
+	// txSession is the session of the transactions started by TransactCtx, which keeps the cache keys
+	// deleted in the transaction until it commits
+	txSession struct {
+		sqlx.Session
+		lock    sync.Mutex
+		pending []pendingKeys
+	}
+
+	pendingKeys struct {
+		cache cache.Cache
+		keys  []string
+	}
+
+	// sessionCache is the cache of the models bound to sessions, which reads through the session
+	// without caching the rows, since the rows are not committed yet
+	sessionCache struct {
+		cache.Cache
+		session *txSession
+	}
+)
+
+// TransactCtx runs fn in a transaction of conn, the cache keys deleted by the models bound to the
+// session are deleted after the transaction commits, so that neither the rolled back rows nor the
+// rows before commit are cached. The nested transactions run on the session of the outer one, their
+// keys are deleted by the outer one.
+func TransactCtx(ctx context.Context, conn Transactor, fn func(context.Context, sqlx.Session) error) error {
+	var (
+		nested  bool
+		session *txSession
+	)
+	err := conn.TransactCtx(ctx, func(ctx context.Context, s sqlx.Session) error {
+		if ts, ok := s.(*txSession); ok {
+			nested = true
+			return fn(ctx, ts)
+		}
+
+		session = &txSession{Session: s}
+		return fn(ctx, session)
+	})
+	if err != nil || nested {
+		return err
+	}
+
+	for _, each := range session.pending {
+		if err := each.cache.DelCtx(ctx, each.keys...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewSessionCache returns the cache of the models bound to session, which never reads or sets c.
+// The keys are deleted from c after commit if session is started by TransactCtx, otherwise they are
+// deleted immediately.
+func NewSessionCache(session sqlx.Session, c cache.Cache) cache.Cache {
+	ts, _ := session.(*txSession)
+	return sessionCache{
+		Cache:   c,
+		session: ts,
+	}
+}
+
+func (c sessionCache) Del(keys ...string) error {
+	return c.DelCtx(context.Background(), keys...)
+}
+
+func (c sessionCache) DelCtx(ctx context.Context, keys ...string) error {
+	if c.session == nil {
+		return c.Cache.DelCtx(ctx, keys...)
+	}
+
+	if len(keys) > 0 {
+		c.session.lock.Lock()
+		c.session.pending = append(c.session.pending, pendingKeys{cache: c.Cache, keys: keys})
+		c.session.lock.Unlock()
+	}
+
+	return nil
+}
+
+func (c sessionCache) Get(string, interface{}) error {
+	return sql.ErrNoRows
+}
+
+func (c sessionCache) GetCtx(context.Context, string, interface{}) error {
+	return sql.ErrNoRows
+}
+
+func (c sessionCache) IsNotFound(err error) bool {
+	return err == sql.ErrNoRows
+}
+
+func (c sessionCache) Set(string, interface{}) error {
+	return nil
+}
+
+func (c sessionCache) SetCtx(context.Context, string, interface{}) error {
+	return nil
+}
+
+func (c sessionCache) SetWithExpire(string, interface{}, time.Duration) error {
+	return nil
+}
+
+func (c sessionCache) SetWithExpireCtx(context.Context, string, interface{}, time.Duration) error {
+	return nil
+}
+
+func (c sessionCache) Take(val interface{}, _ string, query func(val interface{}) error) error {
+	return query(val)
+}
+
+func (c sessionCache) TakeCtx(_ context.Context, val interface{}, _ string, query func(val interface{}) error) error {
+	return query(val)
+}
+
+func (c sessionCache) TakeWithExpire(val interface{}, _ string,
+	query func(val interface{}, expire time.Duration) error) error {
+	return query(val, 0)
+}
+
+func (c sessionCache) TakeWithExpireCtx(_ context.Context, val interface{}, _ string,
+	query func(val interface{}, expire time.Duration) error) error {
+	return query(val, 0)
+}
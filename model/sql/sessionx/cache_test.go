@@ -0,0 +1,92 @@
+package sessionx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/redis/redistest"
+	"github.com/zeromicro/go-zero/core/stores/sqlc"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+func TestTransactCtx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := NewCache(cache.CacheConf{
+		{
+			RedisConf: redis.RedisConf{
+				Host: r.Addr,
+				Type: redis.NodeType,
+			},
+			Weight: 100,
+		},
+	})
+	conn := sqlc.NewConnWithCache(sqlx.NewSqlConnFromDB(db), c)
+	ctx := context.Background()
+	var val string
+	assert.Nil(t, c.SetCtx(ctx, "user", "old"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("delete").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err = TransactCtx(ctx, conn, func(ctx context.Context, session sqlx.Session) error {
+		bound := sqlc.NewConnWithCache(NewSqlConn(session), NewSessionCache(session, c))
+		err := bound.QueryRowCtx(ctx, &val, "user", func(ctx context.Context, conn sqlx.SqlConn, v interface{}) error {
+			*v.(*string) = "uncommitted"
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "uncommitted", val)
+
+		_, err = bound.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+			return conn.ExecCtx(ctx, "update user set name = ?", "uncommitted")
+		}, "user")
+		assert.Nil(t, err)
+		assert.Nil(t, c.GetCtx(ctx, "user", &val), "the keys are deleted after commit")
+		assert.Equal(t, "old", val)
+
+		return TransactCtx(ctx, bound, func(ctx context.Context, inner sqlx.Session) error {
+			assert.Equal(t, session, inner)
+			_, err := sqlc.NewConnWithCache(NewSqlConn(inner), NewSessionCache(inner, c)).ExecCtx(ctx,
+				func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+					return conn.ExecCtx(ctx, "delete from class where id = ?", 1)
+				}, "class")
+			assert.Nil(t, c.SetCtx(ctx, "class", "old"))
+			return err
+		})
+	})
+	assert.Nil(t, err)
+	assert.True(t, c.IsNotFound(c.GetCtx(ctx, "user", &val)))
+	assert.True(t, c.IsNotFound(c.GetCtx(ctx, "class", &val)))
+
+	errRollback := errors.New("rollback")
+	assert.Nil(t, c.SetCtx(ctx, "user", "old"))
+	mock.ExpectBegin()
+	mock.ExpectExec("update").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	err = TransactCtx(ctx, conn, func(ctx context.Context, session sqlx.Session) error {
+		_, err := sqlc.NewConnWithCache(NewSqlConn(session), NewSessionCache(session, c)).ExecCtx(ctx,
+			func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+				return conn.ExecCtx(ctx, "update user set name = ?", "rolled back")
+			}, "user")
+		assert.Nil(t, err)
+		return errRollback
+	})
+	assert.Equal(t, errRollback, err)
+	assert.Nil(t, c.GetCtx(ctx, "user", &val))
+	assert.Equal(t, "old", val)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
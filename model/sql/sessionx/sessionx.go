@@ -0,0 +1,51 @@
+package sessionx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/core/syncx"
+)
+
+var (
+	// ErrNoRawDB is returned by RawDB of the connections on sessions
+	ErrNoRawDB = errors.New("no raw db on a session")
+
+	singleFlights = syncx.NewSingleFlight()
+	stats         = cache.NewStat("sqlc")
+)
+
+type sessionConn struct {
+	sqlx.Session
+}
+
+// NewSqlConn returns a sqlx.SqlConn which runs on session, such as a transaction. The transactions
+// started on it run on session directly, so that the models bound to a transaction can be nested.
+func NewSqlConn(session sqlx.Session) sqlx.SqlConn {
+	if conn, ok := session.(sqlx.SqlConn); ok {
+		return conn
+	}
+
+	return sessionConn{Session: session}
+}
+
+// NewCache returns a cache.Cache of c in the same way as sqlc.NewConn, the models keep it
+// to share the cache with the models bound to sessions
+func NewCache(c cache.CacheConf, opts ...cache.Option) cache.Cache {
+	return cache.New(c, singleFlights, stats, sql.ErrNoRows, opts...)
+}
+
+func (c sessionConn) RawDB() (*sql.DB, error) {
+	return nil, ErrNoRawDB
+}
+
+func (c sessionConn) Transact(fn func(sqlx.Session) error) error {
+	return fn(c.Session)
+}
+
+func (c sessionConn) TransactCtx(ctx context.Context, fn func(context.Context, sqlx.Session) error) error {
+	return fn(ctx, c.Session)
+}
@@ -0,0 +1,36 @@
+package sessionx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+func TestNewSqlConn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	conn := sqlx.NewSqlConnFromDB(db)
+	assert.Equal(t, conn, NewSqlConn(conn))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err = conn.TransactCtx(context.Background(), func(ctx context.Context, session sqlx.Session) error {
+		sc := NewSqlConn(session)
+		_, err := sc.RawDB()
+		assert.Equal(t, ErrNoRawDB, err)
+
+		return sc.TransactCtx(ctx, func(ctx context.Context, inner sqlx.Session) error {
+			assert.Equal(t, session, inner)
+			_, err := inner.ExecCtx(ctx, "delete from user where id = ?", 1)
+			return err
+		})
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,181 @@
+package template
+
+// Api defines a template for the CRUD api definitions of tables
+var Api = `syntax = "v1"
+
+info(
+	title: "{{.service}}"
+	desc: "the CRUD apis of {{.tableNames}}"
+)
+{{range .tables}}
+type (
+	// {{.Name}} is a row of table {{.Table}}
+	{{.Name}} {
+		{{- range .Fields}}{{if .Comment}}
+		// {{.Comment}}{{end}}
+		{{.Name}} {{.ApiType}} ` + "`json:\"{{.JsonName}}\"`" + `
+		{{- end}}
+	}
+
+	Create{{.Name}}Req {
+		{{- range .Create}}{{if .Comment}}
+		// {{.Comment}}{{end}}
+		{{.Name}} {{.ApiType}} ` + "`json:\"{{.JsonName}}{{if .Optional}},optional{{end}}\"`" + `
+		{{- end}}
+	}
+
+	Create{{.Name}}Resp {
+		{{- range .Primary}}
+		{{.Name}} {{.ApiType}} ` + "`json:\"{{.JsonName}}\"`" + `
+		{{- end}}
+	}
+
+	Update{{.Name}}Req {
+		{{- range .Primary}}
+		{{.Name}} {{.ApiType}} ` + "`path:\"{{.JsonName}}\"`" + `
+		{{- end}}
+		{{- range .Update}}{{if .Comment}}
+		// {{.Comment}}{{end}}
+		{{.Name}} {{.ApiType}} ` + "`json:\"{{.JsonName}}{{if .Optional}},optional{{end}}\"`" + `
+		{{- end}}
+	}
+
+	Get{{.Name}}Req {
+		{{- range .Primary}}
+		{{.Name}} {{.ApiType}} ` + "`path:\"{{.JsonName}}\"`" + `
+		{{- end}}
+	}
+{{$name := .Name}}{{range .Unique}}
+	Get{{$name}}By{{.Name}}Req {
+		{{- range .Fields}}
+		{{.Name}} {{.ApiType}} ` + "`path:\"{{.JsonName}}\"`" + `
+		{{- end}}
+	}
+{{end}}
+	List{{.Name}}Req {
+		Page int64 ` + "`form:\"page,default=1\"`" + `
+		PageSize int64 ` + "`form:\"pageSize,default=20\"`" + `
+	}
+
+	List{{.Name}}Resp {
+		Total int64 ` + "`json:\"total\"`" + `
+		List []{{.Name}} ` + "`json:\"list\"`" + `
+	}
+
+	Delete{{.Name}}Req {
+		{{- range .Primary}}
+		{{.Name}} {{.ApiType}} ` + "`path:\"{{.JsonName}}\"`" + `
+		{{- end}}
+	}
+)
+
+@server(
+	group: {{.Group}}
+)
+service {{$.service}} {
+	@doc "create a row of {{.Table}}"
+	@handler Create{{.Name}}
+	post /{{.Path}} (Create{{.Name}}Req) returns (Create{{.Name}}Resp)
+
+	@doc "update a row of {{.Table}} by primary key"
+	@handler Update{{.Name}}
+	put /{{.Path}}{{.PrimaryPath}} (Update{{.Name}}Req)
+
+	@doc "get a row of {{.Table}} by primary key"
+	@handler Get{{.Name}}
+	get /{{.Path}}{{.PrimaryPath}} (Get{{.Name}}Req) returns ({{.Name}})
+{{$table := .}}{{range .Unique}}
+	@doc "get a row of {{$table.Table}} by {{.Columns}}"
+	@handler Get{{$table.Name}}By{{.Name}}
+	get /{{$table.Path}}/by-{{.Path}} (Get{{$table.Name}}By{{.Name}}Req) returns ({{$table.Name}})
+{{end}}
+	@doc "list the rows of {{.Table}} by page"
+	@handler List{{.Name}}
+	get /{{.Path}} (List{{.Name}}Req) returns (List{{.Name}}Resp)
+
+	@doc "delete a row of {{.Table}} by primary key"
+	@handler Delete{{.Name}}
+	delete /{{.Path}}{{.PrimaryPath}} (Delete{{.Name}}Req)
+}
+{{end}}`
+
+// Proto defines a template for the CRUD rpc definitions of tables
+var Proto = `syntax = "proto3";
+
+package {{.package}};
+option go_package = "./{{.package}}";
+{{range .tables}}
+// {{.Name}} is a row of table {{.Table}}
+message {{.Name}} {
+  {{- range .Fields}}{{if .Comment}}
+  // {{.Comment}}{{end}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+
+message Create{{.Name}}Req {
+  {{- range .Create}}{{if .Comment}}
+  // {{.Comment}}{{end}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+
+message Create{{.Name}}Resp {
+  {{- range .Primary}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+
+message Update{{.Name}}Req {
+  {{- range .PrimaryUpdate}}{{if .Comment}}
+  // {{.Comment}}{{end}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+
+message Update{{.Name}}Resp {}
+
+message Get{{.Name}}Req {
+  {{- range .Primary}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+{{$name := .Name}}{{range .Unique}}
+message Get{{$name}}By{{.Name}}Req {
+  {{- range .Fields}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+{{end}}
+message List{{.Name}}Req {
+  int64 page = 1;
+  int64 page_size = 2;
+}
+
+message List{{.Name}}Resp {
+  int64 total = 1;
+  repeated {{.Name}} list = 2;
+}
+
+message Delete{{.Name}}Req {
+  {{- range .Primary}}
+  {{.ProtoType}} {{.ProtoName}} = {{.Number}};
+  {{- end}}
+}
+
+message Delete{{.Name}}Resp {}
+{{end}}
+service {{.service}} {
+{{- range .tables}}{{$table := .}}
+  // {{.Table}}
+  rpc Create{{.Name}}(Create{{.Name}}Req) returns (Create{{.Name}}Resp);
+  rpc Update{{.Name}}(Update{{.Name}}Req) returns (Update{{.Name}}Resp);
+  rpc Get{{.Name}}(Get{{.Name}}Req) returns ({{.Name}});
+  {{- range .Unique}}
+  rpc Get{{$table.Name}}By{{.Name}}(Get{{$table.Name}}By{{.Name}}Req) returns ({{$table.Name}});
+  {{- end}}
+  rpc List{{.Name}}(List{{.Name}}Req) returns (List{{.Name}}Resp);
+  rpc Delete{{.Name}}(Delete{{.Name}}Req) returns (Delete{{.Name}}Resp);
+{{- end}}
+}
+`
@@ -2,21 +2,28 @@ package template
 
 // Delete defines a delete template
 var Delete = `
-func (m *default{{.upperStartCamelObject}}Model) Delete({{.lowerStartCamelPrimaryKey}} {{.dataType}}) error {
-	{{if .withCache}}{{if .containsIndexCache}}data, err:=m.FindOne({{.lowerStartCamelPrimaryKey}})
+func (m *default{{.upperStartCamelObject}}Model) Delete({{.primaryKeyIn}}) error {
+	return m.DeleteCtx(context.Background(), {{.lowerStartCamelPrimaryKeys}})
+}
+
+func (m *default{{.upperStartCamelObject}}Model) DeleteCtx(ctx context.Context, {{.primaryKeyIn}}) error {
+	{{if .withCache}}{{if .containsIndexCache}}data, err:=m.FindOneCtx(ctx, {{.lowerStartCamelPrimaryKeys}})
 	if err!=nil{
 		return err
 	}{{end}}
 
 	{{.keys}}
-    _, err {{if .containsIndexCache}}={{else}}:={{end}} m.Exec(func(conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("delete from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}}", m.table)
-		return conn.Exec(query, {{.lowerStartCamelPrimaryKey}})
-	}, {{.keyValues}}){{else}}query := fmt.Sprintf("delete from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}}", m.table)
-		_,err:=m.conn.Exec(query, {{.lowerStartCamelPrimaryKey}}){{end}}
+    _, err {{if .containsIndexCache}}={{else}}:={{end}} m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
+		{{if .softDelete}}query := fmt.Sprintf("update %s set {{.softDeleteSet}} where {{.originalPrimaryKeyCondition}}{{.notDeleted}}", m.table)
+		return conn.ExecCtx(ctx, query, {{.softDeleteArgs}}){{else}}query := fmt.Sprintf("delete from %s where {{.originalPrimaryKeyCondition}}", m.table)
+		return conn.ExecCtx(ctx, query, {{.lowerStartCamelPrimaryKeys}}){{end}}
+	}, {{.keyValues}}){{else}}{{if .softDelete}}query := fmt.Sprintf("update %s set {{.softDeleteSet}} where {{.originalPrimaryKeyCondition}}{{.notDeleted}}", m.table)
+		_,err:=m.conn.ExecCtx(ctx, query, {{.softDeleteArgs}}){{else}}query := fmt.Sprintf("delete from %s where {{.originalPrimaryKeyCondition}}", m.table)
+		_,err:=m.conn.ExecCtx(ctx, query, {{.lowerStartCamelPrimaryKeys}}){{end}}{{end}}
 	return err
 }
 `
 
 // DeleteMethod defines a delete template for interface method
-var DeleteMethod = `Delete({{.lowerStartCamelPrimaryKey}} {{.dataType}}) error`
+var DeleteMethod = `Delete({{.primaryKeyIn}}) error
+	DeleteCtx(ctx context.Context, {{.primaryKeyIn}}) error`
@@ -3,7 +3,15 @@ package template
 // Error defines an error template
 var Error = `package {{.pkg}}
 
-import "github.com/zeromicro/go-zero/core/stores/sqlx"
+import (
+	"errors"
 
-var ErrNotFound = sqlx.ErrNotFound
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var (
+	ErrNotFound = sqlx.ErrNotFound
+	// ErrConcurrentUpdate is returned by Update if the version of data is out of date
+	ErrConcurrentUpdate = errors.New("concurrent update")
+)
 `
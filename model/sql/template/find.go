@@ -2,12 +2,16 @@ package template
 
 // FindOne defines find row by id.
 var FindOne = `
-func (m *default{{.upperStartCamelObject}}Model) FindOne({{.lowerStartCamelPrimaryKey}} {{.dataType}}) (*{{.upperStartCamelObject}}, error) {
+func (m *default{{.upperStartCamelObject}}Model) FindOne({{.primaryKeyIn}}) (*{{.upperStartCamelObject}}, error) {
+	return m.FindOneCtx(context.Background(), {{.lowerStartCamelPrimaryKeys}})
+}
+
+func (m *default{{.upperStartCamelObject}}Model) FindOneCtx(ctx context.Context, {{.primaryKeyIn}}) (*{{.upperStartCamelObject}}, error) {
 	{{if .withCache}}{{.cacheKey}}
 	var resp {{.upperStartCamelObject}}
-	err := m.QueryRow(&resp, {{.cacheKeyVariable}}, func(conn sqlx.SqlConn, v interface{}) error {
-		query :=  fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
-		return conn.QueryRow(v, query, {{.lowerStartCamelPrimaryKey}})
+	err := m.QueryRowCtx(ctx, &resp, {{.cacheKeyVariable}}, func(ctx context.Context, conn sqlx.SqlConn, v interface{}) error {
+		query :=  fmt.Sprintf("select %s from %s where {{.originalPrimaryKeyCondition}}{{.notDeleted}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
+		return conn.QueryRowCtx(ctx, v, query, {{.lowerStartCamelPrimaryKeys}})
 	})
 	switch err {
 	case nil:
@@ -16,9 +20,9 @@ func (m *default{{.upperStartCamelObject}}Model) FindOne({{.lowerStartCamelPrima
 		return nil, ErrNotFound
 	default:
 		return nil, err
-	}{{else}}query := fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
+	}{{else}}query := fmt.Sprintf("select %s from %s where {{.originalPrimaryKeyCondition}}{{.notDeleted}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
 	var resp {{.upperStartCamelObject}}
-	err := m.conn.QueryRow(&resp, query, {{.lowerStartCamelPrimaryKey}})
+	err := m.conn.QueryRowCtx(ctx, &resp, query, {{.lowerStartCamelPrimaryKeys}})
 	switch err {
 	case nil:
 		return &resp, nil
@@ -33,14 +37,18 @@ func (m *default{{.upperStartCamelObject}}Model) FindOne({{.lowerStartCamelPrima
 // FindOneByField defines find row by field.
 var FindOneByField = `
 func (m *default{{.upperStartCamelObject}}Model) FindOneBy{{.upperField}}({{.in}}) (*{{.upperStartCamelObject}}, error) {
+	return m.FindOneBy{{.upperField}}Ctx(context.Background(), {{.lowerStartCamelField}})
+}
+
+func (m *default{{.upperStartCamelObject}}Model) FindOneBy{{.upperField}}Ctx(ctx context.Context, {{.in}}) (*{{.upperStartCamelObject}}, error) {
 	{{if .withCache}}{{.cacheKey}}
 	var resp {{.upperStartCamelObject}}
-	err := m.QueryRowIndex(&resp, {{.cacheKeyVariable}}, m.formatPrimary, func(conn sqlx.SqlConn, v interface{}) (i interface{}, e error) {
-		query := fmt.Sprintf("select %s from %s where {{.originalField}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
-		if err := conn.QueryRow(&resp, query, {{.lowerStartCamelField}}); err != nil {
+	err := m.QueryRowIndexCtx(ctx, &resp, {{.cacheKeyVariable}}, m.formatPrimary, func(ctx context.Context, conn sqlx.SqlConn, v interface{}) (i interface{}, e error) {
+		query := fmt.Sprintf("select %s from %s where {{.originalField}}{{.notDeleted}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
+		if err := conn.QueryRowCtx(ctx, &resp, query, {{.lowerStartCamelField}}); err != nil {
 			return nil, err
 		}
-		return resp.{{.upperStartCamelPrimaryKey}}, nil
+		return {{.primaryKeyValue}}, nil
 	}, m.queryPrimary)
 	switch err {
 	case nil:
@@ -51,8 +59,8 @@ func (m *default{{.upperStartCamelObject}}Model) FindOneBy{{.upperField}}({{.in}
 		return nil, err
 	}
 }{{else}}var resp {{.upperStartCamelObject}}
-	query := fmt.Sprintf("select %s from %s where {{.originalField}} limit 1", {{.lowerStartCamelObject}}Rows, m.table )
-	err := m.conn.QueryRow(&resp, query, {{.lowerStartCamelField}})
+	query := fmt.Sprintf("select %s from %s where {{.originalField}}{{.notDeleted}} limit 1", {{.lowerStartCamelObject}}Rows, m.table )
+	err := m.conn.QueryRowCtx(ctx, &resp, query, {{.lowerStartCamelField}})
 	switch err {
 	case nil:
 		return &resp, nil
@@ -67,17 +75,56 @@ func (m *default{{.upperStartCamelObject}}Model) FindOneBy{{.upperField}}({{.in}
 // FindOneByFieldExtraMethod defines find row by field with extras.
 var FindOneByFieldExtraMethod = `
 func (m *default{{.upperStartCamelObject}}Model) formatPrimary(primary interface{}) string {
-	return fmt.Sprintf("%s%v", {{.primaryKeyLeft}}, primary)
+	{{if .compositePrimary}}keys := primary.([]interface{})
+	return fmt.Sprintf("%s{{.primaryKeyFormat}}", {{.primaryKeyLeft}}, {{.primaryKeyElements}}){{else}}return fmt.Sprintf("%s%v", {{.primaryKeyLeft}}, primary){{end}}
+}
+
+func (m *default{{.upperStartCamelObject}}Model) queryPrimary(ctx context.Context, conn sqlx.SqlConn, v, primary interface{}) error {
+	query := fmt.Sprintf("select %s from %s where {{.originalPrimaryKeyCondition}}{{.notDeleted}} limit 1", {{.lowerStartCamelObject}}Rows, m.table )
+	return conn.QueryRowCtx(ctx, v, query, {{if .compositePrimary}}primary.([]interface{})...{{else}}primary{{end}})
+}
+`
+
+// FindListByField defines find rows by the fields of normal index.
+var FindListByField = `
+func (m *default{{.upperStartCamelObject}}Model) FindListBy{{.upperField}}({{.in}}, limit, offset int64) ([]*{{.upperStartCamelObject}}, error) {
+	return m.FindListBy{{.upperField}}Ctx(context.Background(), {{.lowerStartCamelField}}, limit, offset)
+}
+
+func (m *default{{.upperStartCamelObject}}Model) FindListBy{{.upperField}}Ctx(ctx context.Context, {{.in}}, limit, offset int64) ([]*{{.upperStartCamelObject}}, error) {
+	query := fmt.Sprintf("select %s from %s where {{.originalField}}{{.notDeleted}} order by {{.orderBy}} limit {{.limit}}", {{.lowerStartCamelObject}}Rows, m.table)
+	var resp []*{{.upperStartCamelObject}}
+	err := {{if .withCache}}m.QueryRowsNoCacheCtx{{else}}m.conn.QueryRowsCtx{{end}}(ctx, &resp, query, {{.lowerStartCamelField}}, limit, offset)
+	return resp, err
+}
+`
+
+// CountByField defines count rows by the fields of normal index.
+var CountByField = `
+func (m *default{{.upperStartCamelObject}}Model) CountBy{{.upperField}}({{.in}}) (int64, error) {
+	return m.CountBy{{.upperField}}Ctx(context.Background(), {{.lowerStartCamelField}})
 }
 
-func (m *default{{.upperStartCamelObject}}Model) queryPrimary(conn sqlx.SqlConn, v, primary interface{}) error {
-	query := fmt.Sprintf("select %s from %s where {{.originalPrimaryField}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table )
-	return conn.QueryRow(v, query, primary)
+func (m *default{{.upperStartCamelObject}}Model) CountBy{{.upperField}}Ctx(ctx context.Context, {{.in}}) (int64, error) {
+	query := fmt.Sprintf("select count(*) from %s where {{.originalField}}{{.notDeleted}}", m.table)
+	var count int64
+	err := {{if .withCache}}m.QueryRowNoCacheCtx{{else}}m.conn.QueryRowCtx{{end}}(ctx, &count, query, {{.lowerStartCamelField}})
+	return count, err
 }
 `
 
 // FindOneMethod defines find row method.
-var FindOneMethod = `FindOne({{.lowerStartCamelPrimaryKey}} {{.dataType}}) (*{{.upperStartCamelObject}}, error)`
+var FindOneMethod = `FindOne({{.primaryKeyIn}}) (*{{.upperStartCamelObject}}, error)
+	FindOneCtx(ctx context.Context, {{.primaryKeyIn}}) (*{{.upperStartCamelObject}}, error)`
 
 // FindOneByFieldMethod defines find row by field method.
-var FindOneByFieldMethod = `FindOneBy{{.upperField}}({{.in}}) (*{{.upperStartCamelObject}}, error) `
+var FindOneByFieldMethod = `FindOneBy{{.upperField}}({{.in}}) (*{{.upperStartCamelObject}}, error)
+	FindOneBy{{.upperField}}Ctx(ctx context.Context, {{.in}}) (*{{.upperStartCamelObject}}, error) `
+
+// FindListByFieldMethod defines find rows by field method.
+var FindListByFieldMethod = `FindListBy{{.upperField}}({{.in}}, limit, offset int64) ([]*{{.upperStartCamelObject}}, error)
+	FindListBy{{.upperField}}Ctx(ctx context.Context, {{.in}}, limit, offset int64) ([]*{{.upperStartCamelObject}}, error)`
+
+// CountByFieldMethod defines count rows by field method.
+var CountByFieldMethod = `CountBy{{.upperField}}({{.in}}) (int64, error)
+	CountBy{{.upperField}}Ctx(ctx context.Context, {{.in}}) (int64, error)`
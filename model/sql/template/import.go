@@ -3,29 +3,37 @@ package template
 var (
 	// Imports defines a import template for model in cache case
 	Imports = `import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"strings"
-	{{if .time}}"time"{{end}}
+	{{.stdImports}}{{if .time}}"time"{{end}}
 
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlc"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 	"github.com/zeromicro/go-zero/core/stringx"
 	"github.com/weitrue/goctl/model/sql/builderx"
+	"github.com/weitrue/goctl/model/sql/sessionx"
+	{{.thirdImports}}
 )
 `
 	// ImportsNoCache defines a import template for model in normal case
 	ImportsNoCache = `import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"strings"
-	{{if .time}}"time"{{end}}
+	{{.stdImports}}{{if .time}}"time"{{end}}
 
 	"github.com/zeromicro/go-zero/core/stores/sqlc"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 	"github.com/zeromicro/go-zero/core/stringx"
 	"github.com/weitrue/goctl/model/sql/builderx"
+	"github.com/weitrue/goctl/model/sql/sessionx"
+	{{.thirdImports}}
 )
 `
 )
@@ -3,17 +3,48 @@ package template
 // Insert defines a template for insert code in model
 var Insert = `
 func (m *default{{.upperStartCamelObject}}Model) Insert(data {{.upperStartCamelObject}}) (sql.Result,error) {
+	return m.InsertCtx(context.Background(), data)
+}
+
+func (m *default{{.upperStartCamelObject}}Model) InsertCtx(ctx context.Context, data {{.upperStartCamelObject}}) (sql.Result,error) {
 	{{if .withCache}}{{if .containsIndexCache}}{{.keys}}
-    ret, err := m.Exec(func(conn sqlx.SqlConn) (result sql.Result, err error) {
+    ret, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("insert into %s (%s) values ({{.expression}})", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet)
-		return conn.Exec(query, {{.expressionValues}})
+		return conn.ExecCtx(ctx, query, {{.expressionValues}})
 	}, {{.keyValues}}){{else}}query := fmt.Sprintf("insert into %s (%s) values ({{.expression}})", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet)
-    ret,err:=m.ExecNoCache(query, {{.expressionValues}})
+    ret,err:=m.ExecNoCacheCtx(ctx, query, {{.expressionValues}})
 	{{end}}{{else}}query := fmt.Sprintf("insert into %s (%s) values ({{.expression}})", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet)
-    ret,err:=m.conn.Exec(query, {{.expressionValues}}){{end}}
+    ret,err:=m.conn.ExecCtx(ctx, query, {{.expressionValues}}){{end}}
 	return ret,err
 }
 `
 
 // InsertMethod defines a interface method template for insert code in model
-var InsertMethod = `Insert(data {{.upperStartCamelObject}}) (sql.Result,error)`
+var InsertMethod = `Insert(data {{.upperStartCamelObject}}) (sql.Result,error)
+	InsertCtx(ctx context.Context, data {{.upperStartCamelObject}}) (sql.Result,error)`
+
+// InsertBatch defines a template for batch insert code in model
+var InsertBatch = `
+func (m *default{{.upperStartCamelObject}}Model) InsertBatch(ctx context.Context, list []*{{.upperStartCamelObject}}) (sql.Result,error) {
+	if len(list) == 0 {
+		return driver.RowsAffected(0), nil
+	}
+
+	values := make([]string, 0, len(list))
+	args := make([]interface{}, 0, len(list)*{{.fieldCount}})
+	{{if .withCache}}keys := make([]string, 0, len(list)*{{.keyCount}})
+	{{end}}for _, data := range list {
+		values = append(values, {{.batchExpression}})
+		args = append(args, {{.expressionValues}})
+		{{if .withCache}}keys = append(keys, {{.batchKeys}})
+	{{end}}}
+
+	query := fmt.Sprintf("insert into %s (%s) values %s", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet, strings.Join(values, ", "))
+	{{if .withCache}}return m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
+		return conn.ExecCtx(ctx, query, args...)
+	}, keys...){{else}}return m.conn.ExecCtx(ctx, query, args...){{end}}
+}
+`
+
+// InsertBatchMethod defines an interface method template for batch insert code in model
+var InsertBatchMethod = `InsertBatch(ctx context.Context, list []*{{.upperStartCamelObject}}) (sql.Result,error)`
@@ -10,5 +10,6 @@ var Model = `package {{.pkg}}
 {{.find}}
 {{.update}}
 {{.delete}}
+{{.trans}}
 {{.extraMethod}}
 `
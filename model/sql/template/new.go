@@ -3,8 +3,10 @@ package template
 // New defines an template for creating model instance
 var New = `
 func New{{.upperStartCamelObject}}Model(conn sqlx.SqlConn{{if .withCache}}, c cache.CacheConf{{end}}) {{.upperStartCamelObject}}Model {
-	return &default{{.upperStartCamelObject}}Model{
-		{{if .withCache}}CachedConn: sqlc.NewConn(conn, c){{else}}conn:conn{{end}},
+	{{if .withCache}}cc := sessionx.NewCache(c)
+	{{end}}return &default{{.upperStartCamelObject}}Model{
+		{{if .withCache}}CachedConn: sqlc.NewConnWithCache(conn, cc),
+		cache:      cc{{else}}conn:conn{{end}},
 		table:      {{.table}},
 	}
 }
@@ -0,0 +1,20 @@
+package template
+
+// Trans defines a template for running model operations in a transaction
+var Trans = `
+func (m *default{{.upperStartCamelObject}}Model) Trans(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error {
+	{{if .withCache}}return sessionx.TransactCtx(ctx, m.CachedConn, fn){{else}}return m.conn.TransactCtx(ctx, fn){{end}}
+}
+
+func (m *default{{.upperStartCamelObject}}Model) WithSession(session sqlx.Session) {{.upperStartCamelObject}}Model {
+	return &default{{.upperStartCamelObject}}Model{
+		{{if .withCache}}CachedConn: sqlc.NewConnWithCache(sessionx.NewSqlConn(session), sessionx.NewSessionCache(session, m.cache)),
+		cache:      m.cache{{else}}conn:       sessionx.NewSqlConn(session){{end}},
+		table:      m.table,
+	}
+}
+`
+
+// TransMethod defines an interface method template for running model operations in a transaction
+var TransMethod = `Trans(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error
+	WithSession(session sqlx.Session) {{.upperStartCamelObject}}Model`
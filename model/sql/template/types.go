@@ -8,7 +8,8 @@ type (
 	}
 
 	default{{.upperStartCamelObject}}Model struct {
-		{{if .withCache}}sqlc.CachedConn{{else}}conn sqlx.SqlConn{{end}}
+		{{if .withCache}}sqlc.CachedConn
+		cache cache.Cache{{else}}conn sqlx.SqlConn{{end}}
 		table string
 	}
 
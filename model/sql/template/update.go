@@ -3,15 +3,32 @@ package template
 // Update defines a template for generating update codes
 var Update = `
 func (m *default{{.upperStartCamelObject}}Model) Update(data {{.upperStartCamelObject}}) error {
+	return m.UpdateCtx(context.Background(), data)
+}
+
+func (m *default{{.upperStartCamelObject}}Model) UpdateCtx(ctx context.Context, data {{.upperStartCamelObject}}) error {
 	{{if .withCache}}{{.keys}}
-    _, err := m.Exec(func(conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("update %s set %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}}", m.table, {{.lowerStartCamelObject}}RowsWithPlaceHolder)
-		return conn.Exec(query, {{.expressionValues}})
-	}, {{.keyValues}}){{else}}query := fmt.Sprintf("update %s set %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}}", m.table, {{.lowerStartCamelObject}}RowsWithPlaceHolder)
-    _,err:=m.conn.Exec(query, {{.expressionValues}}){{end}}
-	return err
+    {{if .version}}ret{{else}}_{{end}}, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
+		query := fmt.Sprintf("update %s set %s{{.versionSet}} where {{.originalPrimaryKeyCondition}}{{.notDeleted}}", m.table, {{.lowerStartCamelObject}}RowsWithPlaceHolder)
+		return conn.ExecCtx(ctx, query, {{.expressionValues}})
+	}, {{.keyValues}}){{else}}query := fmt.Sprintf("update %s set %s{{.versionSet}} where {{.originalPrimaryKeyCondition}}{{.notDeleted}}", m.table, {{.lowerStartCamelObject}}RowsWithPlaceHolder)
+    {{if .version}}ret{{else}}_{{end}},err:=m.conn.ExecCtx(ctx, query, {{.expressionValues}}){{end}}
+	{{if .version}}if err != nil {
+		return err
+	}
+
+	rows, err := ret.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrConcurrentUpdate
+	}
+
+	return nil{{else}}return err{{end}}
 }
 `
 
 // UpdateMethod defines an interface method template for generating update codes
-var UpdateMethod = `Update(data {{.upperStartCamelObject}}) error`
+var UpdateMethod = `Update(data {{.upperStartCamelObject}}) error
+	UpdateCtx(ctx context.Context, data {{.upperStartCamelObject}}) error`
@@ -0,0 +1,23 @@
+package template
+
+// Upsert defines a template for inserting or updating on conflicts in model
+var Upsert = `
+func (m *default{{.upperStartCamelObject}}Model) Upsert(ctx context.Context, data *{{.upperStartCamelObject}}) (sql.Result,error) {
+	{{if .withCache}}{{.keys}}
+	keys := []string{ {{.keyValues}} }
+	{{range .lookups}}if old, err := m.{{.}}; err == nil {
+		keys = append(keys, {{$.oldKeyValues}})
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+	{{end}}
+    return m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
+		query := fmt.Sprintf("insert into %s (%s) values ({{.expression}}) {{.conflict}}", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet)
+		return conn.ExecCtx(ctx, query, {{.expressionValues}})
+	}, keys...){{else}}query := fmt.Sprintf("insert into %s (%s) values ({{.expression}}) {{.conflict}}", m.table, {{.lowerStartCamelObject}}RowsExpectAutoSet)
+	return m.conn.ExecCtx(ctx, query, {{.expressionValues}}){{end}}
+}
+`
+
+// UpsertMethod defines an interface method template for inserting or updating on conflicts in model
+var UpsertMethod = `Upsert(ctx context.Context, data *{{.upperStartCamelObject}}) (sql.Result,error)`
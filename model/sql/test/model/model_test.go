@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -126,6 +127,43 @@ func TestStudentModel(t *testing.T) {
 	})
 	assert.Nil(t, err)
 
+	err = mockStudent(func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(fmt.Sprintf("select (.+) from %s ", testTable)).
+			WithArgs(testInsertId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "class", "name", "age", "score", "create_time", "update_time"}).AddRow(testInsertId, data.Class, data.Name, data.Age, data.Score, testTimeValue, testTimeValue))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectQuery(fmt.Sprintf("select (.+) from %s ", testTable)).
+			WithArgs(class, testUpdateName).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "class", "name", "age", "score", "create_time", "update_time"}).AddRow(testInsertId, data.Class, data.Name, data.Age, data.Score, testTimeValue, testTimeValue))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(fmt.Sprintf("insert into %s", testTable)).
+			WithArgs(class, testUpdateName, data.Age, data.Score).
+			WillReturnResult(sqlmock.NewResult(testInsertId, testRowsAffected))
+	}, func(m StudentModel, redis *redis.Redis) {
+		_, err := m.FindOne(testInsertId)
+		assert.Nil(t, err)
+
+		// the id of upserted data is unset, the cache of existing row is found by the unique key
+		_, err = m.Upsert(context.Background(), &Student{
+			Class: class,
+			Name:  testUpdateName,
+			Age:   data.Age,
+			Score: data.Score,
+		})
+		assert.Nil(t, err)
+
+		val, err := redis.Get(fmt.Sprintf("%s%v", cacheStudentIdPrefix, testInsertId))
+		assert.Nil(t, err)
+		assert.Equal(t, "", val)
+
+		val, err = redis.Get(fmt.Sprintf("%s%v%v", cacheStudentClassNamePrefix, class, testUpdateName))
+		assert.Nil(t, err)
+		assert.Equal(t, "", val)
+	})
+	assert.Nil(t, err)
+
 	err = mockStudent(func(mock sqlmock.Sqlmock) {
 		mock.ExpectExec(fmt.Sprintf("delete from %s where `id` = ?", testTable)).WithArgs(testInsertId).WillReturnResult(sqlmock.NewResult(testInsertId, testRowsAffected))
 	}, func(m StudentModel, redis *redis.Redis) {
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -30,6 +31,7 @@ type (
 		FindOne(id int64) (*Student, error)
 		FindOneByClassName(class, name string) (*Student, error)
 		Update(data Student) error
+		Upsert(ctx context.Context, data *Student) (sql.Result, error)
 		// only for test
 		Delete(id int64, className, studentName string) error
 	}
@@ -114,6 +116,23 @@ func (m *defaultStudentModel) Update(data Student) error {
 	return err
 }
 
+func (m *defaultStudentModel) Upsert(ctx context.Context, data *Student) (sql.Result, error) {
+	studentIdKey := fmt.Sprintf("%s%v", cacheStudentIdPrefix, data.Id)
+	studentClassNameKey := fmt.Sprintf("%s%v%v", cacheStudentClassNamePrefix, data.Class, data.Name)
+	keys := []string{studentIdKey, studentClassNameKey}
+	if old, err := m.FindOneByClassName(data.Class, data.Name); err == nil {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheStudentIdPrefix, old.Id),
+			fmt.Sprintf("%s%v%v", cacheStudentClassNamePrefix, old.Class, old.Name))
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+
+	return m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?) on duplicate key update `age` = values(`age`), `score` = values(`score`)", m.table, studentRowsExpectAutoSet)
+		return conn.ExecCtx(ctx, query, data.Class, data.Name, data.Age, data.Score)
+	}, keys...)
+}
+
 func (m *defaultStudentModel) Delete(id int64, className, studentName string) error {
 	studentIdKey := fmt.Sprintf("%s%v", cacheStudentIdPrefix, id)
 	studentClassNameKey := fmt.Sprintf("%s%v%v", cacheStudentClassNamePrefix, className, studentName)
@@ -3,6 +3,7 @@
 package mocksql
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
@@ -26,39 +27,69 @@ func NewMockConn(db *sql.DB) *MockConn {
 
 // Exec executes sql and returns the result
 func (conn *MockConn) Exec(query string, args ...interface{}) (sql.Result, error) {
-	return exec(conn.db, query, args...)
+	return conn.ExecCtx(context.Background(), query, args...)
+}
+
+// ExecCtx executes sql with ctx and returns the result
+func (conn *MockConn) ExecCtx(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return exec(ctx, conn.db, query, args...)
 }
 
 // Prepare executes sql by sql.DB
 func (conn *MockConn) Prepare(query string) (sqlx.StmtSession, error) {
-	st, err := conn.db.Prepare(query)
+	return conn.PrepareCtx(context.Background(), query)
+}
+
+// PrepareCtx executes sql by sql.DB with ctx
+func (conn *MockConn) PrepareCtx(ctx context.Context, query string) (sqlx.StmtSession, error) {
+	st, err := conn.db.PrepareContext(ctx, query)
 	return statement{stmt: st}, err
 }
 
 // QueryRow executes sql and returns a query row
 func (conn *MockConn) QueryRow(v interface{}, q string, args ...interface{}) error {
-	return query(conn.db, func(rows *sql.Rows) error {
+	return conn.QueryRowCtx(context.Background(), v, q, args...)
+}
+
+// QueryRowCtx executes sql with ctx and returns a query row
+func (conn *MockConn) QueryRowCtx(ctx context.Context, v interface{}, q string, args ...interface{}) error {
+	return query(ctx, conn.db, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, true)
 	}, q, args...)
 }
 
 // QueryRowPartial executes sql and returns a partial query row
 func (conn *MockConn) QueryRowPartial(v interface{}, q string, args ...interface{}) error {
-	return query(conn.db, func(rows *sql.Rows) error {
+	return conn.QueryRowPartialCtx(context.Background(), v, q, args...)
+}
+
+// QueryRowPartialCtx executes sql with ctx and returns a partial query row
+func (conn *MockConn) QueryRowPartialCtx(ctx context.Context, v interface{}, q string, args ...interface{}) error {
+	return query(ctx, conn.db, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, false)
 	}, q, args...)
 }
 
 // QueryRows executes sql and returns  query rows
 func (conn *MockConn) QueryRows(v interface{}, q string, args ...interface{}) error {
-	return query(conn.db, func(rows *sql.Rows) error {
+	return conn.QueryRowsCtx(context.Background(), v, q, args...)
+}
+
+// QueryRowsCtx executes sql with ctx and returns query rows
+func (conn *MockConn) QueryRowsCtx(ctx context.Context, v interface{}, q string, args ...interface{}) error {
+	return query(ctx, conn.db, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, true)
 	}, q, args...)
 }
 
 // QueryRowsPartial executes sql and returns partial query rows
 func (conn *MockConn) QueryRowsPartial(v interface{}, q string, args ...interface{}) error {
-	return query(conn.db, func(rows *sql.Rows) error {
+	return conn.QueryRowsPartialCtx(context.Background(), v, q, args...)
+}
+
+// QueryRowsPartialCtx executes sql with ctx and returns partial query rows
+func (conn *MockConn) QueryRowsPartialCtx(ctx context.Context, v interface{}, q string, args ...interface{}) error {
+	return query(ctx, conn.db, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, false)
 	}, q, args...)
 }
@@ -68,9 +99,14 @@ func (conn *MockConn) RawDB() (*sql.DB, error) {
 	return conn.db, nil
 }
 
-// Transact is the implemention of sqlx.SqlConn, nothing to do
-func (conn *MockConn) Transact(func(session sqlx.Session) error) error {
-	return nil
+// Transact is the implemention of sqlx.SqlConn, fn is run with the connection itself
+func (conn *MockConn) Transact(fn func(session sqlx.Session) error) error {
+	return fn(conn)
+}
+
+// TransactCtx is the implemention of sqlx.SqlConn, fn is run with the connection itself
+func (conn *MockConn) TransactCtx(ctx context.Context, fn func(context.Context, sqlx.Session) error) error {
+	return fn(ctx, conn)
 }
 
 func (s statement) Close() error {
@@ -78,29 +114,49 @@ func (s statement) Close() error {
 }
 
 func (s statement) Exec(args ...interface{}) (sql.Result, error) {
-	return execStmt(s.stmt, args...)
+	return s.ExecCtx(context.Background(), args...)
+}
+
+func (s statement) ExecCtx(ctx context.Context, args ...interface{}) (sql.Result, error) {
+	return execStmt(ctx, s.stmt, args...)
 }
 
 func (s statement) QueryRow(v interface{}, args ...interface{}) error {
-	return queryStmt(s.stmt, func(rows *sql.Rows) error {
+	return s.QueryRowCtx(context.Background(), v, args...)
+}
+
+func (s statement) QueryRowCtx(ctx context.Context, v interface{}, args ...interface{}) error {
+	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, true)
 	}, args...)
 }
 
 func (s statement) QueryRowPartial(v interface{}, args ...interface{}) error {
-	return queryStmt(s.stmt, func(rows *sql.Rows) error {
+	return s.QueryRowPartialCtx(context.Background(), v, args...)
+}
+
+func (s statement) QueryRowPartialCtx(ctx context.Context, v interface{}, args ...interface{}) error {
+	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, false)
 	}, args...)
 }
 
 func (s statement) QueryRows(v interface{}, args ...interface{}) error {
-	return queryStmt(s.stmt, func(rows *sql.Rows) error {
+	return s.QueryRowsCtx(context.Background(), v, args...)
+}
+
+func (s statement) QueryRowsCtx(ctx context.Context, v interface{}, args ...interface{}) error {
+	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, true)
 	}, args...)
 }
 
 func (s statement) QueryRowsPartial(v interface{}, args ...interface{}) error {
-	return queryStmt(s.stmt, func(rows *sql.Rows) error {
+	return s.QueryRowsPartialCtx(context.Background(), v, args...)
+}
+
+func (s statement) QueryRowsPartialCtx(ctx context.Context, v interface{}, args ...interface{}) error {
+	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, false)
 	}, args...)
 }
@@ -3,6 +3,7 @@
 package mocksql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -13,8 +14,8 @@ import (
 
 const slowThreshold = time.Millisecond * 500
 
-func exec(db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
-	tx, err := db.Begin()
+func exec(ctx context.Context, db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +35,7 @@ func exec(db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
 	}
 
 	startTime := timex.Now()
-	result, err := tx.Exec(q, args...)
+	result, err := tx.ExecContext(ctx, q, args...)
 	duration := timex.Since(startTime)
 	if duration > slowThreshold {
 		logx.WithDuration(duration).Slowf("[SQL] exec: slowcall - %s", stmt)
@@ -48,10 +49,10 @@ func exec(db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
 	return result, err
 }
 
-func execStmt(conn *sql.Stmt, args ...interface{}) (sql.Result, error) {
+func execStmt(ctx context.Context, conn *sql.Stmt, args ...interface{}) (sql.Result, error) {
 	stmt := fmt.Sprint(args...)
 	startTime := timex.Now()
-	result, err := conn.Exec(args...)
+	result, err := conn.ExecContext(ctx, args...)
 	duration := timex.Since(startTime)
 	if duration > slowThreshold {
 		logx.WithDuration(duration).Slowf("[SQL] execStmt: slowcall - %s", stmt)
@@ -65,8 +66,8 @@ func execStmt(conn *sql.Stmt, args ...interface{}) (sql.Result, error) {
 	return result, err
 }
 
-func query(db *sql.DB, scanner func(*sql.Rows) error, q string, args ...interface{}) error {
-	tx, err := db.Begin()
+func query(ctx context.Context, db *sql.DB, scanner func(*sql.Rows) error, q string, args ...interface{}) error {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -86,7 +87,7 @@ func query(db *sql.DB, scanner func(*sql.Rows) error, q string, args ...interfac
 	}
 
 	startTime := timex.Now()
-	rows, err := tx.Query(q, args...)
+	rows, err := tx.QueryContext(ctx, q, args...)
 	duration := timex.Since(startTime)
 	if duration > slowThreshold {
 		logx.WithDuration(duration).Slowf("[SQL] query: slowcall - %s", stmt)
@@ -102,10 +103,10 @@ func query(db *sql.DB, scanner func(*sql.Rows) error, q string, args ...interfac
 	return scanner(rows)
 }
 
-func queryStmt(conn *sql.Stmt, scanner func(*sql.Rows) error, args ...interface{}) error {
+func queryStmt(ctx context.Context, conn *sql.Stmt, scanner func(*sql.Rows) error, args ...interface{}) error {
 	stmt := fmt.Sprint(args...)
 	startTime := timex.Now()
-	rows, err := conn.Query(args...)
+	rows, err := conn.QueryContext(ctx, args...)
 	duration := timex.Since(startTime)
 	if duration > slowThreshold {
 		logx.WithDuration(duration).Slowf("[SQL] queryStmt: slowcall - %s", stmt)
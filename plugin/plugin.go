@@ -16,6 +16,7 @@ import (
 	"github.com/urfave/cli"
 	"github.com/weitrue/goctl/api/parser"
 	"github.com/weitrue/goctl/api/spec"
+	"github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/rpc/execx"
 	"github.com/weitrue/goctl/util"
 )
@@ -30,16 +31,21 @@ type Plugin struct {
 	Dir         string
 }
 
-// PluginCommand is the entry of goctl api plugin
+// PluginCommand is the entry of goctl api plugin, the plugins in goctl.yaml are run in order
+// if -plugin is not set
 func PluginCommand(c *cli.Context) error {
-	ex, err := os.Executable()
-	if err != nil {
-		panic(err)
-	}
+	plugins := []string{c.String("plugin")}
+	if len(plugins[0]) == 0 {
+		project, err := config.LoadProject(".")
+		if err != nil {
+			return err
+		}
+
+		if project == nil || len(project.Api.Plugins) == 0 {
+			return errors.New("missing plugin")
+		}
 
-	plugin := c.String("plugin")
-	if len(plugin) == 0 {
-		return errors.New("missing plugin")
+		plugins = project.Api.Plugins
 	}
 
 	transferData, err := prepareArgs(c)
@@ -47,6 +53,21 @@ func PluginCommand(c *cli.Context) error {
 		return err
 	}
 
+	for _, plugin := range plugins {
+		if err = runPlugin(plugin, transferData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runPlugin(plugin string, transferData []byte) error {
+	ex, err := os.Executable()
+	if err != nil {
+		panic(err)
+	}
+
 	bin, args := getPluginAndArgs(plugin)
 
 	bin, download, err := getCommand(bin)
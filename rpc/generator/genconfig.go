@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/weitrue/goctl/rpc/parser"
 	"github.com/weitrue/goctl/util"
 	"github.com/weitrue/goctl/util/format"
+	"github.com/weitrue/goctl/util/preview"
 )
 
 const configTemplate = `package config
@@ -33,6 +33,9 @@ func (g *DefaultGenerator) GenConfig(ctx DirContext, _ parser.Proto, cfg *conf.C
 
 	fileName := filepath.Join(dir.Filename, configFilename+".go")
 	if util.FileExists(fileName) {
+		if preview.Enabled() {
+			preview.Skip(fileName)
+		}
 		return nil
 	}
 
@@ -41,5 +44,5 @@ func (g *DefaultGenerator) GenConfig(ctx DirContext, _ parser.Proto, cfg *conf.C
 		return err
 	}
 
-	return ioutil.WriteFile(fileName, []byte(text), os.ModePerm)
+	return util.WriteFile(fileName, []byte(text), os.ModePerm)
 }
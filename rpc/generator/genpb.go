@@ -3,12 +3,15 @@ package generator
 import (
 	"bytes"
 	"errors"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
 	conf "github.com/weitrue/goctl/config"
 	"github.com/weitrue/goctl/rpc/execx"
 	"github.com/weitrue/goctl/rpc/parser"
+	"github.com/weitrue/goctl/util/preview"
 	"github.com/zeromicro/go-zero/core/collection"
 )
 
@@ -50,11 +53,23 @@ func (g *DefaultGenerator) GenPb(ctx DirContext, protoImportPath []string, proto
 	}
 
 	cw.WriteString(" " + proto.Name)
+	out := dir.Filename
 	if strings.Contains(proto.GoPackage, "/") {
-		cw.WriteString(" --go_out=plugins=grpc:" + ctx.GetMain().Filename)
-	} else {
-		cw.WriteString(" --go_out=plugins=grpc:" + dir.Filename)
+		out = ctx.GetMain().Filename
+	}
+
+	// protoc writes the files by itself, so they are generated into a temporary directory
+	// and staged in preview mode
+	goOut := out
+	if preview.Enabled() {
+		temp, err := ioutil.TempDir("", "goctl-pb")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(temp)
+		goOut = temp
 	}
+	cw.WriteString(" --go_out=plugins=grpc:" + goOut)
 
 	// Compatible with version 1.4.0，github.com/golang/protobuf/protoc-gen-go@v1.4.0
 	// --go_opt usage please see https://developers.google.com/protocol-buffers/docs/reference/go-generated#package
@@ -103,5 +118,32 @@ go get -u github.com/golang/protobuf/protoc-gen-go`)
 
 		return err
 	}
-	return nil
+
+	if goOut == out {
+		return nil
+	}
+
+	return stagePb(goOut, out)
+}
+
+// stagePb stages the files which are generated by protoc in the temporary directory
+func stagePb(temp, out string) error {
+	return filepath.Walk(temp, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(temp, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		preview.Write(filepath.Join(out, rel), data)
+		return nil
+	})
 }
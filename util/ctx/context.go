@@ -2,9 +2,12 @@ package ctx
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/weitrue/goctl/rpc/execx"
+	"github.com/weitrue/goctl/util/preview"
 )
 
 var errModuleCheck = errors.New("the work directory must be found in the go mod or the $GOPATH")
@@ -27,6 +30,10 @@ type ProjectContext struct {
 // workDir parameter is the directory of the source of generating code,
 // where can be found the project path and the project module,
 func Prepare(workDir string) (*ProjectContext, error) {
+	if preview.Enabled() {
+		return preparePreview(workDir)
+	}
+
 	ctx, err := background(workDir)
 	if err == nil {
 		return ctx, nil
@@ -40,6 +47,43 @@ func Prepare(workDir string) (*ProjectContext, error) {
 	return background(workDir)
 }
 
+// preparePreview detects the project from the nearest existing directory, since the directories
+// are not created in preview mode, and the go.mod is staged instead of initialized
+func preparePreview(workDir string) (*ProjectContext, error) {
+	dir := workDir
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	ctx, err := background(dir)
+	if err != nil {
+		name := filepath.Base(workDir)
+		preview.Write(filepath.Join(workDir, "go.mod"), []byte(fmt.Sprintf("module %s\n", name)))
+		return &ProjectContext{
+			WorkDir: workDir,
+			Name:    name,
+			Path:    name,
+			Dir:     workDir,
+		}, nil
+	}
+
+	rel, err := filepath.Rel(dir, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.WorkDir = filepath.Join(ctx.WorkDir, rel)
+	return ctx, nil
+}
+
 func background(workDir string) (*ProjectContext, error) {
 	isGoMod, err := IsGoMod(workDir)
 	if err != nil {
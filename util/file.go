@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/logrusorgru/aurora"
+	"github.com/weitrue/goctl/util/preview"
 	"go.uber.org/atomic"
 )
 
@@ -31,6 +32,14 @@ func RegisterGoctlHome(home string) {
 
 // CreateIfNotExist creates a file if it is not exists
 func CreateIfNotExist(file string) (*os.File, error) {
+	if preview.Enabled() {
+		if preview.Exists(file) {
+			return nil, fmt.Errorf("%s already exist", file)
+		}
+
+		return preview.Create(file)
+	}
+
 	_, err := os.Stat(file)
 	if !os.IsNotExist(err) {
 		return nil, fmt.Errorf("%s already exist", file)
@@ -39,12 +48,27 @@ func CreateIfNotExist(file string) (*os.File, error) {
 	return os.Create(file)
 }
 
+// WriteFile writes data to the file, the file is staged instead in preview mode
+func WriteFile(file string, data []byte, perm os.FileMode) error {
+	if preview.Enabled() {
+		preview.Write(file, data)
+		return nil
+	}
+
+	return ioutil.WriteFile(file, data, perm)
+}
+
 // RemoveIfExist deletes the specified file if it is exists
 func RemoveIfExist(filename string) error {
 	if !FileExists(filename) {
 		return nil
 	}
 
+	if preview.Enabled() {
+		preview.Remove(filename)
+		return nil
+	}
+
 	return os.Remove(filename)
 }
 
@@ -63,6 +87,10 @@ func RemoveOrQuit(filename string) error {
 
 // FileExists returns true if the specified file is exists
 func FileExists(file string) bool {
+	if preview.Enabled() {
+		return preview.Exists(file)
+	}
+
 	_, err := os.Stat(file)
 	return err == nil
 }
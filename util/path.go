@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/weitrue/goctl/util/preview"
 	"github.com/weitrue/goctl/vars"
 )
 
@@ -22,7 +23,8 @@ func JoinPackages(pkgs ...string) string {
 
 // MkdirIfNotExist makes directories if the input path is not exists
 func MkdirIfNotExist(dir string) error {
-	if len(dir) == 0 {
+	// the directories are not created in preview mode, the files are staged only
+	if len(dir) == 0 || preview.Enabled() {
 		return nil
 	}
 
@@ -0,0 +1,331 @@
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/urfave/cli"
+)
+
+const (
+	actionCreate    = "create"
+	actionOverwrite = "overwrite"
+	actionUnchanged = "unchanged"
+	actionSkip      = "skip"
+	actionRemove    = "remove"
+)
+
+// Flags are the flags of preview mode, they are accepted by the commands of generation
+var Flags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "list the files which would be created, skipped or overwritten, nothing is written",
+	},
+	cli.BoolFlag{
+		Name:  "diff",
+		Usage: "like dry-run, and print the unified diffs against the files on disk",
+	},
+}
+
+type (
+	// file is the staged state of a file, the content is kept in memory or in a temporary file
+	file struct {
+		path    string
+		data    []byte
+		temp    string
+		written bool
+		removed bool
+	}
+
+	// Change describes what would happen to a file
+	Change struct {
+		Path   string
+		Action string
+		Diff   string
+	}
+)
+
+var (
+	lock     sync.Mutex
+	enabled  bool
+	showDiff bool
+	files    = make(map[string]*file)
+	order    []string
+)
+
+// Before enables the preview mode if --dry-run or --diff is set
+func Before(c *cli.Context) error {
+	diff := c.Bool("diff")
+	if diff || c.Bool("dry-run") {
+		Enable(diff)
+	}
+
+	return nil
+}
+
+// After prints the staged files and resets the preview mode
+func After(_ *cli.Context) error {
+	if !Enabled() {
+		return nil
+	}
+
+	defer Reset()
+	return Report(os.Stdout)
+}
+
+// Enable turns on the preview mode, the files are staged instead of written to disk
+func Enable(diff bool) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	enabled = true
+	showDiff = diff
+}
+
+// Enabled returns true if the preview mode is on
+func Enabled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return enabled
+}
+
+// Reset turns off the preview mode and drops the staged files
+func Reset() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, f := range files {
+		if len(f.temp) > 0 {
+			os.Remove(f.temp)
+		}
+	}
+	enabled = false
+	showDiff = false
+	files = make(map[string]*file)
+	order = nil
+}
+
+// Exists returns true if the file exists after the staged changes are applied
+func Exists(path string) bool {
+	lock.Lock()
+	f, ok := files[key(path)]
+	lock.Unlock()
+	if ok {
+		if f.written {
+			return true
+		}
+		if f.removed {
+			return false
+		}
+	}
+
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Create stages a file and returns a temporary file to write the content in
+func Create(path string) (*os.File, error) {
+	fp, err := ioutil.TempFile("", "goctl-preview-*")
+	if err != nil {
+		return nil, err
+	}
+
+	stage(path, func(f *file) {
+		f.temp = fp.Name()
+		f.data = nil
+		f.written = true
+	})
+	return fp, nil
+}
+
+// Write stages the content of file
+func Write(path string, data []byte) {
+	stage(path, func(f *file) {
+		f.data = data
+		f.temp = ""
+		f.written = true
+	})
+}
+
+// Remove stages the removal of file
+func Remove(path string) {
+	stage(path, func(f *file) {
+		f.data = nil
+		f.temp = ""
+		f.written = false
+		f.removed = true
+	})
+}
+
+// Skip records that the file exists and the generation is ignored
+func Skip(path string) {
+	// a file which is neither written nor removed is reported as skipped
+	stage(path, func(_ *file) {})
+}
+
+// Changes returns the staged changes in the order of generation
+func Changes() ([]Change, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	var changes []Change
+	for _, k := range order {
+		change, err := files[k].change()
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// Report writes the list of staged changes, and the diffs if --diff is set
+func Report(w io.Writer) error {
+	changes, err := Changes()
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	diff := showDiff
+	lock.Unlock()
+
+	fmt.Fprintln(w, aurora.Yellow("Dry run, no file is written."))
+	for _, each := range changes {
+		fmt.Fprintf(w, "%-10s %s\n", each.Action, each.Path)
+	}
+
+	if !diff {
+		return nil
+	}
+
+	for _, each := range changes {
+		if len(each.Diff) > 0 {
+			fmt.Fprint(w, each.Diff)
+		}
+	}
+
+	return nil
+}
+
+func (f *file) change() (Change, error) {
+	change := Change{Path: display(f.path)}
+	old, err := ioutil.ReadFile(f.path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return change, err
+	}
+
+	var current []byte
+	switch {
+	case f.written:
+		current, err = f.content()
+		if err != nil {
+			return change, err
+		}
+
+		switch {
+		case !exists:
+			change.Action = actionCreate
+		case bytes.Equal(old, current):
+			change.Action = actionUnchanged
+		default:
+			change.Action = actionOverwrite
+		}
+	case f.removed:
+		if !exists {
+			change.Action = actionUnchanged
+			return change, nil
+		}
+		change.Action = actionRemove
+	default:
+		change.Action = actionSkip
+		return change, nil
+	}
+
+	if change.Action == actionUnchanged {
+		return change, nil
+	}
+
+	from, to := "a/"+change.Path, "b/"+change.Path
+	if !exists {
+		from = os.DevNull
+	}
+	if change.Action == actionRemove {
+		to = os.DevNull
+	}
+	change.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(old),
+		B:        splitLines(current),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	return change, err
+}
+
+func (f *file) content() ([]byte, error) {
+	if len(f.temp) == 0 {
+		return f.data, nil
+	}
+
+	return ioutil.ReadFile(f.temp)
+}
+
+func stage(path string, fn func(f *file)) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	k := key(path)
+	f, ok := files[k]
+	if !ok {
+		f = &file{path: k}
+		files[k] = f
+		order = append(order, k)
+	}
+	fn(f)
+}
+
+func key(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return abs
+}
+
+// display returns the path relative to the working directory if it's inside
+func display(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+
+	return rel
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// SplitLines appends an empty line if the text ends with a new line
+	return difflib.SplitLines(strings.TrimSuffix(string(data), "\n"))
+}
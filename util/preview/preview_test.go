@@ -0,0 +1,75 @@
+package preview
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preview")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	existing := filepath.Join(dir, "existing.go")
+	assert.Nil(t, ioutil.WriteFile(existing, []byte("package a\n\nvar a = 1\n"), os.ModePerm))
+	same := filepath.Join(dir, "same.go")
+	assert.Nil(t, ioutil.WriteFile(same, []byte("package a\n"), os.ModePerm))
+	removed := filepath.Join(dir, "removed.go")
+	assert.Nil(t, ioutil.WriteFile(removed, []byte("package a\n"), os.ModePerm))
+
+	Enable(true)
+	defer Reset()
+	assert.True(t, Enabled())
+
+	created := filepath.Join(dir, "sub", "created.go")
+	fp, err := Create(created)
+	assert.Nil(t, err)
+	_, err = fp.WriteString("package sub\n")
+	assert.Nil(t, err)
+	assert.Nil(t, fp.Close())
+	assert.True(t, Exists(created))
+
+	Remove(existing)
+	assert.False(t, Exists(existing))
+	Write(existing, []byte("package a\n\nvar a = 2\n"))
+	assert.True(t, Exists(existing))
+	Write(same, []byte("package a\n"))
+	Skip(filepath.Join(dir, "skipped.go"))
+	Remove(removed)
+
+	changes, err := Changes()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(changes))
+
+	actions := make(map[string]string)
+	for _, each := range changes {
+		actions[filepath.Base(each.Path)] = each.Action
+	}
+	assert.Equal(t, map[string]string{
+		"created.go":  actionCreate,
+		"existing.go": actionOverwrite,
+		"same.go":     actionUnchanged,
+		"skipped.go":  actionSkip,
+		"removed.go":  actionRemove,
+	}, actions)
+
+	assert.Contains(t, changes[0].Diff, "+++ b/")
+	assert.Contains(t, changes[0].Diff, "+package sub\n")
+	assert.Contains(t, changes[1].Diff, "-var a = 1\n+var a = 2\n")
+	assert.Empty(t, changes[2].Diff)
+
+	content, err := ioutil.ReadFile(existing)
+	assert.Nil(t, err)
+	assert.Equal(t, "package a\n\nvar a = 1\n", string(content), "nothing is written to disk")
+	assert.NoDirExists(t, filepath.Join(dir, "sub"))
+
+	Reset()
+	assert.False(t, Enabled())
+	changes, err = Changes()
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
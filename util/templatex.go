@@ -3,10 +3,10 @@ package util
 import (
 	"bytes"
 	goformat "go/format"
-	"io/ioutil"
 	"text/template"
 
 	"github.com/weitrue/goctl/internal/errorx"
+	"github.com/weitrue/goctl/util/preview"
 )
 
 const regularPerm = 0o666
@@ -41,6 +41,9 @@ func (t *DefaultTemplate) GoFmt(format bool) *DefaultTemplate {
 // SaveTo writes the codes to the target path
 func (t *DefaultTemplate) SaveTo(data interface{}, path string, forceUpdate bool) error {
 	if FileExists(path) && !forceUpdate {
+		if preview.Enabled() {
+			preview.Skip(path)
+		}
 		return nil
 	}
 
@@ -49,7 +52,7 @@ func (t *DefaultTemplate) SaveTo(data interface{}, path string, forceUpdate bool
 		return err
 	}
 
-	return ioutil.WriteFile(path, output.Bytes(), regularPerm)
+	return WriteFile(path, output.Bytes(), regularPerm)
 }
 
 // Execute returns the codes after the template executed